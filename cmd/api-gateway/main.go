@@ -6,7 +6,6 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/gateway"
@@ -23,7 +22,7 @@ func main() {
 	}
 
 	// Initialize logger
-	logger := logger.New(cfg.LogLevel)
+	logger := logger.New(cfg.LogLevel, cfg.LogFormat)
 
 	// Set Gin mode
 	if cfg.Environment == "production" {
@@ -41,24 +40,36 @@ func main() {
 	defer cancel()
 
 	// Start gateway server using the new modular system
+	serverErr := make(chan error, 1)
 	go func() {
-		if err := gw.Start(ctx, cfg.Server.Address); err != nil {
-			logger.Fatal().Err(err).Msg("Failed to start gateway server")
-		}
+		serverErr <- gw.Start(ctx, cfg.Server.Address)
 	}()
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+
+	select {
+	case <-quit:
+	case err := <-serverErr:
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to start gateway server")
+		}
+		return
+	}
 
 	logger.Info().Msg("Shutting down server...")
 
-	// Cancel context to trigger graceful shutdown
-	cancel()
+	// Wait for in-flight requests to drain, bounded by the shutdown timeout
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownDuration())
+	defer shutdownCancel()
 
-	// Give some time for graceful shutdown
-	time.Sleep(2 * time.Second)
+	if err := gw.Stop(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("Server forced to shutdown")
+	}
+
+	// Stop background work tied to the server context
+	cancel()
 
 	logger.Info().Msg("Server exiting")
 }
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"news-aggregator/internal/collector/backfill"
+	"news-aggregator/internal/collector/jobs"
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/services"
+	"news-aggregator/pkg/logger"
+	"news-aggregator/pkg/queue"
+
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	sourceName := flag.String("source", "", "name of the configured source to backfill")
+	until := flag.String("until", "", "oldest publication date to collect (YYYY-MM-DD)")
+	maxPages := flag.Int("max-pages", 0, "maximum archive pages to fetch in this run (0 = no limit)")
+	restart := flag.Bool("restart", false, "ignore saved progress and start from the newest archive page")
+	contentHashes := flag.Bool("content-hashes", false, "set content_hash on stored articles that lack it, then exit")
+	flag.Parse()
+
+	if !*contentHashes && (*sourceName == "" || *until == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger
+	logger := logger.New(cfg.LogLevel, cfg.LogFormat)
+
+	if *contentHashes {
+		backfillContentHashes(cfg, logger)
+		return
+	}
+
+	untilDate, err := time.Parse("2006-01-02", *until)
+	if err != nil {
+		log.Fatalf("Invalid -until date: %v", err)
+	}
+
+	var sourceConfig *config.SourceConfig
+	for i := range cfg.Sources {
+		if cfg.Sources[i].Name == *sourceName {
+			sourceConfig = &cfg.Sources[i]
+			break
+		}
+	}
+	if sourceConfig == nil {
+		logger.Fatal().Str("source", *sourceName).Msg("Source not found in configuration")
+	}
+	if sourceConfig.UserAgent == "" {
+		sourceConfig.UserAgent = cfg.Collector.UserAgent
+	}
+	if sourceConfig.MaxRedirects == 0 {
+		sourceConfig.MaxRedirects = cfg.Collector.MaxRedirects
+	}
+
+	// Stop at the next archive page on interrupt; progress is saved per page
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// Initialize news service (needed for backfill progress)
+	newsService, err := services.NewNewsService(cfg, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize news service")
+	}
+	defer newsService.GetRepository().Close()
+
+	// Articles go through the same queue as collected ones
+	publisher, err := queue.NewRabbitMQPublisher(cfg.RabbitMQ.URL, cfg.RabbitMQ.Exchange)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create queue publisher")
+	}
+	defer publisher.Close()
+
+	processor := jobs.NewJobProcessor(logger, publisher, cfg.Collector.JobTimeout, jobs.RetryConfig{
+		MaxAttempts: cfg.Collector.RetryAttempts,
+		Delay:       cfg.Collector.RetryDelay,
+		Backoff:     1.5,
+	})
+
+	logger.Info().
+		Str("source", sourceConfig.Name).
+		Str("archive_url", sourceConfig.ArchiveURL).
+		Time("until", untilDate).
+		Msg("Starting backfill")
+
+	result, err := backfill.New(newsService, processor, logger).Run(ctx, *sourceConfig, backfill.Options{
+		Until:    untilDate,
+		MaxPages: *maxPages,
+		Restart:  *restart,
+	})
+	if result != nil {
+		logger.Info().
+			Str("source", sourceConfig.Name).
+			Int("pages", result.Pages).
+			Int("published", result.Published).
+			Int("too_old", result.TooOld).
+			Int("failed", result.Failed).
+			Bool("complete", result.Complete).
+			Msg("Backfill finished")
+	}
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Backfill stopped; run again to resume")
+	}
+}
+
+// backfillContentHashes fills content_hash on articles stored before it was
+// set consistently, so deduplication also matches them
+func backfillContentHashes(cfg *config.Config, logger zerolog.Logger) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	newsService, err := services.NewNewsService(cfg, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize news service")
+	}
+	defer newsService.GetRepository().Close()
+
+	logger.Info().Msg("Starting content hash backfill")
+
+	updated, err := newsService.GetRepository().BackfillContentHashes(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Int("updated", updated).Msg("Content hash backfill stopped; run again to resume")
+	}
+
+	logger.Info().Int("updated", updated).Msg("Content hash backfill finished")
+}
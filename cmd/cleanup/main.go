@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/services"
@@ -20,7 +21,7 @@ func main() {
 	}
 
 	// Initialize logger
-	logger := logger.New(cfg.LogLevel)
+	logger := logger.New(cfg.LogLevel, cfg.LogFormat)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -32,8 +33,16 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to initialize news service")
 	}
 
+	// Initialize search service (needed for index cleanup); cleanup still
+	// runs against the database when Elasticsearch is unavailable
+	searchService, err := services.NewSearchService(cfg, logger)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to initialize search service, search index will not be cleaned up")
+		searchService = nil
+	}
+
 	// Initialize cleanup service
-	cleanupService := services.NewCleanupService(cfg, logger, newsService)
+	cleanupService := services.NewCleanupService(cfg, logger, newsService, searchService)
 
 	// Start cleanup service
 	go func() {
@@ -51,7 +60,17 @@ func main() {
 	logger.Info().Msg("Shutting down cleanup service...")
 	cancel()
 
-	// Wait for graceful shutdown
-	cleanupService.Stop()
-	logger.Info().Msg("Cleanup service stopped")
+	// Block until the service has drained, bounded by the shutdown timeout
+	stopped := make(chan struct{})
+	go func() {
+		cleanupService.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info().Msg("Cleanup service stopped")
+	case <-time.After(cfg.Server.ShutdownDuration()):
+		logger.Warn().Msg("Timed out waiting for cleanup service to stop")
+	}
 }
@@ -6,10 +6,12 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"news-aggregator/internal/config"
 	"news-aggregator/internal/collector"
+	"news-aggregator/internal/config"
 	"news-aggregator/pkg/logger"
+	"news-aggregator/pkg/metrics"
 )
 
 func main() {
@@ -20,7 +22,7 @@ func main() {
 	}
 
 	// Initialize logger
-	logger := logger.New(cfg.LogLevel)
+	logger := logger.New(cfg.LogLevel, cfg.LogFormat)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -40,6 +42,11 @@ func main() {
 		}
 	}()
 
+	// Expose ingestion metrics
+	if cfg.Metrics.Enabled {
+		go metrics.Serve(ctx, cfg.Metrics.Port, cfg.Metrics.Path, logger)
+	}
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -48,7 +55,17 @@ func main() {
 	logger.Info().Msg("Shutting down collector service...")
 	cancel()
 
-	// Wait for graceful shutdown
-	collectorService.Stop()
-	logger.Info().Msg("Collector service stopped")
+	// Block until the service has drained, bounded by the shutdown timeout
+	stopped := make(chan struct{})
+	go func() {
+		collectorService.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info().Msg("Collector service stopped")
+	case <-time.After(cfg.Server.ShutdownDuration()):
+		logger.Warn().Msg("Timed out waiting for collector service to stop")
+	}
 }
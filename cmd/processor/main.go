@@ -10,6 +10,7 @@ import (
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/processor"
 	"news-aggregator/pkg/logger"
+	"news-aggregator/pkg/metrics"
 )
 
 func main() {
@@ -20,7 +21,7 @@ func main() {
 	}
 
 	// Initialize logger
-	logger := logger.New(cfg.LogLevel)
+	logger := logger.New(cfg.LogLevel, cfg.LogFormat)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -40,6 +41,11 @@ func main() {
 		}
 	}()
 
+	// Expose ingestion metrics
+	if cfg.Metrics.Enabled {
+		go metrics.Serve(ctx, cfg.Metrics.Port, cfg.Metrics.Path, logger)
+	}
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -48,7 +54,8 @@ func main() {
 	logger.Info().Msg("Shutting down processor service...")
 	cancel()
 
-	// Wait for graceful shutdown
+	// Block until the service has drained; Stop cancels the work still
+	// running once the shutdown timeout expires
 	processorService.Stop()
 	logger.Info().Msg("Processor service stopped")
 }
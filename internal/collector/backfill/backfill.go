@@ -0,0 +1,259 @@
+// Package backfill crawls a source's archive to collect articles older than
+// its current feed window.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"news-aggregator/internal/collector/jobs"
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/datasources"
+	"news-aggregator/internal/datasources/core"
+	"news-aggregator/internal/models"
+	"news-aggregator/internal/services"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	pagePlaceholder = "{page}"
+	datePlaceholder = "{date}"
+
+	// defaultDateFormat formats {date} when the source sets no format
+	defaultDateFormat = "2006-01-02"
+
+	// positionDateLayout stores the next date of a date-based backfill
+	positionDateLayout = "2006-01-02"
+)
+
+// ErrNoArchive is returned for sources without a usable archive_url
+var ErrNoArchive = errors.New("source has no archive_url with a {page} or {date} placeholder")
+
+// Options controls a backfill run
+type Options struct {
+	// Until is the oldest publication date to collect
+	Until time.Time
+
+	// MaxPages bounds the archive URLs fetched in one run (0 = no limit)
+	MaxPages int
+
+	// Restart ignores the saved position and starts from the newest archive
+	Restart bool
+}
+
+// Result summarizes a backfill run
+type Result struct {
+	Pages     int
+	Published int
+	TooOld    int
+	Failed    int
+	Complete  bool
+}
+
+// Backfiller fetches archive pages of a source and publishes their articles
+// to the processing pipeline the same way the collector does
+type Backfiller struct {
+	newsService *services.NewsService
+	processor   *jobs.JobProcessor
+	logger      zerolog.Logger
+}
+
+// New creates a Backfiller. Articles are published through the job
+// processor, so they are deduplicated, transformed and indexed as usual.
+func New(newsService *services.NewsService, processor *jobs.JobProcessor, logger zerolog.Logger) *Backfiller {
+	return &Backfiller{
+		newsService: newsService,
+		processor:   processor,
+		logger:      logger.With().Str("component", "backfill").Logger(),
+	}
+}
+
+// Run backfills the source back to opts.Until. Progress is saved after each
+// archive page, so an interrupted run resumes where it stopped.
+func (b *Backfiller) Run(ctx context.Context, sourceConfig config.SourceConfig, opts Options) (*Result, error) {
+	paged := strings.Contains(sourceConfig.ArchiveURL, pagePlaceholder)
+	dated := strings.Contains(sourceConfig.ArchiveURL, datePlaceholder)
+	if paged == dated {
+		return nil, ErrNoArchive
+	}
+
+	position := ""
+	if !opts.Restart {
+		saved, err := b.newsService.GetBackfillPosition(ctx, sourceConfig.Name)
+		if err != nil {
+			return nil, err
+		}
+		position = saved
+	}
+
+	// Pace archive requests with the source's rate limit
+	limiter := datasources.NewRateLimiter(float64(sourceConfig.RateLimit), 1, b.logger)
+
+	var result *Result
+	var err error
+	if paged {
+		result, err = b.runPaged(ctx, sourceConfig, opts, position, limiter)
+	} else {
+		result, err = b.runDated(ctx, sourceConfig, opts, position, limiter)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if result.Complete {
+		if err := b.newsService.ClearBackfillPosition(ctx, sourceConfig.Name); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// runPaged walks {page} from the saved page (or 1) until a page is empty or
+// holds only articles older than opts.Until
+func (b *Backfiller) runPaged(ctx context.Context, sourceConfig config.SourceConfig, opts Options, position string, limiter datasources.RateLimiter) (*Result, error) {
+	result := &Result{}
+
+	page := 1
+	if position != "" {
+		saved, err := strconv.Atoi(position)
+		if err != nil || saved < 1 {
+			return result, fmt.Errorf("invalid saved backfill page %q", position)
+		}
+		page = saved
+		b.logger.Info().Str("source", sourceConfig.Name).Int("page", page).Msg("Resuming backfill")
+	}
+
+	for ; opts.MaxPages == 0 || result.Pages < opts.MaxPages; page++ {
+		url := strings.ReplaceAll(sourceConfig.ArchiveURL, pagePlaceholder, strconv.Itoa(page))
+		items, err := b.fetch(ctx, sourceConfig, url, limiter)
+		if err != nil {
+			return result, err
+		}
+		result.Pages++
+
+		tooOld := b.publish(ctx, sourceConfig.Name, items, opts.Until, result)
+
+		if err := b.newsService.SaveBackfillPosition(ctx, sourceConfig.Name, strconv.Itoa(page+1)); err != nil {
+			return result, err
+		}
+
+		if len(items) == tooOld {
+			result.Complete = true
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// runDated walks {date} one day at a time from the saved date (or today)
+// back to opts.Until
+func (b *Backfiller) runDated(ctx context.Context, sourceConfig config.SourceConfig, opts Options, position string, limiter datasources.RateLimiter) (*Result, error) {
+	result := &Result{}
+
+	dateFormat := sourceConfig.ArchiveDateFormat
+	if dateFormat == "" {
+		dateFormat = defaultDateFormat
+	}
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	if position != "" {
+		saved, err := time.Parse(positionDateLayout, position)
+		if err != nil {
+			return result, fmt.Errorf("invalid saved backfill date %q", position)
+		}
+		day = saved
+		b.logger.Info().Str("source", sourceConfig.Name).Str("date", position).Msg("Resuming backfill")
+	}
+
+	for ; opts.MaxPages == 0 || result.Pages < opts.MaxPages; day = day.AddDate(0, 0, -1) {
+		if day.Before(opts.Until.Truncate(24 * time.Hour)) {
+			result.Complete = true
+			return result, nil
+		}
+
+		url := strings.ReplaceAll(sourceConfig.ArchiveURL, datePlaceholder, day.Format(dateFormat))
+		items, err := b.fetch(ctx, sourceConfig, url, limiter)
+		if err != nil {
+			// Days without an archive are common; move on to the previous one
+			var sourceErr *core.SourceError
+			if !errors.As(err, &sourceErr) || sourceErr.StatusCode != http.StatusNotFound {
+				return result, err
+			}
+			b.logger.Debug().Str("source", sourceConfig.Name).Str("url", url).Msg("No archive for day")
+		}
+		result.Pages++
+
+		b.publish(ctx, sourceConfig.Name, items, opts.Until, result)
+
+		next := day.AddDate(0, 0, -1).Format(positionDateLayout)
+		if err := b.newsService.SaveBackfillPosition(ctx, sourceConfig.Name, next); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// fetch retrieves the articles of one archive URL using the data source
+// implementation for the source's type
+func (b *Backfiller) fetch(ctx context.Context, sourceConfig config.SourceConfig, url string, limiter datasources.RateLimiter) ([]models.News, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	pageConfig := sourceConfig
+	pageConfig.URL = url
+	pageConfig.Enabled = true
+
+	var source datasources.DataSource
+	var err error
+	switch pageConfig.Type {
+	case "rss":
+		source, err = datasources.NewRSSSourceCompat(pageConfig, b.logger)
+	case "api":
+		source, err = datasources.NewAPISource(pageConfig, b.logger)
+	case "scraper":
+		source, err = datasources.NewScraperSource(pageConfig, b.logger)
+	default:
+		return nil, fmt.Errorf("unknown source type: %s", pageConfig.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source: %w", err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, datasources.FetchTimeout(pageConfig))
+	defer cancel()
+
+	b.logger.Info().Str("source", sourceConfig.Name).Str("url", url).Msg("Fetching archive page")
+	return source.Fetch(fetchCtx)
+}
+
+// publish hands the articles published since until to the processing
+// pipeline, adds the outcome to result and returns how many articles were
+// older than until
+func (b *Backfiller) publish(ctx context.Context, sourceName string, items []models.News, until time.Time, result *Result) int {
+	tooOld := 0
+	for _, item := range items {
+		if !item.PublishedAt.IsZero() && item.PublishedAt.Before(until) {
+			tooOld++
+			continue
+		}
+
+		jobResult := b.processor.ProcessJob(ctx, jobs.NewCollectionJob(sourceName, item))
+		if !jobResult.Success {
+			b.logger.Warn().Err(jobResult.Error).Str("source", sourceName).Str("title", item.Title).Msg("Failed to publish backfilled article")
+			result.Failed++
+			continue
+		}
+		result.Published++
+	}
+	result.TooOld += tooOld
+	return tooOld
+}
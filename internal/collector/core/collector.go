@@ -2,7 +2,11 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"sync"
 	"time"
 
 	"news-aggregator/internal/collector/jobs"
@@ -11,6 +15,10 @@ import (
 	"news-aggregator/internal/collector/workers"
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/datasources"
+	"news-aggregator/internal/db"
+	"news-aggregator/internal/models"
+	"news-aggregator/internal/services"
+	"news-aggregator/pkg/metrics"
 	"news-aggregator/pkg/queue"
 
 	"github.com/rs/zerolog"
@@ -27,6 +35,12 @@ type collector struct {
 	workerPool    workers.WorkerPool
 	scheduler     scheduling.JobScheduler
 
+	// newsService records per-source fetch outcomes; nil when the database
+	// is unavailable, in which case fetch statistics are not kept
+	newsService   *services.NewsService
+	sourceConfigs map[string]config.SourceConfig
+	sourcesMu     sync.RWMutex
+
 	// State
 	running bool
 }
@@ -56,10 +70,26 @@ func NewWithConfig(cfg *config.Config, logger zerolog.Logger, collectorConf conf
 		sourceManager: sourceManager,
 		workerPool:    workerPool,
 		scheduler:     scheduler,
+		sourceConfigs: make(map[string]config.SourceConfig),
+	}
+
+	sourceConfigs := make([]config.SourceConfig, 0, len(cfg.Sources))
+	for _, sourceConfig := range cfg.Sources {
+		sourceConfig = collector.withDefaults(sourceConfig)
+		collector.sourceConfigs[sourceConfig.Name] = sourceConfig
+		sourceConfigs = append(sourceConfigs, sourceConfig)
+	}
+
+	// Fetch statistics are best effort; collection works without a database
+	if pool, err := db.NewPool(cfg, logger); err != nil {
+		logger.Warn().Err(err).Msg("Failed to connect to database, source fetch statistics disabled")
+	} else if collector.newsService, err = services.NewNewsServiceWithPool(cfg, pool, logger); err != nil {
+		logger.Warn().Err(err).Msg("Failed to create news service, source fetch statistics disabled")
+		pool.Close()
 	}
 
 	// Initialize data sources
-	if err := collector.sourceManager.Initialize(cfg.Sources); err != nil {
+	if err := collector.sourceManager.Initialize(sourceConfigs); err != nil {
 		logger.Warn().Err(err).Msg("Some sources failed to initialize")
 		// Continue despite source initialization errors
 	}
@@ -88,6 +118,11 @@ func (c *collector) Start(ctx context.Context) error {
 	c.running = true
 	c.logger.Info().Msg("Collector service started successfully")
 
+	// Pick up source changes made through the admin API
+	if c.newsService != nil && c.collectorConf.SourceReloadInterval > 0 {
+		go c.reloadSourcesLoop(ctx)
+	}
+
 	// Wait for context cancellation
 	<-ctx.Done()
 	c.logger.Info().Msg("Collector service context cancelled")
@@ -127,6 +162,10 @@ func (c *collector) Stop() {
 	c.scheduler.Stop()
 	c.workerPool.Stop()
 
+	if c.newsService != nil {
+		c.newsService.GetRepository().Close()
+	}
+
 	c.running = false
 	c.logger.Info().Msg("Collector service stopped")
 }
@@ -138,18 +177,65 @@ func (c *collector) createCollectionHandler(ctx context.Context, sourceName stri
 	}
 }
 
+// fetchWithRetry fetches from a source, retrying transient failures with
+// exponential backoff up to the source's max_retries. Each attempt is bounded
+// by the source's timeout so a slow source cannot hold the collection for
+// longer than it is configured to.
+func (c *collector) fetchWithRetry(ctx context.Context, sourceName string, source datasources.DataSource) ([]models.News, error) {
+	c.sourcesMu.RLock()
+	sourceConfig := c.sourceConfigs[sourceName]
+	c.sourcesMu.RUnlock()
+
+	timeout := datasources.FetchTimeout(sourceConfig)
+	maxRetries, delay := datasources.FetchRetries(sourceConfig)
+
+	for attempt := 0; ; attempt++ {
+		fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+		items, err := source.Fetch(fetchCtx)
+		timedOut := errors.Is(fetchCtx.Err(), context.DeadlineExceeded)
+		cancel()
+		if err == nil {
+			return items, nil
+		}
+		if timedOut {
+			err = fmt.Errorf("fetch timed out after %v: %w", timeout, err)
+		}
+
+		if attempt >= maxRetries || !(timedOut || datasources.IsRetryable(err)) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		c.logger.Warn().
+			Err(err).
+			Str("source", sourceName).
+			Int("attempt", attempt+1).
+			Int("max_retries", maxRetries).
+			Dur("delay", delay).
+			Msg("Retrying fetch from source")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
 // collectFromSource performs data collection from a specific source
 func (c *collector) collectFromSource(ctx context.Context, sourceName string, source datasources.DataSource) {
 	c.logger.Debug().Str("source", sourceName).Msg("Starting collection from source")
 
 	startTime := time.Now()
 
-	// Fetch data from source
-	items, err := source.Fetch(ctx)
+	items, err := c.fetchWithRetry(ctx, sourceName, source)
+	c.recordFetch(ctx, sourceName, err)
 	if err != nil {
+		metrics.SourceFetchErrors.WithLabelValues(sourceName).Inc()
 		c.logger.Error().Err(err).Str("source", sourceName).Msg("Failed to fetch from source")
 		return
 	}
+	metrics.ArticlesFetched.WithLabelValues(sourceName).Add(float64(len(items)))
 
 	if len(items) == 0 {
 		c.logger.Debug().Str("source", sourceName).Msg("No new items from source")
@@ -172,6 +258,11 @@ func (c *collector) collectFromSource(ctx context.Context, sourceName string, so
 		successCount++
 	}
 
+	c.recordIngest(ctx, sourceName, models.SourceIngestCounts{
+		Fetched: int64(len(items)),
+		Failed:  int64(len(items) - successCount),
+	})
+
 	duration := time.Since(startTime)
 	c.logger.Info().
 		Str("source", sourceName).
@@ -181,15 +272,193 @@ func (c *collector) collectFromSource(ctx context.Context, sourceName string, so
 		Msg("Collection completed")
 }
 
+// recordIngest adds the articles of a fetch to the source's daily ingestion
+// statistics. Items that could not be handed to the worker pool count as
+// failed; the processor records the outcome of the others.
+func (c *collector) recordIngest(ctx context.Context, sourceName string, counts models.SourceIngestCounts) {
+	if c.newsService == nil {
+		return
+	}
+
+	if err := c.newsService.AddIngestCounts(ctx, sourceName, counts); err != nil {
+		c.logger.Warn().Err(err).Str("source", sourceName).Msg("Failed to record ingest statistics")
+	}
+}
+
+// recordFetch stores the outcome of a fetch in the sources table so broken
+// feeds show up in the admin source health summary, and disables a source
+// once its failure streak reaches collector.max_consecutive_failures.
+func (c *collector) recordFetch(ctx context.Context, sourceName string, fetchErr error) {
+	if c.newsService == nil {
+		return
+	}
+
+	c.sourcesMu.RLock()
+	sourceConfig, ok := c.sourceConfigs[sourceName]
+	c.sourcesMu.RUnlock()
+	if !ok {
+		return
+	}
+
+	source := &models.Source{
+		Name:      sourceConfig.Name,
+		Type:      sourceConfig.Type,
+		URL:       sourceConfig.URL,
+		Schedule:  sourceConfig.Schedule,
+		RateLimit: sourceConfig.RateLimit,
+		Enabled:   sourceConfig.Enabled,
+	}
+	failures, err := c.newsService.RecordSourceFetch(ctx, source, fetchErr)
+	if err != nil {
+		c.logger.Warn().Err(err).Str("source", sourceName).Msg("Failed to record source fetch")
+		return
+	}
+
+	limit := c.collectorConf.MaxConsecutiveFailures
+	if fetchErr == nil || limit <= 0 || failures < limit {
+		return
+	}
+
+	c.logger.Warn().
+		Str("source", sourceName).
+		Int("consecutive_failures", failures).
+		Msg("Disabling source after repeated fetch failures")
+
+	if err := c.newsService.DisableSource(ctx, sourceName); err != nil {
+		c.logger.Warn().Err(err).Str("source", sourceName).Msg("Failed to disable source")
+		return
+	}
+
+	sourceConfig.Enabled = false
+	c.sourcesMu.Lock()
+	c.sourceConfigs[sourceName] = sourceConfig
+	c.sourcesMu.Unlock()
+
+	if err := c.RemoveSource(sourceName); err != nil {
+		c.logger.Warn().Err(err).Str("source", sourceName).Msg("Failed to stop disabled source")
+	}
+}
+
+// reloadSourcesLoop periodically applies source changes from the database
+// until the context is cancelled.
+func (c *collector) reloadSourcesLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.collectorConf.SourceReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.reloadSources(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reloadSources re-reads schedule, rate limit, enabled and headers for the
+// configured sources from the sources table and recreates the sources that
+// changed, so their limiters, schedules and requests follow edits made
+// through the admin API.
+func (c *collector) reloadSources(ctx context.Context) {
+	stored, err := c.newsService.GetSources(ctx)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("Failed to reload sources")
+		return
+	}
+
+	for _, source := range stored {
+		c.sourcesMu.RLock()
+		current, ok := c.sourceConfigs[source.Name]
+		c.sourcesMu.RUnlock()
+		if !ok {
+			continue
+		}
+
+		headers := mergeHeaders(current.Headers, source.Headers)
+		if current.Schedule == source.Schedule && current.RateLimit == source.RateLimit &&
+			current.Enabled == source.Enabled && maps.Equal(current.Headers, headers) {
+			continue
+		}
+
+		updated := current
+		updated.Schedule = source.Schedule
+		updated.RateLimit = source.RateLimit
+		updated.Enabled = source.Enabled
+		updated.Headers = headers
+
+		if _, exists := c.sourceManager.GetSource(source.Name); exists {
+			if err := c.RemoveSource(source.Name); err != nil {
+				c.logger.Warn().Err(err).Str("source", source.Name).Msg("Failed to remove source for reload")
+				continue
+			}
+		}
+
+		c.sourcesMu.Lock()
+		c.sourceConfigs[source.Name] = updated
+		c.sourcesMu.Unlock()
+
+		if !updated.Enabled {
+			c.logger.Info().Str("source", source.Name).Msg("Source disabled")
+			continue
+		}
+
+		if err := c.AddSource(updated); err != nil {
+			c.logger.Warn().Err(err).Str("source", source.Name).Msg("Failed to reload source")
+			continue
+		}
+
+		c.logger.Info().
+			Str("source", source.Name).
+			Str("schedule", updated.Schedule).
+			Int("rate_limit", updated.RateLimit).
+			Msg("Source reloaded")
+	}
+}
+
+// withDefaults fills in collector-wide settings the source leaves unset and
+// canonicalizes its header names
+func (c *collector) withDefaults(sourceConfig config.SourceConfig) config.SourceConfig {
+	sourceConfig.Headers = mergeHeaders(sourceConfig.Headers, nil)
+	if sourceConfig.UserAgent == "" {
+		sourceConfig.UserAgent = c.collectorConf.UserAgent
+	}
+	if sourceConfig.MaxRedirects == 0 {
+		sourceConfig.MaxRedirects = c.collectorConf.MaxRedirects
+	}
+	return sourceConfig
+}
+
+// mergeHeaders returns the configured headers overlaid with the ones stored
+// on the source row. Stored headers only add or replace entries, since rows
+// created by the collector itself store none. Names are canonicalized so
+// that e.g. "user-agent" from the config file and "User-Agent" from the
+// admin API are the same header.
+func mergeHeaders(configured, stored map[string]string) map[string]string {
+	merged := make(map[string]string, len(configured)+len(stored))
+	for name, value := range configured {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+	for name, value := range stored {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+	return merged
+}
+
 // AddSource adds a new source to the collector
 func (c *collector) AddSource(sourceConfig config.SourceConfig) error {
 	c.logger.Info().Str("source", sourceConfig.Name).Msg("Adding new source")
 
+	sourceConfig = c.withDefaults(sourceConfig)
+
 	// Add source to manager
 	if err := c.sourceManager.AddSource(sourceConfig); err != nil {
 		return fmt.Errorf("failed to add source: %w", err)
 	}
 
+	c.sourcesMu.Lock()
+	c.sourceConfigs[sourceConfig.Name] = sourceConfig
+	c.sourcesMu.Unlock()
+
 	// If collector is running, schedule the new source
 	if c.running {
 		source, exists := c.sourceManager.GetSource(sourceConfig.Name)
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/datasources"
+	"news-aggregator/internal/models"
+
+	"github.com/rs/zerolog"
+)
+
+// blockingSource never answers on its own; Fetch only returns once its
+// context is done.
+type blockingSource struct {
+	calls atomic.Int32
+}
+
+func (s *blockingSource) Fetch(ctx context.Context) ([]models.News, error) {
+	s.calls.Add(1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s *blockingSource) GetSchedule() time.Duration         { return time.Minute }
+func (s *blockingSource) GetName() string                    { return "slow" }
+func (s *blockingSource) GetType() string                    { return "rss" }
+func (s *blockingSource) IsHealthy(ctx context.Context) bool { return true }
+func (s *blockingSource) Validate() error                    { return nil }
+
+func newTestCollector(sourceConfig config.SourceConfig) *collector {
+	return &collector{
+		logger:        zerolog.Nop(),
+		sourceConfigs: map[string]config.SourceConfig{sourceConfig.Name: sourceConfig},
+	}
+}
+
+func TestFetchWithRetryHonorsSourceTimeout(t *testing.T) {
+	noRetries := 0
+	c := newTestCollector(config.SourceConfig{
+		Name:       "slow",
+		Type:       "rss",
+		Timeout:    50 * time.Millisecond,
+		MaxRetries: &noRetries,
+	})
+	source := &blockingSource{}
+
+	start := time.Now()
+	_, err := c.fetchWithRetry(context.Background(), "slow", source)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("fetch took %v, want about 50ms", elapsed)
+	}
+	if calls := source.calls.Load(); calls != 1 {
+		t.Errorf("fetched %d times, want 1", calls)
+	}
+}
+
+func TestFetchWithRetryRetriesTimedOutFetches(t *testing.T) {
+	retries := 2
+	c := newTestCollector(config.SourceConfig{
+		Name:       "slow",
+		Type:       "rss",
+		Timeout:    20 * time.Millisecond,
+		MaxRetries: &retries,
+		RetryDelay: time.Millisecond,
+	})
+	source := &blockingSource{}
+
+	if _, err := c.fetchWithRetry(context.Background(), "slow", source); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if calls := source.calls.Load(); calls != 3 {
+		t.Errorf("fetched %d times, want 3", calls)
+	}
+}
+
+func TestFetchWithRetryTimesOutSlowFeed(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	noRetries := 0
+	sourceConfig := config.SourceConfig{
+		Name:       "slow",
+		Type:       "rss",
+		URL:        server.URL,
+		Enabled:    true,
+		Timeout:    100 * time.Millisecond,
+		MaxRetries: &noRetries,
+	}
+	source, err := datasources.NewRSSSourceCompat(sourceConfig, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	c := newTestCollector(sourceConfig)
+
+	start := time.Now()
+	if _, err := c.fetchWithRetry(context.Background(), "slow", source); err == nil {
+		t.Fatal("expected the fetch to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("fetch took %v, want about 100ms", elapsed)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"news-aggregator/internal/models"
+	loggerPkg "news-aggregator/pkg/logger"
 	"news-aggregator/pkg/queue"
 
 	"github.com/google/uuid"
@@ -223,13 +224,15 @@ func (jp *JobProcessor) ProcessJob(ctx context.Context, job *CollectionJob) *Job
 // processJobAttempt performs a single attempt to process the job
 func (jp *JobProcessor) processJobAttempt(ctx context.Context, job *CollectionJob) error {
 	// Create message for processing pipeline
+	// The correlation ID follows the article through processing and indexing
 	message := models.NewsMessage{
-		ID:        job.Item.ID,
-		Source:    job.Source,
-		Type:      "raw",
-		Data:      job.Item,
-		Timestamp: time.Now(),
-		Retry:     0,
+		ID:            job.Item.ID,
+		CorrelationID: uuid.New().String(),
+		Source:        job.Source,
+		Type:          "raw",
+		Data:          job.Item,
+		Timestamp:     time.Now(),
+		Retry:         0,
 	}
 
 	// Publish to message queue
@@ -237,6 +240,13 @@ func (jp *JobProcessor) processJobAttempt(ctx context.Context, job *CollectionJo
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
+	log := loggerPkg.WithCorrelationID(jp.logger, message.CorrelationID)
+	log.Debug().
+		Str("job_id", job.ID).
+		Str("source", job.Source).
+		Str("title", job.Item.Title).
+		Msg("Published article for processing")
+
 	return nil
 }
 
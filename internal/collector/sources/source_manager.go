@@ -7,6 +7,8 @@ import (
 
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/datasources"
+	"news-aggregator/internal/datasources/core"
+	"news-aggregator/internal/datasources/utils"
 
 	"github.com/rs/zerolog"
 )
@@ -163,6 +165,32 @@ func (sm *sourceManager) validateSourceConfig(config config.SourceConfig) error
 		return fmt.Errorf("rate limit cannot be negative")
 	}
 
+	// Validate timeout if provided
+	if config.Timeout != 0 {
+		if err := utils.ValidateTimeout(config.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+
+	// Validate retry settings if provided
+	if config.MaxRetries != nil || config.RetryDelay != 0 {
+		maxRetries, retryDelay := datasources.FetchRetries(config)
+		if err := utils.ValidateRetrySettings(maxRetries, retryDelay); err != nil {
+			return fmt.Errorf("invalid retry settings: %w", err)
+		}
+	}
+
+	// Inconsistent schedule and rate limit combinations are only warned about
+	// here so that existing configuration files keep loading
+	if schedule, err := time.ParseDuration(config.Schedule); err == nil {
+		if err := utils.ValidateScheduleRateLimit(core.SourceType(config.Type), schedule, float64(config.RateLimit)); err != nil {
+			sm.logger.Warn().
+				Err(err).
+				Str("source", config.Name).
+				Msg("Source schedule and rate limit are inconsistent")
+		}
+	}
+
 	return nil
 }
 
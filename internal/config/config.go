@@ -5,12 +5,15 @@ import (
 	"strings"
 	"time"
 
+	"news-aggregator/internal/models"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	Environment string       `mapstructure:"environment"`
 	LogLevel    string       `mapstructure:"log_level"`
+	LogFormat   string       `mapstructure:"log_format"` // json or console
 	Server      ServerConfig `mapstructure:"server"`
 	Database    DBConfig     `mapstructure:"database"`
 	Redis       RedisConfig  `mapstructure:"redis"`
@@ -21,6 +24,14 @@ type Config struct {
 	Sources     []SourceConfig `mapstructure:"sources"`
 	Collector   CollectorConfig `mapstructure:"collector"`
 	Metrics     MetricsConfig `mapstructure:"metrics"`
+	NLP         NLPConfig     `mapstructure:"nlp"`
+	Trending    TrendingConfig `mapstructure:"trending"`
+	Popular     PopularConfig  `mapstructure:"popular"`
+	Sentiment   SentimentConfig `mapstructure:"sentiment"`
+	Processor   ProcessorConfig `mapstructure:"processor"`
+	Cleanup     CleanupConfig   `mapstructure:"cleanup"`
+	SocialMedia SocialMediaConfig `mapstructure:"social_media"`
+	TopStories  models.TopStoriesConfig `mapstructure:"top_stories"`
 }
 
 type ServerConfig struct {
@@ -28,6 +39,87 @@ type ServerConfig struct {
 	ReadTimeout  int    `mapstructure:"read_timeout"`
 	WriteTimeout int    `mapstructure:"write_timeout"`
 	IdleTimeout  int    `mapstructure:"idle_timeout"`
+
+	// MaxHeaderBytes bounds the size of request headers the gateway reads
+	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
+
+	// ShutdownTimeout is how many seconds a service waits for in-flight work
+	// to drain on shutdown before giving up
+	ShutdownTimeout int `mapstructure:"shutdown_timeout"`
+
+	// Handlers lists the handler groups the gateway serves (auth, news, user,
+	// admin, health); only the services they need are constructed
+	Handlers []string `mapstructure:"handlers"`
+
+	// Cache sets the Cache-Control header per route class
+	Cache CacheConfig `mapstructure:"cache"`
+
+	// RequestTimeout is how many seconds a request may take before the
+	// gateway cancels it and answers 504 (0 disables the deadline). It must
+	// stay below WriteTimeout: the server drops the connection at that point
+	// and the 504 is never sent.
+	RequestTimeout int `mapstructure:"request_timeout"`
+
+	// LogBodies logs JSON and form request and response bodies at debug
+	// level with credentials redacted. It is ignored in the production
+	// environment.
+	LogBodies bool `mapstructure:"log_bodies"`
+
+	// APIKeys authenticate server-to-server clients on admin routes
+	// through the X-API-Key header instead of a user JWT
+	APIKeys []APIKeyConfig `mapstructure:"api_keys"`
+
+	// RequestIDHeader is the header request IDs are read from and returned in
+	RequestIDHeader string `mapstructure:"request_id_header"`
+
+	// HonorTraceparent uses the trace ID of an incoming W3C traceparent
+	// header as request ID when the request carries none
+	HonorTraceparent bool `mapstructure:"honor_traceparent"`
+
+	// Freshness labels articles in responses by age; empty keeps the
+	// built-in new/recent/today levels
+	Freshness []FreshnessConfig `mapstructure:"freshness"`
+
+	// Limits overrides the default and maximum result count of list
+	// endpoints (tags, trending, top_stories, top_scored, similar)
+	Limits map[string]LimitConfig `mapstructure:"limits"`
+}
+
+// FreshnessConfig labels articles published less than MaxAge ago
+type FreshnessConfig struct {
+	Label  string        `mapstructure:"label"`
+	MaxAge time.Duration `mapstructure:"max_age"`
+}
+
+// LimitConfig is the default and maximum result count of a list endpoint
+// (a Max of 0 leaves it unbounded)
+type LimitConfig struct {
+	Default int `mapstructure:"default"`
+	Max     int `mapstructure:"max"`
+}
+
+// APIKeyConfig is a static key of a server-to-server client. Scopes are
+// "admin" (all admin routes) or "admin:read" (GET and HEAD admin routes).
+type APIKeyConfig struct {
+	ClientID string   `mapstructure:"client_id"`
+	Key      string   `mapstructure:"key"`
+	Scopes   []string `mapstructure:"scopes"`
+}
+
+// CacheConfig holds Cache-Control values per route class. An empty value
+// leaves the header unset for that class.
+type CacheConfig struct {
+	Article string `mapstructure:"article"` // single articles by ID
+	Feed    string `mapstructure:"feed"`    // article lists, search and other public news routes
+	Private string `mapstructure:"private"` // auth, user, admin and health routes
+}
+
+// ShutdownDuration returns the shutdown timeout, defaulting to 30 seconds.
+func (s ServerConfig) ShutdownDuration() time.Duration {
+	if s.ShutdownTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(s.ShutdownTimeout) * time.Second
 }
 
 type DBConfig struct {
@@ -40,6 +132,11 @@ type DBConfig struct {
 	MaxConns     int    `mapstructure:"max_conns"`
 	MaxIdleConns int    `mapstructure:"max_idle_conns"`
 	MaxLifetime  int    `mapstructure:"max_lifetime"`
+
+	// ConnectRetries is how many times to try connecting at startup, and
+	// ConnectRetryInterval the initial backoff between attempts (doubled each time)
+	ConnectRetries       int           `mapstructure:"connect_retries"`
+	ConnectRetryInterval time.Duration `mapstructure:"connect_retry_interval"`
 }
 
 type RedisConfig struct {
@@ -61,6 +158,27 @@ type ElasticConfig struct {
 	Username  string   `mapstructure:"username"`
 	Password  string   `mapstructure:"password"`
 	Index     string   `mapstructure:"index"`
+	// Shards and Replicas are only applied when the index is created;
+	// changing them for an existing index requires a reindex
+	Shards   int `mapstructure:"shards"`
+	Replicas int `mapstructure:"replicas"`
+	// Synonyms are expanded at search time, one Solr-format rule per entry
+	// ("usa, united states" or "usa => united states"); like the shard
+	// settings they only apply when the index is created
+	Synonyms []string `mapstructure:"synonyms"`
+	// SearchWindow limits basic search to recently published articles; 0 disables it
+	SearchWindow time.Duration `mapstructure:"search_window"`
+
+	// PopularityWeight blends the synced article popularity into search
+	// relevance (0 disables it); PopularitySyncInterval is how often changed
+	// scores are copied into the index
+	PopularityWeight       float64       `mapstructure:"popularity_weight"`
+	PopularitySyncInterval time.Duration `mapstructure:"popularity_sync_interval"`
+
+	// ConnectRetries and ConnectRetryInterval bound the startup retries of
+	// index initialization while the cluster comes up
+	ConnectRetries       int           `mapstructure:"connect_retries"`
+	ConnectRetryInterval time.Duration `mapstructure:"connect_retry_interval"`
 }
 
 type RateLimitConfig struct {
@@ -79,10 +197,38 @@ type SourceConfig struct {
 	Name        string            `mapstructure:"name"`
 	Type        string            `mapstructure:"type"` // rss, api, scraper
 	URL         string            `mapstructure:"url"`
-	Schedule    string            `mapstructure:"schedule"`
-	RateLimit   int              `mapstructure:"rate_limit"`
+	Schedule    string            `mapstructure:"schedule"`   // time between polls
+	RateLimit   int              `mapstructure:"rate_limit"` // requests per second while polling
 	Headers     map[string]string `mapstructure:"headers"`
 	Enabled     bool             `mapstructure:"enabled"`
+
+	// Retention overrides cleanup.retention for this source's articles
+	Retention time.Duration `mapstructure:"retention"`
+
+	// Timeout bounds a single fetch of the source; zero uses the default
+	// for the source type
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxRetries is how often a fetch that failed with a transient error is
+	// retried (unset uses the default, 0 disables retries). RetryDelay is
+	// the wait before the first retry and doubles with each further one;
+	// zero uses the default for the source type.
+	MaxRetries *int          `mapstructure:"max_retries"`
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+
+	// ArchiveURL locates older articles for cmd/backfill. It contains either
+	// {page} for paginated archives (e.g. "https://example.com/feed/?paged={page}")
+	// or {date} for one archive per day, formatted with ArchiveDateFormat
+	// (default "2006-01-02")
+	ArchiveURL        string `mapstructure:"archive_url"`
+	ArchiveDateFormat string `mapstructure:"archive_date_format"`
+
+	// UserAgent overrides collector.user_agent for this source. A
+	// User-Agent entry in Headers takes precedence over both.
+	UserAgent string `mapstructure:"user_agent"`
+
+	// MaxRedirects overrides collector.max_redirects for this source
+	MaxRedirects int `mapstructure:"max_redirects"`
 }
 
 type CollectorConfig struct {
@@ -92,6 +238,21 @@ type CollectorConfig struct {
 	RetryAttempts   int           `mapstructure:"retry_attempts"`
 	RetryDelay      time.Duration `mapstructure:"retry_delay"`
 	MetricsEnabled  bool          `mapstructure:"metrics_enabled"`
+
+	// SourceReloadInterval is how often the collector re-reads schedule,
+	// rate limit and enabled from the sources table
+	SourceReloadInterval time.Duration `mapstructure:"source_reload_interval"`
+
+	// MaxConsecutiveFailures disables a source once this many fetches in a
+	// row have failed (0 never disables)
+	MaxConsecutiveFailures int `mapstructure:"max_consecutive_failures"`
+
+	// UserAgent is sent on every feed request of sources that set none
+	UserAgent string `mapstructure:"user_agent"`
+
+	// MaxRedirects bounds the redirects a feed request follows for sources
+	// that set none
+	MaxRedirects int `mapstructure:"max_redirects"`
 }
 
 type MetricsConfig struct {
@@ -100,6 +261,138 @@ type MetricsConfig struct {
 	Path    string `mapstructure:"path"`
 }
 
+type NLPConfig struct {
+	Provider string        `mapstructure:"provider"` // simple, llm
+	Endpoint string        `mapstructure:"endpoint"` // OpenAI-compatible base URL
+	APIKey   string        `mapstructure:"api_key"`
+	Model    string        `mapstructure:"model"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// StopWords adds words, keyed by language code, that keyword extraction
+	// skips on top of the built-in lists
+	StopWords map[string][]string `mapstructure:"stop_words"`
+}
+
+type TrendingConfig struct {
+	Window      time.Duration `mapstructure:"window"`       // recent period compared against the one before it
+	Decay       float64       `mapstructure:"decay"`        // exponential decay applied to mentions by age within the window
+	MinMentions int           `mapstructure:"min_mentions"` // minimum number of articles mentioning a topic for it to trend
+	Blocklist   []string      `mapstructure:"blocklist"`    // extra words never reported as topics, on top of the built-in list
+
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // how often cached trends are recomputed in the background
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`        // age at which a request recomputes cached trends itself
+}
+
+// PopularConfig defines how /news/popular ranks articles: by weighted
+// views, clicks and shares within the window, then by article score and
+// recency, so articles without engagement data fall back to newest first.
+type PopularConfig struct {
+	Window      time.Duration `mapstructure:"window"`       // only articles published this recently are ranked
+	ViewWeight  float64       `mapstructure:"view_weight"`  // weight of a view in the engagement total
+	ClickWeight float64       `mapstructure:"click_weight"` // weight of a click in the engagement total
+	ShareWeight float64       `mapstructure:"share_weight"` // weight of a share in the engagement total
+}
+
+// SentimentConfig defines the period /admin/sentiment/trends averages
+// article sentiment over when the request sets no window
+type SentimentConfig struct {
+	Window time.Duration `mapstructure:"window"` // articles published this recently are averaged
+}
+
+type ProcessorConfig struct {
+	// FallbackCategory is assigned when the classifier finds no matching
+	// keywords: a category name (e.g. "general", "uncategorized"), "source"
+	// to keep the feed-provided category, or "none" to leave it empty
+	FallbackCategory string `mapstructure:"fallback_category"`
+
+	// IndexLatencyThreshold is the p95 delay from publication until an
+	// article is searchable above which the processor logs a warning
+	// (0 disables the warning)
+	IndexLatencyThreshold time.Duration `mapstructure:"index_latency_threshold"`
+
+	// IndexDelay holds articles back from search until they are this old,
+	// then indexes the version stored at that time so early edits are
+	// picked up (0 indexes right away)
+	IndexDelay time.Duration `mapstructure:"index_delay"`
+
+	// RecoverPanics keeps a worker alive when processing an article panics;
+	// the article is dead-lettered instead. Disable it to crash on panics
+	// while debugging.
+	RecoverPanics bool `mapstructure:"recover_panics"`
+
+	// Workers is the number of articles processed concurrently
+	Workers int `mapstructure:"workers"`
+
+	// QueueSize is how many received articles may wait for a worker before
+	// new messages are rejected back to the queue
+	QueueSize int `mapstructure:"queue_size"`
+
+	// MaxContentLength truncates article content longer than this many
+	// characters at a sentence boundary (0 means unlimited)
+	MaxContentLength int `mapstructure:"max_content_length"`
+
+	// MaxFutureSkew is how far in the future a publication date may be
+	// before it is treated as a feed timezone bug and set to the current time
+	MaxFutureSkew time.Duration `mapstructure:"max_future_skew"`
+}
+
+type SocialMediaConfig struct {
+	Reddit RedditConfig `mapstructure:"reddit"`
+
+	// MaxConcurrent caps the outbound social platform requests in flight
+	// across all scoring workers
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+}
+
+// RedditConfig holds the credentials of a Reddit "script" or "web" app used
+// for app-only OAuth. Without a client ID, Reddit scores are simulated.
+type RedditConfig struct {
+	ClientID     string        `mapstructure:"client_id"`
+	ClientSecret string        `mapstructure:"client_secret"`
+	UserAgent    string        `mapstructure:"user_agent"` // Reddit rejects generic user agents
+	CacheTTL     time.Duration `mapstructure:"cache_ttl"`  // how long a URL's score is reused
+}
+
+type CleanupConfig struct {
+	// Retention is how long articles are kept after publication before the
+	// cleanup service removes them
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// DefaultArticleRetention is used when cleanup.retention is not set.
+const DefaultArticleRetention = 48 * time.Hour
+
+// ArticleRetention returns the global article retention period.
+func (c *Config) ArticleRetention() time.Duration {
+	if c.Cleanup.Retention <= 0 {
+		return DefaultArticleRetention
+	}
+	return c.Cleanup.Retention
+}
+
+// SourceRetentions returns the per-source retention overrides keyed by
+// source name.
+func (c *Config) SourceRetentions() map[string]time.Duration {
+	overrides := make(map[string]time.Duration)
+	for _, source := range c.Sources {
+		if source.Retention > 0 {
+			overrides[source.Name] = source.Retention
+		}
+	}
+	return overrides
+}
+
+// RetentionFor returns the effective retention for articles from the named
+// source.
+func (c *Config) RetentionFor(source string) time.Duration {
+	for _, s := range c.Sources {
+		if s.Name == source && s.Retention > 0 {
+			return s.Retention
+		}
+	}
+	return c.ArticleRetention()
+}
+
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -133,10 +426,21 @@ func setDefaults() {
 	// Server defaults
 	viper.SetDefault("environment", "development")
 	viper.SetDefault("log_level", "info")
+	viper.SetDefault("log_format", "json")
 	viper.SetDefault("server.address", ":8080")
 	viper.SetDefault("server.read_timeout", 30)
 	viper.SetDefault("server.write_timeout", 30)
 	viper.SetDefault("server.idle_timeout", 120)
+	viper.SetDefault("server.max_header_bytes", 1<<20)
+	viper.SetDefault("server.log_bodies", false)
+	viper.SetDefault("server.shutdown_timeout", 30)
+	viper.SetDefault("server.request_timeout", 25)
+	viper.SetDefault("server.request_id_header", "X-Request-ID")
+	viper.SetDefault("server.honor_traceparent", true)
+	viper.SetDefault("server.handlers", []string{"auth", "news", "user", "admin", "health"})
+	viper.SetDefault("server.cache.article", "public, max-age=300")
+	viper.SetDefault("server.cache.feed", "public, max-age=60")
+	viper.SetDefault("server.cache.private", "no-store")
 
 	// Database defaults
 	viper.SetDefault("database.host", "localhost")
@@ -148,6 +452,8 @@ func setDefaults() {
 	viper.SetDefault("database.max_conns", 25)
 	viper.SetDefault("database.max_idle_conns", 5)
 	viper.SetDefault("database.max_lifetime", 300)
+	viper.SetDefault("database.connect_retries", 5)
+	viper.SetDefault("database.connect_retry_interval", "1s")
 
 	// Redis defaults
 	viper.SetDefault("redis.address", "localhost:6379")
@@ -164,6 +470,13 @@ func setDefaults() {
 	// Elasticsearch defaults
 	viper.SetDefault("elasticsearch.addresses", []string{"http://localhost:9200"})
 	viper.SetDefault("elasticsearch.index", "news_articles")
+	viper.SetDefault("elasticsearch.shards", 1)
+	viper.SetDefault("elasticsearch.replicas", 0)
+	viper.SetDefault("elasticsearch.search_window", "168h")
+	viper.SetDefault("elasticsearch.popularity_weight", 0.0)
+	viper.SetDefault("elasticsearch.popularity_sync_interval", "10m")
+	viper.SetDefault("elasticsearch.connect_retries", 5)
+	viper.SetDefault("elasticsearch.connect_retry_interval", "1s")
 
 	// Rate limiting defaults
 	viper.SetDefault("rate_limit.requests_per_minute", 100)
@@ -182,9 +495,65 @@ func setDefaults() {
 	viper.SetDefault("collector.retry_attempts", 3)
 	viper.SetDefault("collector.retry_delay", "5s")
 	viper.SetDefault("collector.metrics_enabled", true)
+	viper.SetDefault("collector.source_reload_interval", "1m")
+	viper.SetDefault("collector.max_consecutive_failures", 10)
+	viper.SetDefault("collector.user_agent", "NewsAggregator/1.0 (+https://github.com/punitwa/newsss)")
+	viper.SetDefault("collector.max_redirects", 5)
 
 	// Metrics defaults
 	viper.SetDefault("metrics.enabled", true)
 	viper.SetDefault("metrics.port", ":9090")
 	viper.SetDefault("metrics.path", "/metrics")
+
+	// NLP defaults
+	viper.SetDefault("nlp.provider", "simple")
+	viper.SetDefault("nlp.endpoint", "https://api.openai.com/v1")
+	viper.SetDefault("nlp.model", "gpt-4o-mini")
+	viper.SetDefault("nlp.timeout", "10s")
+
+	// Top stories defaults
+	viper.SetDefault("top_stories.scoring_weights.engagement_weight", 0.25)
+	viper.SetDefault("top_stories.scoring_weights.credibility_weight", 0.30)
+	viper.SetDefault("top_stories.scoring_weights.content_weight", 0.20)
+	viper.SetDefault("top_stories.scoring_weights.social_weight", 0.15)
+	viper.SetDefault("top_stories.scoring_weights.recency_weight", 0.10)
+	viper.SetDefault("top_stories.min_score", 0.3)
+	viper.SetDefault("top_stories.max_age", "24h")
+	viper.SetDefault("top_stories.refresh_interval", "15m")
+
+	// Popular news defaults
+	viper.SetDefault("popular.window", "72h")
+	viper.SetDefault("popular.view_weight", 1.0)
+	viper.SetDefault("popular.click_weight", 2.0)
+	viper.SetDefault("popular.share_weight", 5.0)
+
+	// Sentiment trend defaults
+	viper.SetDefault("sentiment.window", "168h")
+
+	// Trending defaults
+	viper.SetDefault("trending.window", "24h")
+	viper.SetDefault("trending.decay", 1.0)
+	viper.SetDefault("trending.min_mentions", 3)
+	viper.SetDefault("trending.refresh_interval", "5m")
+	viper.SetDefault("trending.cache_ttl", "10m")
+
+	// Processor defaults
+	viper.SetDefault("processor.fallback_category", "general")
+	viper.SetDefault("processor.index_latency_threshold", "30m")
+	viper.SetDefault("processor.index_delay", "0s")
+	viper.SetDefault("processor.recover_panics", true)
+	viper.SetDefault("processor.workers", 5)
+	viper.SetDefault("processor.queue_size", 1000)
+	viper.SetDefault("processor.max_content_length", 0)
+	viper.SetDefault("processor.max_future_skew", "2h")
+
+	// Cleanup defaults
+	viper.SetDefault("cleanup.retention", "48h")
+
+	// Social media defaults
+	viper.SetDefault("social_media.max_concurrent", 4)
+	viper.SetDefault("social_media.reddit.client_id", "")
+	viper.SetDefault("social_media.reddit.client_secret", "")
+	viper.SetDefault("social_media.reddit.user_agent", "NewsAggregator/1.0 (by /u/newsaggregator)")
+	viper.SetDefault("social_media.reddit.cache_ttl", "15m")
 }
@@ -2,8 +2,12 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"syscall"
 )
 
 // Common errors for data sources
@@ -49,6 +53,12 @@ var (
 	
 	// ErrQuotaExceeded indicates API quota was exceeded
 	ErrQuotaExceeded = errors.New("API quota exceeded")
+	
+	// ErrResponseTooLarge indicates a response body exceeded the size limit
+	ErrResponseTooLarge = errors.New("response exceeds maximum size")
+	
+	// ErrTooManyRedirects indicates a request was redirected too often
+	ErrTooManyRedirects = errors.New("too many redirects")
 )
 
 // SourceError represents an error from a specific data source.
@@ -110,13 +120,31 @@ func NewSourceErrorWithCode(sourceName string, sourceType SourceType, operation
 	}
 }
 
+// IsRetryable reports whether a failed fetch is worth retrying: timeouts,
+// dropped connections and 5xx responses are, while 4xx responses and
+// parse errors are not.
+func IsRetryable(err error) bool {
+	var se *SourceError
+	if errors.As(err, &se) {
+		return se.Retryable
+	}
+	return isRetryableError(err)
+}
+
 // isRetryableError determines if an error is retryable.
 func isRetryableError(err error) bool {
 	if err == nil {
 		return false
 	}
 	
+	// A wrapped source error has already been classified
+	var se *SourceError
+	if errors.As(err, &se) {
+		return se.Retryable
+	}
+	
 	// Check for specific error types that are retryable
+	var netErr net.Error
 	switch {
 	case errors.Is(err, ErrFetchTimeout):
 		return true
@@ -124,6 +152,11 @@ func isRetryableError(err error) bool {
 		return true
 	case errors.Is(err, ErrRateLimitExceeded):
 		return true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED), errors.Is(err, syscall.EPIPE):
+		return true
 	default:
 		return false
 	}
@@ -143,6 +176,88 @@ func isRetryableHTTPError(statusCode int) bool {
 	}
 }
 
+// ErrorCategory classifies a source failure so operators can tell e.g. an
+// unreachable host from a malformed feed without reading the message.
+type ErrorCategory string
+
+// Error categories reported by ClassifyError.
+const (
+	ErrorCategoryNetwork    ErrorCategory = "network"    // DNS, refused or dropped connections
+	ErrorCategoryTimeout    ErrorCategory = "timeout"    // the source did not answer in time
+	ErrorCategoryHTTP       ErrorCategory = "http"       // the source answered with an error status
+	ErrorCategoryRateLimit  ErrorCategory = "rate_limit" // throttled by us or by the source
+	ErrorCategoryAuth       ErrorCategory = "auth"       // credentials missing or rejected
+	ErrorCategoryParse      ErrorCategory = "parse"      // the response is not a readable feed
+	ErrorCategoryValidation ErrorCategory = "validation" // the feed parsed but lacks required fields
+	ErrorCategoryUnknown    ErrorCategory = "unknown"
+)
+
+// ClassifyError returns the category of a fetch error, or an empty category
+// for a nil error.
+func ClassifyError(err error) ErrorCategory {
+	if err == nil {
+		return ""
+	}
+
+	var validationErr *ValidationError
+	var parsingErr *ParsingError
+	if errors.As(err, &validationErr) {
+		return ErrorCategoryValidation
+	}
+	if errors.As(err, &parsingErr) || errors.Is(err, ErrInvalidContent) || errors.Is(err, ErrNoContent) ||
+		errors.Is(err, ErrResponseTooLarge) {
+		return ErrorCategoryParse
+	}
+	if errors.Is(err, ErrTooManyRedirects) {
+		return ErrorCategoryHTTP
+	}
+
+	switch code := statusCode(err); {
+	case code == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimit
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case code == http.StatusRequestTimeout || code == http.StatusGatewayTimeout:
+		return ErrorCategoryTimeout
+	case code != 0:
+		return ErrorCategoryHTTP
+	}
+
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	var se *SourceError
+	switch {
+	case errors.Is(err, ErrRateLimitExceeded), errors.Is(err, ErrQuotaExceeded):
+		return ErrorCategoryRateLimit
+	case errors.Is(err, ErrAuthenticationFailed):
+		return ErrorCategoryAuth
+	case errors.Is(err, ErrFetchTimeout), errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorCategoryTimeout
+	case errors.Is(err, ErrNetworkError), errors.As(err, &dnsErr), errors.As(err, &netErr),
+		errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED), errors.Is(err, syscall.EPIPE):
+		return ErrorCategoryNetwork
+	case errors.As(err, &se) && se.Operation == "parse":
+		return ErrorCategoryParse
+	default:
+		return ErrorCategoryUnknown
+	}
+}
+
+// statusCode returns the first HTTP status code recorded on a SourceError
+// in err's chain. Sources wrap the HTTP client's error in their own
+// SourceError, so the outermost one usually carries no code.
+func statusCode(err error) int {
+	for err != nil {
+		if se, ok := err.(*SourceError); ok && se.StatusCode != 0 {
+			return se.StatusCode
+		}
+		err = errors.Unwrap(err)
+	}
+	return 0
+}
+
 // ValidationError represents a validation error.
 type ValidationError struct {
 	Field   string
@@ -90,6 +90,10 @@ type SourceConfig struct {
 	// UserAgent for HTTP requests
 	UserAgent string `json:"user_agent,omitempty" yaml:"user_agent,omitempty"`
 	
+	// MaxRedirects bounds the redirects followed per request (0 uses the
+	// HTTP client's default)
+	MaxRedirects int `json:"max_redirects,omitempty" yaml:"max_redirects,omitempty"`
+	
 	// Categories to filter content
 	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
 	
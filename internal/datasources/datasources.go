@@ -189,6 +189,41 @@ func IsValidSourceType(sourceType core.SourceType) bool {
 
 // Compatibility wrapper functions for the old config system
 
+// DefaultMaxRetries is how often a failed fetch is retried when the source
+// does not configure max_retries.
+const DefaultMaxRetries = 3
+
+// FetchRetries returns how many times a failed fetch of a configured source
+// is retried and the delay before the first retry, falling back to the
+// defaults for its type.
+func FetchRetries(sourceConfig config.SourceConfig) (int, time.Duration) {
+	maxRetries := DefaultMaxRetries
+	if sourceConfig.MaxRetries != nil {
+		maxRetries = *sourceConfig.MaxRetries
+	}
+
+	retryDelay := sourceConfig.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = factory.DefaultRetryDelayForType(core.SourceType(sourceConfig.Type))
+	}
+
+	return maxRetries, retryDelay
+}
+
+// IsRetryable reports whether a failed fetch is worth retrying.
+func IsRetryable(err error) bool {
+	return core.IsRetryable(err)
+}
+
+// FetchTimeout returns how long a single fetch of a configured source may
+// take, falling back to the default for its type when none is configured.
+func FetchTimeout(sourceConfig config.SourceConfig) time.Duration {
+	if sourceConfig.Timeout > 0 {
+		return sourceConfig.Timeout
+	}
+	return factory.DefaultTimeoutForType(core.SourceType(sourceConfig.Type))
+}
+
 // NewRSSSource creates a new RSS data source (compatibility wrapper)
 func NewRSSSourceCompat(sourceConfig config.SourceConfig, logger zerolog.Logger) (core.DataSource, error) {
 	// Parse schedule duration
@@ -201,14 +236,18 @@ func NewRSSSourceCompat(sourceConfig config.SourceConfig, logger zerolog.Logger)
 
 	// Convert config.SourceConfig to core.SourceConfig
 	coreConfig := core.SourceConfig{
-		Name:      sourceConfig.Name,
-		Type:      core.SourceType(sourceConfig.Type),
-		URL:       sourceConfig.URL,
-		Schedule:  scheduleDuration,
-		RateLimit: float64(sourceConfig.RateLimit),
-		Headers:   sourceConfig.Headers,
-		Enabled:   sourceConfig.Enabled,
+		Name:         sourceConfig.Name,
+		Type:         core.SourceType(sourceConfig.Type),
+		URL:          sourceConfig.URL,
+		Schedule:     scheduleDuration,
+		RateLimit:    float64(sourceConfig.RateLimit),
+		Headers:      sourceConfig.Headers,
+		Enabled:      sourceConfig.Enabled,
+		Timeout:      FetchTimeout(sourceConfig),
+		UserAgent:    sourceConfig.UserAgent,
+		MaxRedirects: sourceConfig.MaxRedirects,
 	}
+	coreConfig.MaxRetries, coreConfig.RetryDelay = FetchRetries(sourceConfig)
 
 	factory := factory.NewSourceFactory(logger)
 	return factory.CreateSource(coreConfig)
@@ -226,14 +265,18 @@ func NewAPISource(sourceConfig config.SourceConfig, logger zerolog.Logger) (core
 
 	// Convert config.SourceConfig to core.SourceConfig
 	coreConfig := core.SourceConfig{
-		Name:      sourceConfig.Name,
-		Type:      core.SourceType(sourceConfig.Type),
-		URL:       sourceConfig.URL,
-		Schedule:  scheduleDuration,
-		RateLimit: float64(sourceConfig.RateLimit),
-		Headers:   sourceConfig.Headers,
-		Enabled:   sourceConfig.Enabled,
+		Name:         sourceConfig.Name,
+		Type:         core.SourceType(sourceConfig.Type),
+		URL:          sourceConfig.URL,
+		Schedule:     scheduleDuration,
+		RateLimit:    float64(sourceConfig.RateLimit),
+		Headers:      sourceConfig.Headers,
+		Enabled:      sourceConfig.Enabled,
+		Timeout:      FetchTimeout(sourceConfig),
+		UserAgent:    sourceConfig.UserAgent,
+		MaxRedirects: sourceConfig.MaxRedirects,
 	}
+	coreConfig.MaxRetries, coreConfig.RetryDelay = FetchRetries(sourceConfig)
 
 	factory := factory.NewSourceFactory(logger)
 	return factory.CreateSource(coreConfig)
@@ -251,14 +294,18 @@ func NewScraperSource(sourceConfig config.SourceConfig, logger zerolog.Logger) (
 
 	// Convert config.SourceConfig to core.SourceConfig
 	coreConfig := core.SourceConfig{
-		Name:      sourceConfig.Name,
-		Type:      core.SourceType(sourceConfig.Type),
-		URL:       sourceConfig.URL,
-		Schedule:  scheduleDuration,
-		RateLimit: float64(sourceConfig.RateLimit),
-		Headers:   sourceConfig.Headers,
-		Enabled:   sourceConfig.Enabled,
+		Name:         sourceConfig.Name,
+		Type:         core.SourceType(sourceConfig.Type),
+		URL:          sourceConfig.URL,
+		Schedule:     scheduleDuration,
+		RateLimit:    float64(sourceConfig.RateLimit),
+		Headers:      sourceConfig.Headers,
+		Enabled:      sourceConfig.Enabled,
+		Timeout:      FetchTimeout(sourceConfig),
+		UserAgent:    sourceConfig.UserAgent,
+		MaxRedirects: sourceConfig.MaxRedirects,
 	}
+	coreConfig.MaxRetries, coreConfig.RetryDelay = FetchRetries(sourceConfig)
 
 	factory := factory.NewSourceFactory(logger)
 	return factory.CreateSource(coreConfig)
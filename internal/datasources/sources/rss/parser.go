@@ -173,6 +173,9 @@ func (p *Parser) parseItem(item *Item, channel *Channel, sourceName string) (*mo
 	if pubDate.IsZero() && item.DCDate != "" {
 		pubDate = p.parseDate(item.DCDate)
 	}
+	if pubDate.IsZero() {
+		pubDate = p.fallbackDate(item, channel)
+	}
 
 	// Extract and clean content
 	content := p.extractContent(item)
@@ -198,20 +201,23 @@ func (p *Parser) parseItem(item *Item, channel *Channel, sourceName string) (*mo
 	// Extract author
 	author := p.extractAuthor(item)
 
-	// Create news item
+	// Create news item; the feed's category is kept in SourceCategory since
+	// classification may later replace Category
+	feedCategory := strings.Join(categories, ", ")
 	newsItem := &models.News{
-		ID:          id,
-		Title:       strings.TrimSpace(html.UnescapeString(item.Title)),
-		Content:     content,
-		Summary:     description,
-		URL:         strings.TrimSpace(item.Link),
-		Author:      author,
-		PublishedAt: pubDate,
-		Category:    strings.Join(categories, ", "), // Convert slice to string
-		ImageURL:    imageURL,
-		Source:      sourceName,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:             id,
+		Title:          strings.TrimSpace(html.UnescapeString(item.Title)),
+		Content:        content,
+		Summary:        description,
+		URL:            strings.TrimSpace(item.Link),
+		Author:         author,
+		PublishedAt:    pubDate,
+		Category:       feedCategory,
+		SourceCategory: feedCategory,
+		ImageURL:       imageURL,
+		Source:         sourceName,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	// Validate the news item
@@ -222,6 +228,34 @@ func (p *Parser) parseItem(item *Item, channel *Channel, sourceName string) (*mo
 	return newsItem, nil
 }
 
+// fallbackDate returns the publication date of an item without a usable
+// date according to the DateFallback option.
+func (p *Parser) fallbackDate(item *Item, channel *Channel) time.Time {
+	var date time.Time
+	switch p.options.DateFallback {
+	case DateFallbackNone:
+		return date
+	case DateFallbackChannel:
+		date = p.parseDate(channel.PubDate)
+		if date.IsZero() {
+			date = p.parseDate(channel.LastBuildDate)
+		}
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	p.logger.Debug().
+		Str("item_title", item.Title).
+		Str("pub_date", item.PubDate).
+		Str("dc_date", item.DCDate).
+		Str("fallback", p.options.DateFallback).
+		Time("published_at", date).
+		Msg("Item has no usable publication date, using fallback")
+
+	return date
+}
+
 // extractContent extracts the main content from an RSS item.
 func (p *Parser) extractContent(item *Item) string {
 	// Priority order: content:encoded > description > title
@@ -4,6 +4,7 @@ package rss
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"news-aggregator/internal/datasources/core"
@@ -46,12 +47,15 @@ func NewSource(config core.SourceConfig, logger zerolog.Logger) (*Source, error)
 	// Create base source
 	baseSource := core.NewBaseSource(config, logger)
 
-	// Create HTTP client
+	// Create HTTP client. Oversized feeds are cut off while downloading
+	// instead of after the parser has the whole body in memory.
 	httpClient := utils.NewHTTPClient(
 		config.Timeout,
 		config.GetDefaultUserAgent(),
 		logger,
 	)
+	httpClient.SetMaxRedirects(config.MaxRedirects)
+	httpClient.SetMaxBodySize(MaxFeedSize)
 
 	// Create rate limiter
 	rateLimiter := utils.NewRateLimiter(
@@ -84,6 +88,21 @@ func NewSource(config core.SourceConfig, logger zerolog.Logger) (*Source, error)
 	return source, nil
 }
 
+// feedAccept asks for RSS and Atom first; some servers answer generic
+// clients with an HTML page or 403.
+const feedAccept = "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+
+// requestHeaders returns the headers sent with every feed request: an
+// Accept header suited to feeds, overridden by the source's own headers.
+func (s *Source) requestHeaders() map[string]string {
+	headers := make(map[string]string, len(s.config.Headers)+1)
+	headers["Accept"] = feedAccept
+	for name, value := range s.config.Headers {
+		headers[http.CanonicalHeaderKey(name)] = value
+	}
+	return headers
+}
+
 // Fetch retrieves and processes news items from the RSS feed.
 func (s *Source) Fetch(ctx context.Context) ([]models.News, error) {
 	if !s.IsEnabled() {
@@ -104,7 +123,7 @@ func (s *Source) Fetch(ctx context.Context) ([]models.News, error) {
 	}
 
 	// Fetch RSS feed content
-	content, err := s.httpClient.Get(ctx, s.config.URL, s.config.Headers)
+	content, err := s.httpClient.Get(ctx, s.config.URL, s.requestHeaders())
 	if err != nil {
 		responseTime := time.Since(startTime)
 		s.RecordFetchFailure(responseTime, err)
@@ -172,7 +191,7 @@ func (s *Source) IsHealthy(ctx context.Context) bool {
 	}
 
 	// Perform a GET request to check if the RSS feed is accessible
-	if _, err := s.httpClient.Get(ctx, s.config.URL, s.config.Headers); err != nil {
+	if _, err := s.httpClient.Get(ctx, s.config.URL, s.requestHeaders()); err != nil {
 		s.logger.Warn().Err(err).Msg("RSS source health check failed")
 		return false
 	}
@@ -183,7 +202,7 @@ func (s *Source) IsHealthy(ctx context.Context) bool {
 // GetMetadata returns metadata about the RSS feed.
 func (s *Source) GetMetadata(ctx context.Context) (*FeedMetadata, error) {
 	// Fetch RSS content
-	content, err := s.httpClient.Get(ctx, s.config.URL, s.config.Headers)
+	content, err := s.httpClient.Get(ctx, s.config.URL, s.requestHeaders())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch RSS feed for metadata: %w", err)
 	}
@@ -207,7 +226,7 @@ func (s *Source) ValidateFeed(ctx context.Context) (*ValidationResult, error) {
 	}
 
 	// Fetch RSS content
-	content, err := s.httpClient.Get(ctx, s.config.URL, s.config.Headers)
+	content, err := s.httpClient.Get(ctx, s.config.URL, s.requestHeaders())
 	if err != nil {
 		result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch feed: %v", err))
 		return result, nil
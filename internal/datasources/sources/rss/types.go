@@ -11,8 +11,15 @@ type Feed struct {
 	XMLName xml.Name `xml:"rss"`
 	Version string   `xml:"version,attr"`
 	Channel Channel  `xml:"channel"`
+
+	// ContentNamespace declares the content: prefix of Item.Content when
+	// marshaling; set it to ContentNamespaceURI
+	ContentNamespace string `xml:"xmlns:content,attr,omitempty"`
 }
 
+// ContentNamespaceURI is the RSS content module namespace.
+const ContentNamespaceURI = "http://purl.org/rss/1.0/modules/content/"
+
 // Channel represents an RSS channel.
 type Channel struct {
 	Title          string `xml:"title"`
@@ -35,11 +42,11 @@ type Channel struct {
 type Item struct {
 	Title       string         `xml:"title"`
 	Description string         `xml:"description"`
-	Content     string         `xml:"content:encoded"`
+	Content     string         `xml:"content:encoded,omitempty"`
 	Link        string         `xml:"link"`
 	GUID        *GUID          `xml:"guid"`
-	PubDate     string         `xml:"pubDate"`
-	Author      string         `xml:"author"`
+	PubDate     string         `xml:"pubDate,omitempty"`
+	Author      string         `xml:"author,omitempty"`
 	Category    []Category     `xml:"category"`
 	Comments    string         `xml:"comments,omitempty"`
 	Enclosure   *Enclosure     `xml:"enclosure,omitempty"`
@@ -56,10 +63,11 @@ type Item struct {
 	MediaDescription string           `xml:"http://search.yahoo.com/mrss/ description,omitempty"`
 }
 
-// GUID represents an RSS item GUID.
+// GUID represents an RSS item GUID. IsPermaLink holds the attribute as
+// written, "true" or "false"; RSS treats a missing attribute as "true".
 type GUID struct {
 	Value       string `xml:",chardata"`
-	IsPermaLink bool   `xml:"isPermaLink,attr,omitempty"`
+	IsPermaLink string `xml:"isPermaLink,attr,omitempty"`
 }
 
 // Category represents an RSS category.
@@ -69,10 +77,11 @@ type Category struct {
 }
 
 // Enclosure represents an RSS enclosure (typically for media files).
+// Length is required by RSS 2.0; 0 means the size is unknown.
 type Enclosure struct {
 	URL    string `xml:"url,attr"`
 	Type   string `xml:"type,attr"`
-	Length int64  `xml:"length,attr,omitempty"`
+	Length int64  `xml:"length,attr"`
 }
 
 // ChannelSource represents an RSS channel source element.
@@ -169,8 +178,26 @@ type ParsingOptions struct {
 
 	// MinContentLength filters out items with content shorter than this
 	MinContentLength int `json:"min_content_length"`
+
+	// DateFallback chooses the publication date of items whose date is
+	// missing or unparseable: DateFallbackNow, DateFallbackChannel or
+	// DateFallbackNone
+	DateFallback string `json:"date_fallback"`
 }
 
+// Publication date fallbacks for items without a usable date.
+const (
+	// DateFallbackNow uses the time the feed was parsed
+	DateFallbackNow = "now"
+
+	// DateFallbackChannel uses the channel's pubDate or lastBuildDate,
+	// then the parse time when the channel has neither
+	DateFallbackChannel = "channel"
+
+	// DateFallbackNone leaves the date zero
+	DateFallbackNone = "none"
+)
+
 // DefaultParsingOptions returns default parsing options for RSS feeds.
 func DefaultParsingOptions() ParsingOptions {
 	return ParsingOptions{
@@ -181,6 +208,7 @@ func DefaultParsingOptions() ParsingOptions {
 		ParseDates:       true,
 		FilterDuplicates: true,
 		MinContentLength: 50,
+		DateFallback:     DateFallbackNow,
 	}
 }
 
@@ -2,7 +2,10 @@
 package utils
 
 import (
+	"bufio"
+	"compress/flate"
 	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"fmt"
 	"io"
@@ -15,16 +18,22 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// DefaultMaxRedirects is how many redirects a request follows unless
+// SetMaxRedirects is called.
+const DefaultMaxRedirects = 10
+
 // HTTPClient provides HTTP functionality for data sources.
 type HTTPClient struct {
-	client    *http.Client
-	userAgent string
-	logger    zerolog.Logger
+	client       *http.Client
+	userAgent    string
+	maxRedirects int
+	maxBodySize  int64
+	logger       zerolog.Logger
 }
 
 // NewHTTPClient creates a new HTTP client with the specified configuration.
 func NewHTTPClient(timeout time.Duration, userAgent string, logger zerolog.Logger) *HTTPClient {
-	return &HTTPClient{
+	hc := &HTTPClient{
 		client: &http.Client{
 			Timeout: timeout,
 			Transport: &http.Transport{
@@ -33,9 +42,20 @@ func NewHTTPClient(timeout time.Duration, userAgent string, logger zerolog.Logge
 				IdleConnTimeout:     30 * time.Second,
 			},
 		},
-		userAgent: userAgent,
-		logger:    logger.With().Str("component", "http_client").Logger(),
+		userAgent:    userAgent,
+		maxRedirects: DefaultMaxRedirects,
+		logger:       logger.With().Str("component", "http_client").Logger(),
+	}
+	hc.client.CheckRedirect = hc.checkRedirect
+	return hc
+}
+
+// checkRedirect stops following redirects after maxRedirects hops
+func (hc *HTTPClient) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > hc.maxRedirects {
+		return fmt.Errorf("%w: stopped after %d", core.ErrTooManyRedirects, hc.maxRedirects)
 	}
+	return nil
 }
 
 // Get performs a GET request with the specified headers.
@@ -74,24 +94,28 @@ func (hc *HTTPClient) Get(ctx context.Context, url string, headers map[string]st
 			fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status), resp.StatusCode)
 	}
 	
-	// Handle response body
-	var reader io.Reader = resp.Body
-	
-	// Handle gzip encoding
-	if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
-		gzipReader, err := gzip.NewReader(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
-		}
-		defer gzipReader.Close()
-		reader = gzipReader
+	// Handle compressed responses
+	reader, err := decodeBody(resp)
+	if err != nil {
+		return nil, err
 	}
+	defer reader.Close()
 	
-	// Read response body
-	body, err := io.ReadAll(reader)
+	// Read response body, giving up as soon as it exceeds the size limit
+	// rather than after downloading it all; the limit applies to the
+	// decompressed bytes
+	var limited io.Reader = reader
+	if hc.maxBodySize > 0 {
+		limited = io.LimitReader(reader, hc.maxBodySize+1)
+	}
+	body, err := io.ReadAll(limited)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	if hc.maxBodySize > 0 && int64(len(body)) > hc.maxBodySize {
+		return nil, core.NewSourceError("http_client", core.SourceTypeAPI, "request",
+			fmt.Errorf("%w of %d bytes", core.ErrResponseTooLarge, hc.maxBodySize))
+	}
 	
 	hc.logger.Debug().
 		Str("url", url).
@@ -102,6 +126,39 @@ func (hc *HTTPClient) Get(ctx context.Context, url string, headers map[string]st
 	return body, nil
 }
 
+// decodeBody returns the response body decompressed according to its
+// Content-Encoding. We ask for gzip and deflate ourselves, so the transport
+// leaves decompression to us.
+func decodeBody(resp *http.Response) (io.ReadCloser, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+
+	switch {
+	case strings.Contains(encoding, "gzip"):
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		return gzipReader, nil
+
+	case strings.Contains(encoding, "deflate"):
+		// "deflate" should be zlib-wrapped, but some servers send raw
+		// deflate data; tell them apart by the zlib header
+		buffered := bufio.NewReader(resp.Body)
+		header, _ := buffered.Peek(2)
+		if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			zlibReader, err := zlib.NewReader(buffered)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create deflate reader: %w", err)
+			}
+			return zlibReader, nil
+		}
+		return flate.NewReader(buffered), nil
+
+	default:
+		return io.NopCloser(resp.Body), nil
+	}
+}
+
 // Post performs a POST request with the specified body and headers.
 func (hc *HTTPClient) Post(ctx context.Context, url string, body []byte, headers map[string]string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(body)))
@@ -162,6 +219,21 @@ func (hc *HTTPClient) SetUserAgent(userAgent string) {
 	hc.userAgent = userAgent
 }
 
+// SetMaxRedirects updates how many redirects a request follows; zero or
+// less restores DefaultMaxRedirects.
+func (hc *HTTPClient) SetMaxRedirects(maxRedirects int) {
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	hc.maxRedirects = maxRedirects
+}
+
+// SetMaxBodySize limits the bytes read from a GET response; larger bodies
+// fail with core.ErrResponseTooLarge. Zero means unlimited.
+func (hc *HTTPClient) SetMaxBodySize(maxBodySize int64) {
+	hc.maxBodySize = maxBodySize
+}
+
 // Head performs a HEAD request to check if a resource exists.
 func (hc *HTTPClient) Head(ctx context.Context, url string, headers map[string]string) error {
 	req, err := http.NewRequestWithContext(ctx, "HEAD", url, nil)
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/datasources/core"
+
+	"github.com/rs/zerolog"
+)
+
+const feedFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Example News</title>
+    <link>https://example.com</link>
+    <item>
+      <title>Compressed feeds are decoded</title>
+      <link>https://example.com/compressed</link>
+      <pubDate>Mon, 02 Jan 2006 15:04:05 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+// compress encodes data with the given writer constructor
+func compress(t *testing.T, data string, newWriter func(io.Writer) (io.WriteCloser, error)) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := newWriter(&buf)
+	if err != nil {
+		t.Fatalf("failed to create writer: %v", err)
+	}
+	if _, err := io.WriteString(w, data); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// serveFeed serves body with the given Content-Encoding
+func serveFeed(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		if encoding != "" {
+			w.Header().Set("Content-Encoding", encoding)
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestGetDecodesCompressedFeeds(t *testing.T) {
+	tests := map[string]struct {
+		encoding string
+		body     []byte
+	}{
+		"identity": {"", []byte(feedFixture)},
+		"gzip": {"gzip", compress(t, feedFixture, func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		})},
+		"x-gzip": {"x-gzip", compress(t, feedFixture, func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		})},
+		"zlib deflate": {"deflate", compress(t, feedFixture, func(w io.Writer) (io.WriteCloser, error) {
+			return zlib.NewWriter(w), nil
+		})},
+		"raw deflate": {"deflate", compress(t, feedFixture, func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.DefaultCompression)
+		})},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			url := serveFeed(t, tt.encoding, tt.body)
+			client := NewHTTPClient(5*time.Second, "test", zerolog.Nop())
+
+			body, err := client.Get(context.Background(), url, nil)
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if string(body) != feedFixture {
+				t.Errorf("body = %q, want the feed fixture", body)
+			}
+		})
+	}
+}
+
+func TestGetRejectsCorruptGzip(t *testing.T) {
+	url := serveFeed(t, "gzip", []byte(feedFixture))
+	client := NewHTTPClient(5*time.Second, "test", zerolog.Nop())
+
+	if _, err := client.Get(context.Background(), url, nil); err == nil {
+		t.Fatal("expected an error for a body that is not gzip")
+	}
+}
+
+func TestGetLimitsDecompressedSize(t *testing.T) {
+	body := compress(t, feedFixture, func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	})
+	url := serveFeed(t, "gzip", body)
+
+	// The compressed body fits the limit but the feed does not
+	client := NewHTTPClient(5*time.Second, "test", zerolog.Nop())
+	client.SetMaxBodySize(int64(len(feedFixture) - 1))
+	if int64(len(body)) > int64(len(feedFixture)-1) {
+		t.Fatalf("fixture compresses to %d bytes, too large for this test", len(body))
+	}
+
+	if _, err := client.Get(context.Background(), url, nil); !errors.Is(err, core.ErrResponseTooLarge) {
+		t.Errorf("err = %v, want ErrResponseTooLarge", err)
+	}
+}
@@ -51,6 +51,11 @@ func ValidateSourceConfig(config *core.SourceConfig) []error {
 	if err := ValidateRateLimit(config.RateLimit); err != nil {
 		errors = append(errors, err)
 	}
+
+	// Validate that schedule and rate limit fit together
+	if err := ValidateScheduleRateLimit(config.Type, config.Schedule, config.RateLimit); err != nil {
+		errors = append(errors, err)
+	}
 	
 	// Validate timeout
 	if err := ValidateTimeout(config.Timeout); err != nil {
@@ -166,6 +171,51 @@ func ValidateRateLimit(rateLimit float64) error {
 	return nil
 }
 
+// Rate limit semantics: the schedule is the time between two polls of a
+// source, and rate_limit is the maximum number of HTTP requests per second
+// the collector sends to the source while a poll is in progress. A zero
+// rate limit means the limiter default of one request per second.
+
+// maxRateLimitForType caps the rate limit per source type. One poll of an RSS
+// feed is a single request, so anything above a few requests per second only
+// matters for the requests an API or scraper makes within one poll.
+var maxRateLimitForType = map[core.SourceType]float64{
+	core.SourceTypeRSS:     10,
+	core.SourceTypeAPI:     20,
+	core.SourceTypeScraper: 2,
+}
+
+// requestsPerPollForType estimates how many requests one poll makes.
+var requestsPerPollForType = map[core.SourceType]float64{
+	core.SourceTypeRSS:     1,
+	core.SourceTypeAPI:     10,
+	core.SourceTypeScraper: 50,
+}
+
+// ValidateScheduleRateLimit rejects schedule and rate limit combinations that
+// contradict each other: a rate limit higher than the source type can use,
+// or one so low that a poll could not finish before the next one starts.
+func ValidateScheduleRateLimit(sourceType core.SourceType, schedule time.Duration, rateLimit float64) error {
+	if rateLimit == 0 {
+		rateLimit = 1
+	}
+
+	if max, ok := maxRateLimitForType[sourceType]; ok && rateLimit > max {
+		return core.NewValidationError("rate_limit", rateLimit,
+			fmt.Sprintf("rate limit too high for %s sources (maximum: %.1f requests per second while polling)", sourceType, max))
+	}
+
+	if perPoll, ok := requestsPerPollForType[sourceType]; ok && schedule > 0 {
+		pollDuration := time.Duration(perPoll / rateLimit * float64(time.Second))
+		if pollDuration > schedule {
+			return core.NewValidationError("rate_limit", rateLimit,
+				fmt.Sprintf("rate limit too low for a %v schedule: a poll of about %.0f requests would take %v", schedule, perPoll, pollDuration))
+		}
+	}
+
+	return nil
+}
+
 // ValidateTimeout validates a timeout duration.
 func ValidateTimeout(timeout time.Duration) error {
 	if timeout <= 0 {
@@ -0,0 +1,64 @@
+// Package db provides the shared PostgreSQL connection pool.
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"news-aggregator/internal/config"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+// NewPool creates a connection pool from the database configuration and
+// verifies it with a ping. Postgres may still be starting (e.g. under
+// docker-compose), so pool creation and the ping are retried with backoff.
+// The pool is meant to be shared by all repositories of a process.
+func NewPool(cfg *config.Config, logger zerolog.Logger) (*pgxpool.Pool, error) {
+	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Database,
+		cfg.Database.SSLMode,
+	)
+
+	poolConfig, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+
+	if cfg.Database.MaxConns > 0 {
+		poolConfig.MaxConns = int32(cfg.Database.MaxConns)
+	}
+	poolConfig.MinConns = int32(cfg.Database.MaxIdleConns)
+	if cfg.Database.MaxLifetime > 0 {
+		poolConfig.MaxConnLifetime = time.Duration(cfg.Database.MaxLifetime) * time.Second
+	}
+
+	logger = logger.With().Str("component", "database").Logger()
+
+	var pool *pgxpool.Pool
+	err = RetryWithBackoff(context.Background(), logger, "database",
+		cfg.Database.ConnectRetries, cfg.Database.ConnectRetryInterval, func() error {
+			p, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create database pool: %w", err)
+			}
+			if err := p.Ping(context.Background()); err != nil {
+				p.Close()
+				return fmt.Errorf("failed to ping database: %w", err)
+			}
+			pool = p
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().Int32("max_conns", poolConfig.MaxConns).Msg("Database pool created")
+	return pool, nil
+}
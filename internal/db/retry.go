@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxRetryInterval caps the backoff between startup connection attempts.
+const maxRetryInterval = 30 * time.Second
+
+// RetryWithBackoff runs connect until it succeeds or attempts are exhausted,
+// doubling the wait between attempts. It lets services survive dependencies
+// (Postgres, Elasticsearch) that are still starting up.
+func RetryWithBackoff(ctx context.Context, logger zerolog.Logger, target string, attempts int, interval time.Duration, connect func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = connect(); err == nil {
+			if attempt > 1 {
+				logger.Info().Str("target", target).Int("attempt", attempt).Msg("Connected after retrying")
+			}
+			return nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		logger.Warn().
+			Err(err).
+			Str("target", target).
+			Int("attempt", attempt).
+			Int("max_attempts", attempts).
+			Dur("retry_in", interval).
+			Msg("Connection attempt failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+
+	return fmt.Errorf("%s unavailable after %d attempts: %w", target, attempts, err)
+}
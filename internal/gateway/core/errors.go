@@ -2,6 +2,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -275,6 +276,12 @@ func MapErrorToHTTPStatus(err error) int {
 	}
 	
 	switch {
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+		
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+		
 	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrInvalidToken), 
 		 errors.Is(err, ErrTokenExpired), errors.Is(err, ErrInvalidCredentials):
 		return http.StatusUnauthorized
@@ -313,6 +320,12 @@ func MapErrorToCode(err error) string {
 	}
 	
 	switch {
+	case errors.Is(err, context.Canceled):
+		return CodeClientClosed
+		
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+		
 	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrInvalidToken), 
 		 errors.Is(err, ErrTokenExpired), errors.Is(err, ErrInvalidCredentials):
 		return CodeUnauthorized
@@ -343,6 +356,12 @@ func MapErrorToCode(err error) string {
 	}
 }
 
+// IsContextError checks if an error was caused by request cancellation or
+// deadline expiry rather than a failure on our side.
+func IsContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
 // IsRetryableError checks if an error is retryable.
 func IsRetryableError(err error) bool {
 	if gatewayErr, ok := err.(*GatewayError); ok {
@@ -3,6 +3,7 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/services"
@@ -220,6 +221,10 @@ type ResponseWriter interface {
 
 	// Accepted writes an accepted response for async operations
 	Accepted(c *gin.Context, data interface{})
+
+	// SuccessWithStatus writes a successful response with the given status,
+	// e.g. 207 when only part of an operation succeeded
+	SuccessWithStatus(c *gin.Context, status int, data interface{})
 }
 
 // RequestValidator defines the interface for request validation.
@@ -314,8 +319,75 @@ type RouterConfig struct {
 
 	// MaxRequestSize maximum request body size
 	MaxRequestSize int64
+
+	// RequestIDHeader header used to read and propagate request IDs
+	// (empty uses server.request_id_header from the service config)
+	RequestIDHeader string
+
+	// HonorTraceparent derives request IDs from an incoming W3C traceparent
+	// header and propagates it back on the response
+	HonorTraceparent bool
+
+	// CacheControl maps cache classes to the Cache-Control header sent on
+	// successful GET responses; a missing or empty entry sends no header
+	CacheControl map[string]string
+
+	// RequestTimeout bounds the context of each request; handlers that are
+	// still running when it expires get their response replaced by a 504.
+	// Zero disables the deadline.
+	RequestTimeout time.Duration
+
+	// LogBodies logs JSON and form request and response bodies at debug
+	// level with password, token and authorization fields redacted; other
+	// bodies are left out. Meant for development only; it never runs when
+	// gin is in release mode.
+	LogBodies bool
+
+	// BodyLogLimit caps how many bytes of each body are logged
+	// (0 uses DefaultBodyLogLimit)
+	BodyLogLimit int
+
+	// APIKeys are the static keys server-to-server clients may send in
+	// APIKeyHeader instead of a JWT on admin routes
+	APIKeys []APIKey
+
+	// JWTSecretKey verifies the HS256 signature of bearer tokens; without
+	// it every token is rejected
+	JWTSecretKey string
+
+	// JWTIssuer, when set, must match the iss claim of bearer tokens
+	JWTIssuer string
 }
 
+// APIKey is a server-to-server client key and the scopes it grants.
+type APIKey struct {
+	ClientID string
+	Key      string
+	Scopes   []string
+}
+
+// DefaultBodyLogLimit is the number of body bytes logged when
+// RouterConfig.BodyLogLimit is unset.
+const DefaultBodyLogLimit = 4 << 10
+
+// APIKeyHeader carries the API key of server-to-server clients.
+const APIKeyHeader = "X-API-Key"
+
+// API key scopes.
+const (
+	// ScopeAdmin grants access to all admin routes
+	ScopeAdmin = "admin"
+
+	// ScopeAdminRead grants access to GET and HEAD admin routes
+	ScopeAdminRead = "admin:read"
+)
+
+// DefaultRequestIDHeader is the request ID header used when none is configured.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// TraceparentHeader is the W3C trace context propagation header.
+const TraceparentHeader = "traceparent"
+
 // DefaultRouterConfig returns default router configuration.
 func DefaultRouterConfig() RouterConfig {
 	return RouterConfig{
@@ -326,6 +398,7 @@ func DefaultRouterConfig() RouterConfig {
 		EnableLogging:     true,
 		TrustedProxies:    []string{"127.0.0.1"},
 		MaxRequestSize:    10 << 20, // 10MB
+		HonorTraceparent:  true,
 	}
 }
 
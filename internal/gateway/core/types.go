@@ -15,6 +15,11 @@ type PaginationInfo struct {
 	HasPrev    bool  `json:"has_prev"`
 	NextPage   *int  `json:"next_page,omitempty"`
 	PrevPage   *int  `json:"prev_page,omitempty"`
+
+	// NextURL and PrevURL are the request path and query with only the page
+	// changed; they are omitted when there is no such page
+	NextURL string `json:"next_url,omitempty"`
+	PrevURL string `json:"prev_url,omitempty"`
 }
 
 // NewPaginationInfo creates pagination info from parameters.
@@ -148,14 +153,21 @@ const (
 	CodeNotFound       = "NOT_FOUND"
 	CodeValidationError = "VALIDATION_ERROR"
 	CodeRateLimited    = "RATE_LIMITED"
+	CodeClientClosed   = "CLIENT_CLOSED_REQUEST"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
 	
 	// Server error codes
 	CodeInternalError  = "INTERNAL_ERROR"
 	CodeServiceError   = "SERVICE_ERROR"
 	CodeDatabaseError  = "DATABASE_ERROR"
 	CodeExternalError  = "EXTERNAL_ERROR"
+	CodeTimeout        = "REQUEST_TIMEOUT"
 )
 
+// StatusClientClosedRequest is the non-standard status used when the client
+// disconnected before a response was written.
+const StatusClientClosedRequest = 499
+
 // Constants for health status
 const (
 	StatusHealthy   = "healthy"
@@ -222,6 +234,10 @@ type NewsFilter struct {
 	Tags      []string  `json:"tags,omitempty"`
 	Page      int       `json:"page"`
 	Limit     int       `json:"limit"`
+
+	// Categories and Sources hold multi-value filters
+	Categories []string `json:"categories,omitempty"`
+	Sources    []string `json:"sources,omitempty"`
 }
 
 // AdminStats represents admin dashboard statistics.
@@ -291,3 +307,18 @@ type RequestLog struct {
 	Timestamp    time.Time     `json:"timestamp"`
 	Error        string        `json:"error,omitempty"`
 }
+
+// RouteInfo describes a single registered route and its access requirements.
+type RouteInfo struct {
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	RequiresAuth  bool   `json:"requires_auth"`
+	RequiresAdmin bool   `json:"requires_admin"`
+}
+
+// HandlerRoutes describes the routes a registered handler exposes.
+type HandlerRoutes struct {
+	Name     string      `json:"name"`
+	BasePath string      `json:"base_path"`
+	Routes   []RouteInfo `json:"routes"`
+}
@@ -7,22 +7,29 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"news-aggregator/internal/config"
+	"news-aggregator/internal/db"
 	"news-aggregator/internal/gateway/core"
 	"news-aggregator/internal/gateway/router"
 	"news-aggregator/internal/gateway/utils"
 
+	"news-aggregator/internal/handlers/admin"
 	"news-aggregator/internal/handlers/auth"
 	handlerCore "news-aggregator/internal/handlers/core"
 	"news-aggregator/internal/handlers/health"
 	"news-aggregator/internal/handlers/news"
 	"news-aggregator/internal/handlers/user"
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
+	"news-aggregator/internal/repository"
 	"news-aggregator/internal/services"
+	"news-aggregator/pkg/metrics"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
@@ -43,38 +50,110 @@ type Gateway struct {
 	userService     *services.UserService
 	searchService   *services.SearchService
 	trendingService *services.TrendingService
+	scoringService  *services.ScoringService
+	popularitySync  *services.PopularitySync
+
+	// stopBackground stops the background jobs started by Start
+	stopBackground context.CancelFunc
 }
 
 // New creates a new gateway instance with all dependencies.
 func New(cfg *config.Config, logger zerolog.Logger) (*Gateway, error) {
-	return NewWithConfig(cfg, logger, core.DefaultRouterConfig())
+	routerConfig := core.DefaultRouterConfig()
+	routerConfig.HonorTraceparent = cfg.Server.HonorTraceparent
+
+	return NewWithConfig(cfg, logger, routerConfig)
 }
 
 // NewWithConfig creates a new gateway instance with custom router configuration.
 func NewWithConfig(cfg *config.Config, logger zerolog.Logger, routerConfig core.RouterConfig) (*Gateway, error) {
-	// Initialize services
-	newsService, err := services.NewNewsService(cfg, logger)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create news service: %w", err)
+	// Initialize only the services needed by the enabled handlers. A service
+	// that fails to start is left nil and its routes answer 503, so e.g. a
+	// search outage doesn't take down the rest of the API.
+	enabled := enabledHandlers(cfg.Server.Handlers)
+	required := requiredServices(enabled)
+
+	var (
+		newsService     *services.NewsService
+		userService     *services.UserService
+		searchService   *services.SearchService
+		trendingService *services.TrendingService
+		scoringService  *services.ScoringService
+		popularitySync  *services.PopularitySync
+		err             error
+	)
+
+	// News and user repositories share one connection pool
+	var pool *pgxpool.Pool
+	if required[handlerCore.ServiceNews] || required[handlerCore.ServiceUser] {
+		if pool, err = db.NewPool(cfg, logger); err != nil {
+			logger.Error().Err(err).Msg("Failed to connect to database, news and user routes will be unavailable")
+			pool = nil
+		}
 	}
 
-	userService, err := services.NewUserService(cfg, logger)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user service: %w", err)
+	if required[handlerCore.ServiceNews] && pool != nil {
+		if newsService, err = services.NewNewsServiceWithPool(cfg, pool, logger); err != nil {
+			logger.Error().Err(err).Msg("Failed to create news service, news routes will be unavailable")
+			newsService = nil
+		}
 	}
 
-	searchService, err := services.NewSearchService(cfg, logger)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create search service: %w", err)
+	if required[handlerCore.ServiceUser] && pool != nil {
+		if userService, err = services.NewUserServiceWithPool(cfg, pool, logger); err != nil {
+			logger.Error().Err(err).Msg("Failed to create user service, user routes will be unavailable")
+			userService = nil
+		}
+	}
+
+	if required[handlerCore.ServiceSearch] {
+		if searchService, err = services.NewSearchService(cfg, logger); err != nil {
+			logger.Error().Err(err).Msg("Failed to create search service, search routes will be unavailable")
+			searchService = nil
+		}
+	}
+
+	// Trending topics are computed from the news repository
+	if required[handlerCore.ServiceTrending] && newsService != nil {
+		trendingService = services.NewTrendingService(newsService.GetRepository(), cfg.Trending, logger)
+	}
+
+	// Article scores live next to the articles they rate
+	if required[handlerCore.ServiceScoring] && newsService != nil {
+		scoringRepo := repository.NewScoringRepository(pool, logger)
+		if err = scoringRepo.InitSchema(context.Background()); err != nil {
+			logger.Error().Err(err).Msg("Failed to initialize scoring schema, scoring routes will be unavailable")
+		} else {
+			scoringService = services.NewScoringService(
+				newsService.GetRepository(),
+				scoringRepo,
+				logger,
+				cfg.TopStories,
+				services.NewNLPClient(cfg, logger),
+				services.NewSocialClient(cfg, logger),
+			)
+
+			// Scores only reach search ranking when they are blended in
+			if searchService != nil && cfg.Elasticsearch.PopularityWeight > 0 {
+				popularitySync = services.NewPopularitySync(
+					scoringRepo,
+					searchService.GetRepository(),
+					cfg.Elasticsearch.PopularitySyncInterval,
+					logger,
+				)
+			}
+		}
 	}
 
-	// Initialize trending service
-	trendingService := services.NewTrendingService(newsService.GetRepository(), logger)
+	// The request ID header comes from the service config unless the caller set it
+	if routerConfig.RequestIDHeader == "" {
+		routerConfig.RequestIDHeader = cfg.Server.RequestIDHeader
+	}
 
 	// Create utilities for handlers (independent of gateway)
 	responseWriter := utils.NewResponseWriter(logger)
 	validator := utils.NewRequestValidator(logger)
-	contextManager := utils.NewContextManager(logger)
+	contextManager := utils.NewContextManager(logger, routerConfig.RequestIDHeader)
 
 	// Create adapters to make gateway interfaces compatible with handler interfaces
 	responseAdapter := &responseWriterAdapter{responseWriter}
@@ -87,6 +166,7 @@ func NewWithConfig(cfg *config.Config, logger zerolog.Logger, routerConfig core.
 		UserService:     userService,
 		SearchService:   searchService,
 		TrendingService: trendingService,
+		ScoringService:  scoringService,
 		Config:          cfg,
 		Logger:          logger,
 		ResponseWriter:  responseAdapter,
@@ -97,31 +177,83 @@ func NewWithConfig(cfg *config.Config, logger zerolog.Logger, routerConfig core.
 	// Create handler registry
 	handlerRegistry := handlerCore.NewHandlerRegistry(logger)
 
-	// Create and register independent handlers
-	handlerConfig := handlerCore.DefaultHandlerConfig()
+	// Shared handler configuration
+	handlerConfig, err := newHandlerConfig(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create independent handlers
-	authHandler := auth.NewHandler(handlerDeps, handlerConfig)
-	newsHandler := news.NewHandler(handlerDeps, handlerConfig)
-	userHandler := user.NewHandler(handlerDeps, handlerConfig)
-	healthHandler := health.NewHandler(handlerDeps, handlerConfig)
+	// Create and register the enabled independent handlers
+	handlerFactories := map[string]func() handlerCore.Handler{
+		"auth":   func() handlerCore.Handler { return auth.NewHandler(handlerDeps, handlerConfig) },
+		"news":   func() handlerCore.Handler { return news.NewHandler(handlerDeps, handlerConfig) },
+		"user":   func() handlerCore.Handler { return user.NewHandler(handlerDeps, handlerConfig) },
+		"health": func() handlerCore.Handler { return health.NewHandler(handlerDeps, handlerConfig) },
+		"admin":  func() handlerCore.Handler { return admin.NewHandler(handlerDeps, handlerConfig) },
+	}
 
-	// Register handlers
-	if err := handlerRegistry.RegisterHandler(authHandler); err != nil {
-		return nil, fmt.Errorf("failed to register auth handler: %w", err)
+	for _, name := range enabled {
+		newHandler, ok := handlerFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown handler %q in server.handlers", name)
+		}
+		if err := handlerRegistry.RegisterHandler(newHandler()); err != nil {
+			return nil, fmt.Errorf("failed to register %s handler: %w", name, err)
+		}
 	}
-	if err := handlerRegistry.RegisterHandler(newsHandler); err != nil {
-		return nil, fmt.Errorf("failed to register news handler: %w", err)
+
+	// The request deadline comes from the service config unless the caller set it
+	if routerConfig.RequestTimeout == 0 {
+		routerConfig.RequestTimeout = time.Duration(cfg.Server.RequestTimeout) * time.Second
 	}
-	if err := handlerRegistry.RegisterHandler(userHandler); err != nil {
-		return nil, fmt.Errorf("failed to register user handler: %w", err)
+
+	// The server closes the connection at write_timeout, so a request deadline
+	// at or past it is never answered with the 504
+	writeTimeout := time.Duration(cfg.Server.WriteTimeout) * time.Second
+	if writeTimeout > 0 && routerConfig.RequestTimeout >= writeTimeout {
+		logger.Warn().
+			Dur("request_timeout", routerConfig.RequestTimeout).
+			Dur("write_timeout", writeTimeout).
+			Msg("Request timeout is not below the write timeout; timed out requests get no response")
 	}
-	if err := handlerRegistry.RegisterHandler(healthHandler); err != nil {
-		return nil, fmt.Errorf("failed to register health handler: %w", err)
+
+	// Cache policies come from the service config unless the caller set them
+	if routerConfig.CacheControl == nil {
+		routerConfig.CacheControl = map[string]string{
+			handlerCore.CacheClassArticle: cfg.Server.Cache.Article,
+			handlerCore.CacheClassFeed:    cfg.Server.Cache.Feed,
+			handlerCore.CacheClassPrivate: cfg.Server.Cache.Private,
+		}
+	}
+
+	// API keys come from the service config unless the caller set them
+	if routerConfig.APIKeys == nil {
+		for _, key := range cfg.Server.APIKeys {
+			routerConfig.APIKeys = append(routerConfig.APIKeys, core.APIKey{
+				ClientID: key.ClientID,
+				Key:      key.Key,
+				Scopes:   key.Scopes,
+			})
+		}
+	}
+
+	// Tokens are verified with the key the user service signs them with
+	if routerConfig.JWTSecretKey == "" {
+		routerConfig.JWTSecretKey = cfg.JWT.SecretKey
+		routerConfig.JWTIssuer = cfg.JWT.Issuer
+	}
+
+	// Body logging can be switched on from the service config, but never in
+	// production where bodies carry user data
+	routerConfig.LogBodies = routerConfig.LogBodies || cfg.Server.LogBodies
+	if routerConfig.LogBodies && cfg.Environment == "production" {
+		logger.Warn().Msg("Body logging is not allowed in production; disabling it")
+		routerConfig.LogBodies = false
 	}
 
 	// Create router with independent handlers
 	gatewayRouter := router.NewRouter(routerConfig, handlerRegistry, logger)
+	gatewayRouter.SetMetricsCollector(&NoOpMetricsCollector{})
 
 	gateway := &Gateway{
 		config:          cfg,
@@ -133,6 +265,8 @@ func NewWithConfig(cfg *config.Config, logger zerolog.Logger, routerConfig core.
 		userService:     userService,
 		searchService:   searchService,
 		trendingService: trendingService,
+		scoringService:  scoringService,
+		popularitySync:  popularitySync,
 	}
 
 	return gateway, nil
@@ -146,14 +280,33 @@ func (g *Gateway) Start(ctx context.Context, addr string) error {
 	// Setup error handlers
 	g.router.SetupErrorHandlers(engine)
 
+	// Background jobs run until the server stops
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	g.stopBackground = stopBackground
+
+	// Keep trending topics precomputed for the lifetime of the server
+	if g.trendingService != nil {
+		g.trendingService.Start(backgroundCtx)
+	}
+
+	// Score refreshes started through the API stop with the server
+	if g.scoringService != nil {
+		g.scoringService.Start(backgroundCtx)
+	}
+
+	// Copy changed article scores into the search index
+	if g.popularitySync != nil {
+		g.popularitySync.Start(backgroundCtx)
+	}
+
 	// Create HTTP server
 	g.server = &http.Server{
 		Addr:           addr,
 		Handler:        engine,
-		ReadTimeout:    30 * time.Second,
-		WriteTimeout:   30 * time.Second,
-		IdleTimeout:    120 * time.Second,
-		MaxHeaderBytes: 1 << 20, // 1 MB
+		ReadTimeout:    time.Duration(g.config.Server.ReadTimeout) * time.Second,
+		WriteTimeout:   time.Duration(g.config.Server.WriteTimeout) * time.Second,
+		IdleTimeout:    time.Duration(g.config.Server.IdleTimeout) * time.Second,
+		MaxHeaderBytes: g.config.Server.MaxHeaderBytes,
 	}
 
 	g.logger.Info().
@@ -165,33 +318,38 @@ func (g *Gateway) Start(ctx context.Context, addr string) error {
 	go func() {
 		if err := g.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			errChan <- fmt.Errorf("server failed to start: %w", err)
+			return
 		}
+		errChan <- nil
 	}()
 
-	// Wait for context cancellation or server error
+	// Wait for context cancellation, server error or a shutdown via Stop
 	select {
 	case <-ctx.Done():
 		g.logger.Info().Msg("Gateway server context cancelled")
-		return g.Stop(context.Background())
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), g.config.Server.ShutdownDuration())
+		defer cancel()
+		return g.Stop(shutdownCtx)
 	case err := <-errChan:
 		return err
 	}
 }
 
-// Stop gracefully shuts down the gateway server.
+// Stop gracefully shuts down the gateway server. It returns as soon as
+// in-flight requests have drained, or with an error once ctx expires.
 func (g *Gateway) Stop(ctx context.Context) error {
+	if g.stopBackground != nil {
+		g.stopBackground()
+	}
+
 	if g.server == nil {
 		return nil
 	}
 
 	g.logger.Info().Msg("Shutting down gateway server")
 
-	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
 	// Shutdown server
-	if err := g.server.Shutdown(shutdownCtx); err != nil {
+	if err := g.server.Shutdown(ctx); err != nil {
 		g.logger.Error().Err(err).Msg("Server shutdown failed")
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
@@ -256,9 +414,7 @@ func (g *Gateway) legacyHealthCheck(c *gin.Context) {
 }
 
 func (g *Gateway) legacyMetrics(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Metrics endpoint - integrate with Prometheus",
-	})
+	metrics.Handler().ServeHTTP(c.Writer, c.Request)
 }
 
 func (g *Gateway) legacyLogin(c *gin.Context) {
@@ -295,6 +451,67 @@ func (g *Gateway) legacyWebsocketHandler(c *gin.Context) {
 	})
 }
 
+// defaultHandlers are served when server.handlers is not configured.
+var defaultHandlers = []string{"auth", "news", "user", "admin", "health"}
+
+// handlerServices lists the services each handler group depends on.
+var handlerServices = map[string][]string{
+	"auth":   {handlerCore.ServiceUser},
+	"user":   {handlerCore.ServiceUser},
+	"news":   {handlerCore.ServiceNews, handlerCore.ServiceSearch, handlerCore.ServiceTrending},
+	"admin":  {handlerCore.ServiceNews, handlerCore.ServiceUser, handlerCore.ServiceSearch, handlerCore.ServiceTrending, handlerCore.ServiceScoring},
+	"health": {},
+}
+
+// newHandlerConfig returns the default handler configuration with the
+// freshness levels and list limits of the service config applied.
+func newHandlerConfig(server config.ServerConfig) (handlerCore.HandlerConfig, error) {
+	handlerConfig := handlerCore.DefaultHandlerConfig()
+
+	if len(server.Freshness) > 0 {
+		levels := make([]newsModels.FreshnessLevel, 0, len(server.Freshness))
+		for _, level := range server.Freshness {
+			if level.Label == "" || level.MaxAge <= 0 {
+				return handlerConfig, fmt.Errorf("server.freshness entries need a label and a positive max_age, got %+v", level)
+			}
+			levels = append(levels, newsModels.FreshnessLevel{Label: level.Label, MaxAge: level.MaxAge})
+		}
+		sort.Slice(levels, func(i, j int) bool { return levels[i].MaxAge < levels[j].MaxAge })
+		handlerConfig.FreshnessLevels = levels
+	}
+
+	for endpoint, limits := range server.Limits {
+		if _, ok := handlerConfig.LimitOverrides[endpoint]; !ok {
+			return handlerConfig, fmt.Errorf("unknown endpoint %q in server.limits", endpoint)
+		}
+		if limits.Default < 1 || (limits.Max > 0 && limits.Max < limits.Default) {
+			return handlerConfig, fmt.Errorf("server.limits.%s needs a default of at least 1 and no larger than max", endpoint)
+		}
+		handlerConfig.LimitOverrides[endpoint] = handlerCore.LimitRange{Default: limits.Default, Max: limits.Max}
+	}
+
+	return handlerConfig, nil
+}
+
+// enabledHandlers returns the configured handler groups, or all of them when none are configured.
+func enabledHandlers(configured []string) []string {
+	if len(configured) == 0 {
+		return defaultHandlers
+	}
+	return configured
+}
+
+// requiredServices returns the set of services needed by the given handler groups.
+func requiredServices(handlers []string) map[string]bool {
+	required := make(map[string]bool)
+	for _, name := range handlers {
+		for _, service := range handlerServices[name] {
+			required[service] = true
+		}
+	}
+	return required
+}
+
 // NoOpMetricsCollector is a placeholder metrics collector.
 type NoOpMetricsCollector struct{}
 
@@ -330,6 +547,7 @@ func CreateProductionGateway(cfg *config.Config, logger zerolog.Logger) (*Gatewa
 		EnableLogging:     true,
 		TrustedProxies:    []string{"127.0.0.1", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
 		MaxRequestSize:    10 << 20, // 10MB
+		HonorTraceparent:  true,
 	}
 
 	return NewWithConfig(cfg, logger, routerConfig)
@@ -345,6 +563,7 @@ func CreateDevelopmentGateway(cfg *config.Config, logger zerolog.Logger) (*Gatew
 		EnableLogging:     true,
 		TrustedProxies:    []string{"*"}, // Allow all for development
 		MaxRequestSize:    50 << 20,      // 50MB for development
+		HonorTraceparent:  true,
 	}
 
 	return NewWithConfig(cfg, logger, routerConfig)
@@ -360,6 +579,7 @@ func CreateTestGateway(cfg *config.Config, logger zerolog.Logger) (*Gateway, err
 		EnableLogging:     false,
 		TrustedProxies:    []string{"127.0.0.1"},
 		MaxRequestSize:    1 << 20, // 1MB for testing
+		HonorTraceparent:  false,
 	}
 
 	return NewWithConfig(cfg, logger, routerConfig)
@@ -407,12 +627,14 @@ func (rw *responseWriterAdapter) Success(c *gin.Context, data interface{}) {
 func (rw *responseWriterAdapter) SuccessWithPagination(c *gin.Context, data interface{}, pagination handlerCore.PaginationInfo) {
 	// Convert handler PaginationInfo to gateway PaginationInfo
 	gatewayPagination := core.PaginationInfo{
-		Page:    pagination.Page,
-		Limit:   pagination.Limit,
-		Total:   pagination.Total,
-		Pages:   pagination.Pages,
-		HasNext: pagination.HasNext,
-		HasPrev: pagination.HasPrev,
+		Page:     pagination.Page,
+		Limit:    pagination.Limit,
+		Total:    pagination.Total,
+		Pages:    pagination.Pages,
+		HasNext:  pagination.HasNext,
+		HasPrev:  pagination.HasPrev,
+		NextPage: pagination.NextPage,
+		PrevPage: pagination.PrevPage,
 	}
 	rw.ResponseWriter.SuccessWithPagination(c, data, gatewayPagination)
 }
@@ -425,6 +647,34 @@ type requestValidatorAdapter struct {
 	core.RequestValidator
 }
 
+// ValidateNewsFilter validates a handler news filter with the gateway's
+// rules; the normalized pagination is copied back to the filter
+func (v *requestValidatorAdapter) ValidateNewsFilter(filter interface{}) error {
+	newsFilter, ok := filter.(*models.NewsFilter)
+	if !ok {
+		return v.RequestValidator.ValidateNewsFilter(filter)
+	}
+
+	gatewayFilter := &core.NewsFilter{
+		Category: newsFilter.Category,
+		Source:   newsFilter.Source,
+		DateFrom: newsFilter.DateFrom,
+		DateTo:   newsFilter.DateTo,
+		Tags:     newsFilter.Tags,
+		Page:     newsFilter.Page,
+		Limit:    newsFilter.Limit,
+
+		Categories: newsFilter.Categories,
+		Sources:    newsFilter.Sources,
+	}
+	if err := v.RequestValidator.ValidateNewsFilter(gatewayFilter); err != nil {
+		return err
+	}
+
+	newsFilter.Page, newsFilter.Limit = gatewayFilter.Page, gatewayFilter.Limit
+	return nil
+}
+
 func (v *requestValidatorAdapter) ValidateSearchQuery(query interface{}) error {
 	// Add implementation or delegate to existing method
 	return nil // TODO: Implement
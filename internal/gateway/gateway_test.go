@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"news-aggregator/internal/config"
+	handlerCore "news-aggregator/internal/handlers/core"
+)
+
+func TestNewHandlerConfigAppliesServerConfig(t *testing.T) {
+	handlerConfig, err := newHandlerConfig(config.ServerConfig{
+		Freshness: []config.FreshnessConfig{
+			{Label: "today", MaxAge: 24 * time.Hour},
+			{Label: "breaking", MaxAge: 15 * time.Minute},
+		},
+		Limits: map[string]config.LimitConfig{
+			handlerCore.LimitTrending: {Default: 5, Max: 25},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newHandlerConfig: %v", err)
+	}
+
+	levels := handlerConfig.FreshnessLevels
+	if len(levels) != 2 || levels[0].Label != "breaking" || levels[1].Label != "today" {
+		t.Errorf("freshness levels = %+v, want breaking then today", levels)
+	}
+	if got := handlerConfig.ClampLimitFor(handlerCore.LimitTrending, 100); got != 25 {
+		t.Errorf("trending limit = %d, want the configured max 25", got)
+	}
+	if got := handlerConfig.ClampLimitFor(handlerCore.LimitTags, 0); got != 50 {
+		t.Errorf("tags limit = %d, want the built-in default 50", got)
+	}
+}
+
+func TestNewHandlerConfigRejectsInvalidEntries(t *testing.T) {
+	tests := map[string]config.ServerConfig{
+		"unknown endpoint": {Limits: map[string]config.LimitConfig{"news": {Default: 10}}},
+		"default above max": {Limits: map[string]config.LimitConfig{
+			handlerCore.LimitTags: {Default: 100, Max: 10},
+		}},
+		"freshness without age": {Freshness: []config.FreshnessConfig{{Label: "new"}}},
+	}
+
+	for name, server := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newHandlerConfig(server); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -2,18 +2,32 @@
 package router
 
 import (
-	"encoding/base64"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net"
 	"net/http"
+	"path"
+	"regexp"
+	"runtime/debug"
+	"sort"
 	"strings"
 	"time"
 
 	"news-aggregator/internal/gateway/core"
 	handlerCore "news-aggregator/internal/handlers/core"
+	"news-aggregator/pkg/metrics"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
@@ -21,22 +35,77 @@ import (
 type Router struct {
 	config          core.RouterConfig
 	handlerRegistry handlerCore.HandlerRegistry
+	metrics         core.MetricsCollector
+	bodyLimits      map[string]int64
+	contentTypes    map[string][]string
+	cacheClasses    map[string]string
+	engine          *gin.Engine
+	handlerRoutes   []core.HandlerRoutes
+	apiKeys         []apiKey
 	logger          zerolog.Logger
 }
 
+// apiKey is a configured API key with the key itself replaced by its
+// SHA-256 digest, so every comparison covers the same number of bytes.
+type apiKey struct {
+	clientID string
+	digest   [sha256.Size]byte
+	scopes   map[string]bool
+}
+
+// routeAccess is the authentication level enforced by the group a handler is mounted on.
+type routeAccess int
+
+const (
+	accessPublic routeAccess = iota
+	accessAuthenticated
+	accessAdmin
+)
+
 // NewRouter creates a new router with independent handlers.
 func NewRouter(config core.RouterConfig, handlerRegistry handlerCore.HandlerRegistry, logger zerolog.Logger) *Router {
-	return &Router{
+	r := &Router{
 		config:          config,
 		handlerRegistry: handlerRegistry,
+		bodyLimits:      make(map[string]int64),
+		contentTypes:    make(map[string][]string),
+		cacheClasses:    make(map[string]string),
 		logger:          logger.With().Str("component", "router").Logger(),
 	}
+
+	for _, key := range config.APIKeys {
+		if key.Key == "" || key.ClientID == "" {
+			r.logger.Warn().Str("client_id", key.ClientID).Msg("Ignoring API key without key or client ID")
+			continue
+		}
+		scopes := make(map[string]bool, len(key.Scopes))
+		for _, scope := range key.Scopes {
+			scopes[scope] = true
+		}
+		r.apiKeys = append(r.apiKeys, apiKey{
+			clientID: key.ClientID,
+			digest:   sha256.Sum256([]byte(key.Key)),
+			scopes:   scopes,
+		})
+	}
+
+	if config.JWTSecretKey == "" {
+		r.logger.Warn().Msg("No JWT secret configured; bearer tokens will be rejected")
+	}
+
+	return r
+}
+
+// SetMetricsCollector sets the collector used to record router-level metrics.
+func (r *Router) SetMetricsCollector(metrics core.MetricsCollector) {
+	r.metrics = metrics
 }
 
 // Setup configures and returns a Gin engine with all routes and middleware.
 func (r *Router) Setup() *gin.Engine {
 	// Create Gin engine
 	engine := gin.New()
+	r.engine = engine
 
 	// Set trusted proxies
 	if len(r.config.TrustedProxies) > 0 {
@@ -56,12 +125,12 @@ func (r *Router) Setup() *gin.Engine {
 
 // setupGlobalMiddleware configures global middleware.
 func (r *Router) setupGlobalMiddleware(engine *gin.Engine) {
-	// Recovery middleware
-	engine.Use(gin.Recovery())
-
-	// Request ID middleware
+	// Request ID middleware (first, so panics can be correlated)
 	engine.Use(r.requestIDMiddleware())
 
+	// Recovery middleware
+	engine.Use(r.recoveryMiddleware())
+
 	// Logging middleware
 	if r.config.EnableLogging {
 		engine.Use(r.loggingMiddleware())
@@ -88,6 +157,26 @@ func (r *Router) setupGlobalMiddleware(engine *gin.Engine) {
 	// Request size limit middleware
 	engine.Use(r.requestSizeLimitMiddleware())
 
+	// Content type enforcement middleware
+	engine.Use(r.contentTypeMiddleware())
+
+	// Cache-Control policy middleware
+	engine.Use(r.cacheControlMiddleware())
+
+	// Body logging middleware (before the deadline so 504s are logged too)
+	if r.config.LogBodies {
+		if gin.Mode() == gin.ReleaseMode {
+			r.logger.Warn().Msg("Body logging requested in release mode; not enabling it")
+		} else {
+			engine.Use(r.bodyLoggingMiddleware())
+		}
+	}
+
+	// Request deadline middleware
+	if r.config.RequestTimeout > 0 {
+		engine.Use(r.requestTimeoutMiddleware())
+	}
+
 	r.logger.Info().Msg("Global middleware configured")
 }
 
@@ -102,25 +191,27 @@ func (r *Router) setupRoutes(engine *gin.Engine) {
 	// Register health handlers directly (no authentication required)
 	healthHandlers := r.handlerRegistry.GetHandlersByType("health")
 	for _, handler := range healthHandlers {
-		handler.RegisterRoutes(engine)
+		r.registerHandler(engine, "/", handler, accessPublic, handlerCore.CacheClassPrivate)
 	}
 
 	// API v1 routes
 	v1 := engine.Group("/api/v1")
 	{
-		// Public routes (no authentication required)
+		// Public routes (no authentication required). A valid token still
+		// identifies the user, e.g. for personalized search.
 		public := v1.Group("/")
+		public.Use(r.optionalAuthMiddleware())
 		{
 			// Register auth handlers
 			authHandlers := r.handlerRegistry.GetHandlersByType("auth")
 			for _, handler := range authHandlers {
-				handler.RegisterRoutes(public)
+				r.registerHandler(public, "/api/v1", handler, accessPublic, handlerCore.CacheClassPrivate)
 			}
 
 			// Register news handlers
 			newsHandlers := r.handlerRegistry.GetHandlersByType("news")
 			for _, handler := range newsHandlers {
-				handler.RegisterRoutes(public)
+				r.registerHandler(public, "/api/v1", handler, accessPublic, handlerCore.CacheClassFeed)
 			}
 		}
 
@@ -131,21 +222,25 @@ func (r *Router) setupRoutes(engine *gin.Engine) {
 			// Register user handlers
 			userHandlers := r.handlerRegistry.GetHandlersByType("user")
 			for _, handler := range userHandlers {
-				handler.RegisterRoutes(protected)
+				r.registerHandler(protected, "/api/v1", handler, accessAuthenticated, handlerCore.CacheClassPrivate)
 			}
 		}
 
 		// Admin routes (admin authentication required)
 		admin := v1.Group("/admin")
+		admin.Use(r.apiKeyMiddleware())
 		admin.Use(r.authMiddleware())
 		admin.Use(r.adminMiddleware())
 		{
 			// Register admin handlers
 			adminHandlers := r.handlerRegistry.GetHandlersByType("admin")
 			for _, handler := range adminHandlers {
-				handler.RegisterRoutes(admin)
+				r.registerHandler(admin, "/api/v1/admin", handler, accessAdmin, handlerCore.CacheClassPrivate)
 			}
 		}
+
+		// Route introspection (admin only)
+		v1.GET("/_routes", r.apiKeyMiddleware(), r.authMiddleware(), r.adminMiddleware(), r.routesHandler)
 	}
 
 	// WebSocket endpoint
@@ -161,18 +256,130 @@ func (r *Router) setupRoutes(engine *gin.Engine) {
 		Msg("Routes configured with independent handlers")
 }
 
+// registerHandler registers a handler's routes on the group mounted at prefix,
+// records the routes it added with the group's access level and cache class
+// and applies any per-route metadata the handler declares.
+func (r *Router) registerHandler(group gin.IRouter, prefix string, handler handlerCore.Handler, access routeAccess, cacheClass string) {
+	existing := make(map[string]bool)
+	for _, route := range r.engine.Routes() {
+		existing[routeKey(route.Method, route.Path)] = true
+	}
+
+	handler.RegisterRoutes(group)
+
+	info := core.HandlerRoutes{
+		Name:     handler.GetName(),
+		BasePath: path.Join(prefix, handler.GetBasePath()),
+		Routes:   []core.RouteInfo{},
+	}
+	for _, route := range r.engine.Routes() {
+		if existing[routeKey(route.Method, route.Path)] {
+			continue
+		}
+		r.cacheClasses[routeKey(route.Method, route.Path)] = cacheClass
+		info.Routes = append(info.Routes, core.RouteInfo{
+			Method:        route.Method,
+			Path:          route.Path,
+			RequiresAuth:  access >= accessAuthenticated,
+			RequiresAdmin: access == accessAdmin,
+		})
+	}
+	sort.Slice(info.Routes, func(i, j int) bool {
+		if info.Routes[i].Path != info.Routes[j].Path {
+			return info.Routes[i].Path < info.Routes[j].Path
+		}
+		return info.Routes[i].Method < info.Routes[j].Method
+	})
+	r.handlerRoutes = append(r.handlerRoutes, info)
+
+	provider, ok := handler.(handlerCore.RouteMetadataProvider)
+	if !ok {
+		return
+	}
+
+	for _, meta := range provider.GetRouteMetadata() {
+		fullPath := path.Join(prefix, handler.GetBasePath(), meta.Path)
+		if meta.MaxBodySize > 0 {
+			r.bodyLimits[routeKey(meta.Method, fullPath)] = meta.MaxBodySize
+			r.logger.Debug().
+				Str("handler", handler.GetName()).
+				Str("route", routeKey(meta.Method, fullPath)).
+				Int64("max_body_size", meta.MaxBodySize).
+				Msg("Registered route body size limit")
+		}
+		if len(meta.ContentTypes) > 0 {
+			r.contentTypes[routeKey(meta.Method, fullPath)] = meta.ContentTypes
+		}
+		if meta.CacheClass != "" {
+			r.cacheClasses[routeKey(meta.Method, fullPath)] = meta.CacheClass
+		}
+	}
+}
+
 // Middleware functions
 
 // requestIDMiddleware adds request ID to each request.
 func (r *Router) requestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Generate request ID
-		requestID := c.GetHeader("X-Request-ID")
+		header := r.requestIDHeader()
+
+		// Prefer an explicit request ID, then the trace ID of an incoming
+		// traceparent, and only then generate a new one
+		requestID := c.GetHeader(header)
+		if requestID == "" && r.config.HonorTraceparent {
+			traceparent := c.GetHeader(core.TraceparentHeader)
+			if traceID, ok := parseTraceparent(traceparent); ok {
+				requestID = traceID
+				c.Header(core.TraceparentHeader, traceparent)
+			}
+		}
 		if requestID == "" {
 			requestID = generateRequestID()
 		}
 		c.Set("request_id", requestID)
-		c.Header("X-Request-ID", requestID)
+		c.Header(header, requestID)
+		c.Next()
+	}
+}
+
+// recoveryMiddleware recovers from panics, logs them with their stack trace
+// and responds with the standard error shape.
+func (r *Router) recoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			r.logger.Error().
+				Str("request_id", getRequestID(c)).
+				Str("method", c.Request.Method).
+				Str("path", c.Request.URL.Path).
+				Interface("panic", recovered).
+				Bytes("stack", debug.Stack()).
+				Msg("Recovered from panic")
+
+			metrics.GatewayPanics.Inc()
+
+			// A broken connection cannot receive a response
+			if isBrokenPipe(recovered) {
+				c.Abort()
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, core.ErrorResponse{
+				Error: core.APIError{
+					Code:    core.CodeInternalError,
+					Message: "Internal server error",
+				},
+				RequestID: getRequestID(c),
+				Timestamp: time.Now().UTC(),
+				Path:      c.Request.URL.Path,
+				Method:    c.Request.Method,
+			})
+		}()
+
 		c.Next()
 	}
 }
@@ -200,13 +407,146 @@ func (r *Router) loggingMiddleware() gin.HandlerFunc {
 	}
 }
 
+// bodyLoggingMiddleware logs the request and response bodies at debug
+// level, truncated to the configured limit and with credentials redacted.
+// The request body is only peeked at; the handler still reads all of it
+// through the original reader, so size limits keep applying.
+func (r *Router) bodyLoggingMiddleware() gin.HandlerFunc {
+	limit := r.config.BodyLogLimit
+	if limit <= 0 {
+		limit = core.DefaultBodyLogLimit
+	}
+
+	return func(c *gin.Context) {
+		var requestBody []byte
+		if c.Request.Body != nil {
+			body := c.Request.Body
+			requestBody, _ = io.ReadAll(io.LimitReader(body, int64(limit)+1))
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(requestBody), body), body}
+		}
+
+		writer := &bodyLogWriter{ResponseWriter: c.Writer, limit: limit + 1}
+		c.Writer = writer
+		c.Next()
+
+		r.logger.Debug().
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Int("status", writer.Status()).
+			Str("request_body", formatLoggedBody(requestBody, c.GetHeader("Content-Type"), limit)).
+			Str("response_body", formatLoggedBody(writer.body.Bytes(), writer.Header().Get("Content-Type"), limit)).
+			Str("request_id", getRequestID(c)).
+			Msg("HTTP bodies")
+	}
+}
+
+// bodyLogWriter keeps a copy of the first limit bytes written.
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body  bytes.Buffer
+	limit int
+}
+
+func (w *bodyLogWriter) capture(data []byte) {
+	if room := w.limit - w.body.Len(); room > 0 {
+		if len(data) > room {
+			data = data[:room]
+		}
+		w.body.Write(data)
+	}
+}
+
+func (w *bodyLogWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// redactedFields matches the JSON keys whose values are never logged.
+var redactedFields = regexp.MustCompile(`(?i)password|token|authorization`)
+
+// redactedValues finds string values of redacted keys in JSON that could
+// not be parsed, typically because it was truncated.
+var redactedValues = regexp.MustCompile(`(?i)("[^"]*(?:password|token|authorization)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// redactedFormValues finds the values of redacted keys in a form-urlencoded
+// body.
+var redactedFormValues = regexp.MustCompile(`(?i)((?:^|&)[^=&]*(?:password|token|authorization)[^=&]*=)[^&]*`)
+
+// formatLoggedBody redacts credentials in body and truncates it to limit
+// bytes. JSON bodies are redacted by key, falling back to a pattern match
+// on "key": "value" pairs when they do not parse, and form bodies by field
+// name. Bodies of any other content type are not logged since there is no
+// telling where credentials are in them.
+func formatLoggedBody(body []byte, contentType string, limit int) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	isJSON := mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	isForm := mediaType == "application/x-www-form-urlencoded"
+	if !isJSON && !isForm {
+		return fmt.Sprintf("(%q body not logged)", mediaType)
+	}
+
+	truncated := len(body) > limit
+	if truncated {
+		body = body[:limit]
+	}
+
+	var logged string
+	if isForm {
+		logged = redactedFormValues.ReplaceAllString(string(body), "${1}[REDACTED]")
+	} else {
+		var value interface{}
+		if !truncated && json.Unmarshal(body, &value) == nil {
+			if redacted, err := json.Marshal(redactJSON(value)); err == nil {
+				return string(redacted)
+			}
+		}
+		logged = redactedValues.ReplaceAllString(string(body), `$1"[REDACTED]"`)
+	}
+
+	if truncated {
+		logged += "...(truncated)"
+	}
+	return logged
+}
+
+// redactJSON replaces the values of credential keys anywhere in value.
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redactedFields.MatchString(key) {
+				v[key] = "[REDACTED]"
+				continue
+			}
+			v[key] = redactJSON(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return value
+}
+
 // corsMiddleware configures CORS.
 func (r *Router) corsMiddleware() gin.HandlerFunc {
 	config := cors.Config{
 		AllowOrigins:     []string{"*"}, // Configure based on your needs
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"X-Request-ID"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-None-Match", r.requestIDHeader(), core.TraceparentHeader, core.APIKeyHeader},
+		ExposeHeaders:    []string{"ETag", r.requestIDHeader(), core.TraceparentHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -249,82 +589,382 @@ func (r *Router) metricsMiddleware() gin.HandlerFunc {
 	}
 }
 
-// requestSizeLimitMiddleware limits request body size.
+// requestSizeLimitMiddleware limits request body size, using a route's own
+// limit when its handler declares one and the global limit otherwise.
 func (r *Router) requestSizeLimitMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if c.Request.ContentLength > r.config.MaxRequestSize {
+		limit := r.config.MaxRequestSize
+		if routeLimit, ok := r.bodyLimits[routeKey(c.Request.Method, c.FullPath())]; ok {
+			limit = routeLimit
+		}
+
+		if c.Request.ContentLength > limit {
 			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request too large"})
 			c.Abort()
 			return
 		}
+
+		// Also cap bodies without a declared length (chunked uploads)
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
 		c.Next()
 	}
 }
 
-// authMiddleware validates JWT tokens.
-func (r *Router) authMiddleware() gin.HandlerFunc {
+// contentTypeMiddleware rejects request bodies that are not JSON with 415,
+// unless the route declares other accepted media types (e.g. multipart uploads).
+func (r *Router) contentTypeMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenString := c.GetHeader("Authorization")
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "Authorization header required",
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			c.Next()
+			return
+		}
+
+		// Requests without a body have nothing to bind
+		if c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		contentType := c.ContentType()
+		allowed, ok := r.contentTypes[routeKey(c.Request.Method, c.FullPath())]
+		if !ok {
+			allowed = []string{"application/json"}
+		}
+
+		if !isAcceptedContentType(contentType, allowed) {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, core.ErrorResponse{
+				Error: core.APIError{
+					Code:    core.CodeUnsupportedMediaType,
+					Message: fmt.Sprintf("Unsupported content type %q, expected %s", contentType, strings.Join(allowed, " or ")),
 				},
-				"request_id": generateRequestID(),
-				"timestamp":  time.Now().UTC(),
-				"path":       c.Request.URL.Path,
-				"method":     c.Request.Method,
+				RequestID: getRequestID(c),
+				Timestamp: time.Now().UTC(),
+				Path:      c.Request.URL.Path,
+				Method:    c.Request.Method,
 			})
-			c.Abort()
 			return
 		}
 
-		// Remove "Bearer " prefix if present
-		if len(tokenString) > 7 && tokenString[:7] == "Bearer " {
-			tokenString = tokenString[7:]
-		}
-
-		// For testing purposes, extract user_id from JWT without full validation
-		// In production, you'd validate the JWT signature here
-		if tokenString != "" {
-			// This is a temporary hack for testing - decode JWT payload
-			parts := strings.Split(tokenString, ".")
-			if len(parts) == 3 {
-				// Decode payload (second part)
-				payload, err := base64.RawURLEncoding.DecodeString(parts[1])
-				if err == nil {
-					var claims map[string]interface{}
-					if json.Unmarshal(payload, &claims) == nil {
-						if userID, ok := claims["user_id"].(string); ok && userID != "" {
-							c.Set("user_id", userID)
-							c.Set("user_role", "user")
-							c.Next()
-							return
-						}
-					}
-				}
-			}
+		c.Next()
+	}
+}
+
+// cacheControlMiddleware sets the Cache-Control policy of the route's cache
+// class on successful GET and HEAD responses. The header is added when the
+// response is written so errors are never cached under a public policy.
+func (r *Router) cacheControlMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		policy := r.config.CacheControl[r.cacheClasses[routeKey(c.Request.Method, c.FullPath())]]
+		if policy == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, policy: policy}
+		c.Next()
+	}
+}
+
+// cacheControlWriter adds a Cache-Control header just before the response
+// headers are written, once the status code is known.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy string
+}
+
+func (w *cacheControlWriter) setHeader() {
+	if w.Written() || w.Header().Get("Cache-Control") != "" {
+		return
+	}
+	if (w.Status() >= http.StatusOK && w.Status() < http.StatusMultipleChoices) || w.Status() == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.policy)
+	} else {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// requestTimeoutMiddleware puts a deadline on the request context so that
+// DB and search calls made by slow handlers are cancelled. Once the
+// deadline passes, whatever the handler writes is discarded and a 504 is
+// sent instead. WebSocket upgrades are long-lived and exempt.
+func (r *Router) requestTimeoutMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+			c.Next()
+			return
 		}
 
-		c.JSON(http.StatusUnauthorized, gin.H{
+		ctx, cancel := context.WithTimeout(c.Request.Context(), r.config.RequestTimeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		writer := &timeoutWriter{ResponseWriter: c.Writer, ctx: ctx}
+		c.Writer = writer
+		c.Next()
+
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) || writer.ResponseWriter.Written() {
+			return
+		}
+
+		r.logger.Warn().
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Dur("timeout", r.config.RequestTimeout).
+			Str("request_id", getRequestID(c)).
+			Msg("Request timed out")
+
+		c.Writer = writer.ResponseWriter
+		c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
 			"error": gin.H{
-				"code":    "UNAUTHORIZED",
-				"message": "Invalid token",
+				"code":    core.CodeTimeout,
+				"message": "The request took too long to complete",
 			},
-			"request_id": generateRequestID(),
+			"request_id": getRequestID(c),
 			"timestamp":  time.Now().UTC(),
-			"path":       c.Request.URL.Path,
-			"method":     c.Request.Method,
 		})
-		c.Abort()
 	}
 }
 
-// adminMiddleware ensures user has admin role.
+// timeoutWriter drops writes made after the request deadline has passed,
+// so a handler that gives up on a cancelled call cannot answer with its own
+// error before the middleware sends the 504.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx context.Context
+}
+
+func (w *timeoutWriter) expired() bool {
+	return !w.ResponseWriter.Written() && errors.Is(w.ctx.Err(), context.DeadlineExceeded)
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if w.expired() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.expired() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.expired() {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if w.expired() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// apiKeyMiddleware authenticates server-to-server clients by the key in
+// core.APIKeyHeader. Requests with a valid key whose scopes cover the
+// request get client_id set and skip the JWT checks that follow; requests
+// without the header fall through to them. Keys are compared by digest in
+// constant time, and every configured key is compared so the time taken
+// does not reveal which one matched.
+func (r *Router) apiKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := c.GetHeader(core.APIKeyHeader)
+		if presented == "" {
+			c.Next()
+			return
+		}
+
+		digest := sha256.Sum256([]byte(presented))
+		var matched *apiKey
+		for i := range r.apiKeys {
+			if subtle.ConstantTimeCompare(digest[:], r.apiKeys[i].digest[:]) == 1 {
+				matched = &r.apiKeys[i]
+			}
+		}
+
+		if matched == nil {
+			r.abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid API key")
+			return
+		}
+
+		readOnly := c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead
+		if !matched.scopes[core.ScopeAdmin] && !(readOnly && matched.scopes[core.ScopeAdminRead]) {
+			r.abortWithError(c, http.StatusForbidden, "FORBIDDEN", "API key does not grant access to this route")
+			return
+		}
+
+		c.Set("client_id", matched.clientID)
+		c.Next()
+	}
+}
+
+// authenticatedByAPIKey reports whether apiKeyMiddleware accepted the request.
+func authenticatedByAPIKey(c *gin.Context) bool {
+	return c.GetString("client_id") != ""
+}
+
+// abortWithError aborts the request with an error body in the gateway's
+// error format.
+func (r *Router) abortWithError(c *gin.Context, status int, code, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"code":    code,
+			"message": message,
+		},
+		"request_id": getRequestID(c),
+		"timestamp":  time.Now().UTC(),
+		"path":       c.Request.URL.Path,
+		"method":     c.Request.Method,
+	})
+	c.Abort()
+}
+
+// authMiddleware validates JWT tokens. Tokens must carry a valid HS256
+// signature made with the configured secret, an unexpired exp claim and,
+// when an issuer is configured, a matching iss claim.
+func (r *Router) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticatedByAPIKey(c) {
+			c.Next()
+			return
+		}
+
+		tokenString := c.GetHeader("Authorization")
+		if tokenString == "" {
+			r.abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Authorization header required")
+			return
+		}
+
+		// Remove "Bearer " prefix if present
+		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+		claims, err := r.parseToken(tokenString)
+		if err != nil {
+			r.logger.Debug().
+				Err(err).
+				Str("request_id", getRequestID(c)).
+				Msg("Rejected bearer token")
+
+			r.abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid token")
+			return
+		}
+
+		userID, _ := claims["user_id"].(string)
+		if userID == "" {
+			r.abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid token")
+			return
+		}
+
+		role := "user"
+		if isAdmin, _ := claims["is_admin"].(bool); isAdmin {
+			role = "admin"
+		}
+
+		c.Set("user_id", userID)
+		c.Set("user_role", role)
+		c.Next()
+	}
+}
+
+// optionalAuthMiddleware sets the user of requests that carry a valid JWT
+// the way authMiddleware does, and lets every other request through
+// anonymously, including ones with an invalid token.
+func (r *Router) optionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if tokenString == "" {
+			c.Next()
+			return
+		}
+
+		claims, err := r.parseToken(tokenString)
+		if err != nil {
+			r.logger.Debug().
+				Err(err).
+				Str("request_id", getRequestID(c)).
+				Msg("Ignoring invalid bearer token on public route")
+			c.Next()
+			return
+		}
+
+		if userID, _ := claims["user_id"].(string); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	}
+}
+
+// parseToken verifies a bearer token and returns its claims.
+func (r *Router) parseToken(tokenString string) (jwt.MapClaims, error) {
+	if r.config.JWTSecretKey == "" {
+		return nil, errors.New("no JWT secret configured")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+	}
+	if r.config.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(r.config.JWTIssuer))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(r.config.JWTSecretKey), nil
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The parser only checks exp when present; tokens without one never expire
+	if exp, err := claims.GetExpirationTime(); err != nil || exp == nil {
+		return nil, errors.New("token has no expiration")
+	}
+	return claims, nil
+}
+
+// adminMiddleware ensures user has admin role. It runs after
+// authMiddleware, which sets the role from the token's is_admin claim.
 func (r *Router) adminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement admin role check
+		if authenticatedByAPIKey(c) {
+			c.Next()
+			return
+		}
+
+		if c.GetString("user_role") != "admin" {
+			r.abortWithError(c, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -358,14 +998,22 @@ func (r *Router) websocketHandler(c *gin.Context) {
 	})
 }
 
-// metricsHandler serves Prometheus metrics.
-func (r *Router) metricsHandler(c *gin.Context) {
-	// Prometheus metrics would be served here
+// routesHandler lists every registered handler with its routes and their
+// auth requirements, so the live route surface can be audited.
+func (r *Router) routesHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Metrics endpoint - Prometheus integration pending",
+		"handlers":   r.handlerRoutes,
+		"count":      len(r.handlerRoutes),
+		"request_id": getRequestID(c),
+		"timestamp":  time.Now().UTC(),
 	})
 }
 
+// metricsHandler serves Prometheus metrics.
+func (r *Router) metricsHandler(c *gin.Context) {
+	metrics.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
 // Custom error handlers
 
 // NoRouteHandler handles 404 errors.
@@ -394,18 +1042,81 @@ func (r *Router) SetupErrorHandlers(engine *gin.Engine) {
 
 // Helper functions
 
-// generateRequestID generates a unique request ID.
+// generateRequestID generates a unique UUID v4 request ID.
 func generateRequestID() string {
-	// Simple UUID-like generation
-	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), time.Now().Unix())
+	return uuid.New().String()
 }
 
-// getRequestID gets request ID from context.
+// getRequestID gets request ID from context, generating one if the request
+// did not pass through the request ID middleware.
 func getRequestID(c *gin.Context) string {
 	if requestID, exists := c.Get("request_id"); exists {
-		if id, ok := requestID.(string); ok {
+		if id, ok := requestID.(string); ok && id != "" {
 			return id
 		}
 	}
-	return ""
+
+	requestID := generateRequestID()
+	c.Set("request_id", requestID)
+	return requestID
+}
+
+// isBrokenPipe reports whether a recovered panic was caused by the client
+// closing the connection.
+func isBrokenPipe(recovered interface{}) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+
+	var netErr *net.OpError
+	if !errors.As(err, &netErr) {
+		return false
+	}
+
+	msg := strings.ToLower(netErr.Error())
+	return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
+}
+
+// isAcceptedContentType reports whether a media type is in the allowed list.
+// Structured JSON types such as application/merge-patch+json count as JSON.
+func isAcceptedContentType(contentType string, allowed []string) bool {
+	for _, accepted := range allowed {
+		if contentType == accepted {
+			return true
+		}
+		if accepted == "application/json" && strings.HasPrefix(contentType, "application/") && strings.HasSuffix(contentType, "+json") {
+			return true
+		}
+	}
+	return false
+}
+
+// routeKey builds the lookup key for per-route settings.
+func routeKey(method, fullPath string) string {
+	return method + " " + fullPath
+}
+
+// requestIDHeader returns the configured request ID header name.
+func (r *Router) requestIDHeader() string {
+	if r.config.RequestIDHeader == "" {
+		return core.DefaultRequestIDHeader
+	}
+	return r.config.RequestIDHeader
+}
+
+// parseTraceparent extracts the trace ID from a W3C traceparent header
+// (version-traceid-parentid-flags) and formats it as a UUID.
+func parseTraceparent(header string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return "", false
+	}
+
+	traceID, err := uuid.Parse(parts[1])
+	if err != nil || traceID == uuid.Nil {
+		return "", false
+	}
+
+	return traceID.String(), true
 }
@@ -0,0 +1,73 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"news-aggregator/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestFormatLoggedBodyRedactsCredentials(t *testing.T) {
+	tests := map[string]struct {
+		body        string
+		contentType string
+		want        string
+	}{
+		"json": {
+			`{"email":"a@example.com","password":"secret"}`,
+			"application/json; charset=utf-8",
+			`{"email":"a@example.com","password":"[REDACTED]"}`,
+		},
+		"form": {
+			"email=a%40example.com&password=secret&refresh_token=abc",
+			"application/x-www-form-urlencoded",
+			"email=a%40example.com&password=[REDACTED]&refresh_token=[REDACTED]",
+		},
+		"plain text": {"password=secret", "text/plain", `("text/plain" body not logged)`},
+		"no type":    {"password=secret", "", `("" body not logged)`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := formatLoggedBody([]byte(tt.body), tt.contentType, 1024); got != tt.want {
+				t.Errorf("formatLoggedBody = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLoggedBodyRedactsTruncatedForm(t *testing.T) {
+	got := formatLoggedBody([]byte("user=a&password=secretvalue"), "application/x-www-form-urlencoded", 20)
+	if strings.Contains(got, "secret") || !strings.HasSuffix(got, "...(truncated)") {
+		t.Errorf("formatLoggedBody = %s, want the password redacted and the body truncated", got)
+	}
+}
+
+func TestRecoveryMiddlewareCountsPanics(t *testing.T) {
+	r := &Router{logger: zerolog.Nop()}
+	engine := gin.New()
+	engine.Use(r.recoveryMiddleware())
+	engine.GET("/panic", func(c *gin.Context) { panic("handler bug") })
+
+	before := testutil.ToFloat64(metrics.GatewayPanics)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	if panics := testutil.ToFloat64(metrics.GatewayPanics) - before; panics != 1 {
+		t.Errorf("panic metric increased by %v, want 1", panics)
+	}
+}
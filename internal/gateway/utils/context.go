@@ -13,13 +13,20 @@ import (
 
 // ContextManager implements context management functionality.
 type ContextManager struct {
-	logger zerolog.Logger
+	logger          zerolog.Logger
+	requestIDHeader string
 }
 
-// NewContextManager creates a new context manager.
-func NewContextManager(logger zerolog.Logger) core.ContextManager {
+// NewContextManager creates a new context manager reading and setting
+// request IDs in the given header (core.DefaultRequestIDHeader when empty).
+func NewContextManager(logger zerolog.Logger, requestIDHeader string) core.ContextManager {
+	if requestIDHeader == "" {
+		requestIDHeader = core.DefaultRequestIDHeader
+	}
+
 	return &ContextManager{
-		logger: logger.With().Str("component", "context_manager").Logger(),
+		logger:          logger.With().Str("component", "context_manager").Logger(),
+		requestIDHeader: requestIDHeader,
 	}
 }
 
@@ -94,7 +101,7 @@ func (cm *ContextManager) GetRequestID(c *gin.Context) string {
 	}
 	
 	// Check for request ID in headers
-	headerID := c.GetHeader("X-Request-ID")
+	headerID := c.GetHeader(cm.requestIDHeader)
 	if headerID != "" {
 		c.Set("request_id", headerID)
 		return headerID
@@ -103,7 +110,7 @@ func (cm *ContextManager) GetRequestID(c *gin.Context) string {
 	// Generate new request ID
 	newID := uuid.New().String()
 	c.Set("request_id", newID)
-	c.Header("X-Request-ID", newID)
+	c.Header(cm.requestIDHeader, newID)
 	
 	return newID
 }
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+func TestGetRequestIDUsesConfiguredHeader(t *testing.T) {
+	cm := NewContextManager(zerolog.Nop(), "X-Correlation-ID")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("X-Correlation-ID", "abc-123")
+	c.Request.Header.Set("X-Request-ID", "ignored")
+
+	if got := cm.GetRequestID(c); got != "abc-123" {
+		t.Errorf("GetRequestID = %q, want the configured header's value", got)
+	}
+
+	// A generated ID is returned in the configured header
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	id := cm.GetRequestID(c)
+	if got := w.Header().Get("X-Correlation-ID"); got == "" || got != id {
+		t.Errorf("X-Correlation-ID = %q, want the generated ID %q", got, id)
+	}
+}
@@ -3,6 +3,7 @@ package utils
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"news-aggregator/internal/gateway/core"
@@ -36,6 +37,16 @@ func (rw *ResponseWriter) Success(c *gin.Context, data interface{}) {
 
 // SuccessWithPagination writes a successful response with pagination.
 func (rw *ResponseWriter) SuccessWithPagination(c *gin.Context, data interface{}, pagination core.PaginationInfo) {
+	// Links are only useful when the page is selected by query parameters
+	if c.Request.Method == http.MethodGet {
+		if pagination.HasNext {
+			pagination.NextURL = pageURL(c, pagination.Page+1, pagination.Limit)
+		}
+		if pagination.HasPrev {
+			pagination.PrevURL = pageURL(c, pagination.Page-1, pagination.Limit)
+		}
+	}
+
 	meta := &core.Meta{
 		Pagination: &pagination,
 	}
@@ -50,8 +61,22 @@ func (rw *ResponseWriter) SuccessWithPagination(c *gin.Context, data interface{}
 	c.JSON(http.StatusOK, response)
 }
 
+// pageURL returns the current request path and query with page and limit
+// replaced, keeping every other parameter (filters, sort) as sent
+func pageURL(c *gin.Context, page, limit int) string {
+	query := c.Request.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	return c.Request.URL.Path + "?" + query.Encode()
+}
+
 // Error writes an error response.
 func (rw *ResponseWriter) Error(c *gin.Context, err error) {
+	if rw.writeContextError(c, err) {
+		return
+	}
+	
 	statusCode := core.MapErrorToHTTPStatus(err)
 	errorCode := core.MapErrorToCode(err)
 	message := core.SanitizeErrorMessage(err)
@@ -177,6 +202,17 @@ func (rw *ResponseWriter) Accepted(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusAccepted, response)
 }
 
+// SuccessWithStatus writes a successful response with a custom status code.
+func (rw *ResponseWriter) SuccessWithStatus(c *gin.Context, status int, data interface{}) {
+	response := core.SuccessResponse{
+		Data:      data,
+		RequestID: rw.getRequestID(c),
+		Timestamp: time.Now().UTC(),
+	}
+
+	c.JSON(status, response)
+}
+
 // NotFound writes a not found error response.
 func (rw *ResponseWriter) NotFound(c *gin.Context, message string) {
 	if message == "" {
@@ -215,6 +251,10 @@ func (rw *ResponseWriter) BadRequest(c *gin.Context, message string) {
 
 // InternalError writes an internal server error response.
 func (rw *ResponseWriter) InternalError(c *gin.Context, err error) {
+	if rw.writeContextError(c, err) {
+		return
+	}
+	
 	// Log the actual error but don't expose it to the client
 	rw.logger.Error().
 		Err(err).
@@ -251,6 +291,43 @@ func (rw *ResponseWriter) Batch(c *gin.Context, response core.BatchResponse) {
 	c.JSON(statusCode, response)
 }
 
+// writeContextError writes a 499 or 504 response for cancelled or timed out
+// requests. These are not server failures, so they are logged at debug level
+// and kept out of the error path. It returns false for any other error.
+func (rw *ResponseWriter) writeContextError(c *gin.Context, err error) bool {
+	if !core.IsContextError(err) {
+		return false
+	}
+	
+	statusCode := core.MapErrorToHTTPStatus(err)
+	message := "Request timed out"
+	if statusCode == core.StatusClientClosedRequest {
+		message = "Client closed request"
+	}
+	
+	rw.logger.Debug().
+		Err(err).
+		Str("request_id", rw.getRequestID(c)).
+		Str("path", c.Request.URL.Path).
+		Str("method", c.Request.Method).
+		Int("status_code", statusCode).
+		Msg("Request cancelled")
+	
+	response := core.ErrorResponse{
+		Error: core.APIError{
+			Code:    core.MapErrorToCode(err),
+			Message: message,
+		},
+		RequestID: rw.getRequestID(c),
+		Timestamp: time.Now().UTC(),
+		Path:      c.Request.URL.Path,
+		Method:    c.Request.Method,
+	}
+	
+	c.JSON(statusCode, response)
+	return true
+}
+
 // getRequestID extracts request ID from context.
 func (rw *ResponseWriter) getRequestID(c *gin.Context) string {
 	if requestID, exists := c.Get("request_id"); exists {
@@ -278,6 +355,10 @@ func (rw *ResponseWriter) mapStatusCodeToErrorCode(statusCode int) string {
 		return core.CodeInternalError
 	case http.StatusServiceUnavailable:
 		return core.CodeServiceError
+	case http.StatusGatewayTimeout:
+		return core.CodeTimeout
+	case core.StatusClientClosedRequest:
+		return core.CodeClientClosed
 	default:
 		return core.CodeInternalError
 	}
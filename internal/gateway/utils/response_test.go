@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/gateway/core"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// slowLookup stands in for a pgx or Elasticsearch call: it returns the
+// context error, wrapped the way repositories and services wrap it.
+func slowLookup(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("failed to get news: %w", ctx.Err())
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+
+// serve runs handler for a request carrying ctx and returns the recorded
+// response and the log output.
+func serve(ctx context.Context, handler func(rw core.ResponseWriter, c *gin.Context)) (*httptest.ResponseRecorder, string) {
+	var logs bytes.Buffer
+	rw := NewResponseWriter(zerolog.New(&logs))
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/news", nil).WithContext(ctx)
+
+	handler(rw, c)
+	return recorder, logs.String()
+}
+
+func errorCode(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var body core.ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid error body %q: %v", recorder.Body.String(), err)
+	}
+	return body.Error.Code
+}
+
+func TestClientCancellationIsNotAnInternalError(t *testing.T) {
+	writers := map[string]func(rw core.ResponseWriter, c *gin.Context, err error){
+		"InternalError": func(rw core.ResponseWriter, c *gin.Context, err error) { rw.InternalError(c, err) },
+		"Error":         func(rw core.ResponseWriter, c *gin.Context, err error) { rw.Error(c, err) },
+	}
+
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			// The client disconnects while the lookup is running
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, cancel)
+
+			recorder, logs := serve(ctx, func(rw core.ResponseWriter, c *gin.Context) {
+				if err := slowLookup(c.Request.Context()); err != nil {
+					write(rw, c, err)
+				}
+			})
+
+			if recorder.Code != core.StatusClientClosedRequest {
+				t.Errorf("status = %d, want %d", recorder.Code, core.StatusClientClosedRequest)
+			}
+			if code := errorCode(t, recorder); code != core.CodeClientClosed {
+				t.Errorf("code = %q, want %q", code, core.CodeClientClosed)
+			}
+			if strings.Contains(logs, `"level":"error"`) {
+				t.Errorf("cancellation logged as an error: %s", logs)
+			}
+		})
+	}
+}
+
+func TestDeadlineExceededReturnsGatewayTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	recorder, logs := serve(ctx, func(rw core.ResponseWriter, c *gin.Context) {
+		if err := slowLookup(c.Request.Context()); err != nil {
+			rw.InternalError(c, err)
+		}
+	})
+
+	if recorder.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusGatewayTimeout)
+	}
+	if code := errorCode(t, recorder); code != core.CodeTimeout {
+		t.Errorf("code = %q, want %q", code, core.CodeTimeout)
+	}
+	if strings.Contains(logs, `"level":"error"`) {
+		t.Errorf("timeout logged as an error: %s", logs)
+	}
+}
+
+func TestOtherErrorsRemainInternalErrors(t *testing.T) {
+	recorder, logs := serve(context.Background(), func(rw core.ResponseWriter, c *gin.Context) {
+		rw.InternalError(c, errors.New("connection refused"))
+	})
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(logs, `"level":"error"`) {
+		t.Errorf("internal error not logged as an error: %s", logs)
+	}
+}
+
+func TestSuccessWithStatusUsesTheSuccessEnvelope(t *testing.T) {
+	recorder, _ := serve(context.Background(), func(rw core.ResponseWriter, c *gin.Context) {
+		rw.SuccessWithStatus(c, http.StatusMultiStatus, map[string]bool{"deleted": true})
+	})
+
+	if recorder.Code != http.StatusMultiStatus {
+		t.Errorf("status = %d, want 207", recorder.Code)
+	}
+
+	var body core.SuccessResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body %q: %v", recorder.Body.String(), err)
+	}
+	if data, _ := body.Data.(map[string]interface{}); data["deleted"] != true || body.Timestamp.IsZero() {
+		t.Errorf("body = %+v, want the data in a success envelope", body)
+	}
+}
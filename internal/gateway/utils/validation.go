@@ -128,6 +128,18 @@ func (v *RequestValidator) ValidateNewsFilter(filter interface{}) error {
 			errors["source"] = err.Error()
 		}
 	}
+
+	// Validate multi-value categories and sources
+	for i, category := range newsFilter.Categories {
+		if err := v.validateCategory(category); err != nil {
+			errors[fmt.Sprintf("categories[%d]", i)] = err.Error()
+		}
+	}
+	for i, source := range newsFilter.Sources {
+		if err := v.validateSource(source); err != nil {
+			errors[fmt.Sprintf("sources[%d]", i)] = err.Error()
+		}
+	}
 	
 	// Validate date range
 	if !newsFilter.DateFrom.IsZero() && !newsFilter.DateTo.IsZero() {
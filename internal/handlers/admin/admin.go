@@ -0,0 +1,1205 @@
+// Package admin provides administrative HTTP handlers that are independent of any gateway.
+package admin
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	handlerCore "news-aggregator/internal/handlers/core"
+	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
+	sourceModels "news-aggregator/internal/models/source"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// Handler implements admin operations independently.
+type Handler struct {
+	deps   *handlerCore.HandlerDependencies
+	config handlerCore.HandlerConfig
+	logger zerolog.Logger
+}
+
+// NewHandler creates a new independent admin handler.
+func NewHandler(deps *handlerCore.HandlerDependencies, config handlerCore.HandlerConfig) handlerCore.AdminHandler {
+	return &Handler{
+		deps:   deps,
+		config: config,
+		logger: deps.Logger.With().Str("handler", "admin").Logger(),
+	}
+}
+
+// RegisterRoutes registers admin routes.
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	requireNews := handlerCore.RequireServices(h.deps, handlerCore.ServiceNews)
+	requireUser := handlerCore.RequireServices(h.deps, handlerCore.ServiceUser)
+	requireTrending := handlerCore.RequireServices(h.deps, handlerCore.ServiceTrending)
+	requireScoring := handlerCore.RequireServices(h.deps, handlerCore.ServiceScoring)
+
+	admin := router.Group(h.GetBasePath())
+	{
+		// User management
+		admin.GET("/users", requireUser, h.GetUsers)
+
+		// System statistics
+		admin.GET("/stats", requireNews, h.GetStats)
+		admin.GET("/stats/daily", requireNews, h.GetDailyStats)
+		admin.GET("/sentiment/trends", requireNews, h.GetSentimentTrends)
+
+		// Source management
+		admin.POST("/sources", requireNews, h.AddSource)
+		admin.POST("/sources/import", requireNews, h.ImportSources)
+		admin.GET("/sources/export", requireNews, h.ExportSources)
+		admin.GET("/sources/health", requireNews, h.GetSourceHealth)
+		admin.GET("/sources/ingest-stats", requireNews, h.GetSourceIngestStats)
+		admin.PATCH("/sources/bulk", requireNews, h.BulkUpdateSources)
+		admin.PUT("/sources/:id", requireNews, h.UpdateSource)
+		admin.DELETE("/sources/:id", requireNews, h.DeleteSource)
+		admin.POST("/sources/:id/enable", requireNews, h.EnableSource)
+		admin.GET("/sources/:id/articles", requireNews, h.GetSourceArticles)
+
+		// Article corrections
+		admin.PATCH("/news/:id", requireNews, h.PatchNews)
+		admin.DELETE("/news/:id", requireNews, h.DeleteNews)
+
+		// Category management
+		admin.POST("/categories", requireNews, h.AddCategory)
+		admin.PUT("/categories/:id", requireNews, h.UpdateCategory)
+		admin.DELETE("/categories/:id", requireNews, h.DeleteCategory)
+
+		// Tag management
+		admin.POST("/tags/merge", requireNews, h.MergeTags)
+
+		// Maintenance
+		admin.POST("/cleanup", requireNews, h.CleanupOldArticles)
+		admin.POST("/trending/refresh", requireTrending, h.RefreshTrending)
+		admin.POST("/scoring/refresh", requireScoring, h.RefreshScores)
+		admin.GET("/scoring/refresh/:id", requireScoring, h.GetScoreRefresh)
+	}
+}
+
+// GetBasePath returns the base path for admin routes. The router mounts
+// admin handlers under /api/v1/admin already.
+func (h *Handler) GetBasePath() string {
+	return ""
+}
+
+// GetName returns a unique name for this handler.
+func (h *Handler) GetName() string {
+	return "admin_handler"
+}
+
+// maxOPMLImportSize bounds the size of an uploaded OPML document.
+const maxOPMLImportSize = 5 << 20
+
+// GetRouteMetadata declares the routes that accept non-JSON bodies.
+func (h *Handler) GetRouteMetadata() []handlerCore.RouteMetadata {
+	return []handlerCore.RouteMetadata{
+		{
+			Method:       http.MethodPost,
+			Path:         "/sources/import",
+			MaxBodySize:  maxOPMLImportSize,
+			ContentTypes: []string{"multipart/form-data", "application/xml", "text/xml", "text/x-opml", "application/octet-stream"},
+		},
+	}
+}
+
+// GetUsers retrieves all users.
+func (h *Handler) GetUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	if page < 1 {
+		page = 1
+	}
+	limit = h.config.ClampLimit(limit)
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Int("page", page).
+			Int("limit", limit).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Get users request")
+	}
+
+	users, total, err := h.deps.UserService.GetUsers(c.Request.Context(), page, limit)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get users")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	pagination := handlerCore.NewPaginationInfo(page, limit, int64(total))
+	h.deps.ResponseWriter.SuccessWithPagination(c, users, pagination)
+}
+
+// GetStats retrieves system statistics, optionally narrowed by the from, to,
+// category and source query parameters.
+func (h *Handler) GetStats(c *gin.Context) {
+	filter := models.StatsFilter{
+		Category: c.Query("category"),
+		Source:   c.Query("source"),
+	}
+
+	var err error
+	if filter.From, err = parseStatsDate(c.Query("from"), false); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid from date (use YYYY-MM-DD or RFC 3339)")
+		return
+	}
+	if filter.To, err = parseStatsDate(c.Query("to"), true); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid to date (use YYYY-MM-DD or RFC 3339)")
+		return
+	}
+
+	if err := filter.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("category", filter.Category).
+			Str("source", filter.Source).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Get stats request")
+	}
+
+	stats, err := h.deps.NewsService.GetStatsWithFilter(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get stats")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, stats)
+}
+
+const (
+	// defaultDailyStatsDays is the window GetDailyStats covers without a from date
+	defaultDailyStatsDays = 30
+
+	// maxDailyStatsDays caps the window GetDailyStats covers
+	maxDailyStatsDays = 366
+)
+
+// GetDailyStats returns per-day article counts between the from and to
+// query parameters, defaulting to the last 30 days. Days without articles
+// are included with a zero count.
+func (h *Handler) GetDailyStats(c *gin.Context) {
+	from, to, ok := h.statsWindow(c)
+	if !ok {
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Time("from", from).
+			Time("to", to).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Get daily stats request")
+	}
+
+	counts, err := h.deps.NewsService.GetDailyCounts(c.Request.Context(), from, to)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get daily stats")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"from":  from,
+		"to":    to,
+		"daily": counts,
+	})
+}
+
+// statsWindow reads the day window of a stats request from its from and
+// to query parameters, defaulting to the last defaultDailyStatsDays days
+// up to the end of today. It answers 400 and returns false when the window
+// is invalid or longer than maxDailyStatsDays.
+func (h *Handler) statsWindow(c *gin.Context) (from, to time.Time, ok bool) {
+	from, err := parseStatsDate(c.Query("from"), false)
+	if err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid from date (use YYYY-MM-DD or RFC 3339)")
+		return time.Time{}, time.Time{}, false
+	}
+	to, err = parseStatsDate(c.Query("to"), true)
+	if err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid to date (use YYYY-MM-DD or RFC 3339)")
+		return time.Time{}, time.Time{}, false
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultDailyStatsDays)
+	}
+
+	if !from.Before(to) {
+		h.deps.ResponseWriter.BadRequest(c, newsModels.ErrInvalidDateRange.Error())
+		return time.Time{}, time.Time{}, false
+	}
+	if to.Sub(from) > maxDailyStatsDays*24*time.Hour {
+		h.deps.ResponseWriter.BadRequest(c, fmt.Sprintf("Date range cannot exceed %d days", maxDailyStatsDays))
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, to, true
+}
+
+// parseStatsDate parses a stats date bound given as YYYY-MM-DD or RFC 3339.
+// A date-only upper bound covers the whole day, so to=2024-01-07 includes
+// articles published on January 7th.
+func parseStatsDate(value string, upper bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// AddSource adds a new news source.
+func (h *Handler) AddSource(c *gin.Context) {
+	var req models.SourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("name", req.Name).
+			Str("url", req.URL).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Add source request")
+	}
+
+	source, err := h.deps.NewsService.AddSource(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("name", req.Name).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to add source")
+
+		if errors.Is(err, sourceModels.ErrInconsistentRateLimit) {
+			h.deps.ResponseWriter.BadRequest(c, err.Error())
+			return
+		}
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, source)
+}
+
+// ImportSources bulk-adds RSS sources from an OPML document, sent either as
+// the "file" field of a multipart form or as the raw request body.
+func (h *Handler) ImportSources(c *gin.Context) {
+	body := io.Reader(c.Request.Body)
+	if c.ContentType() == "multipart/form-data" {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			h.deps.ResponseWriter.BadRequest(c, "OPML file is required")
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			h.deps.ResponseWriter.BadRequest(c, "Failed to read OPML file")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	feeds, err := sourceModels.ParseOPML(body)
+	if err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Int("feeds", len(feeds)).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Import sources request")
+	}
+
+	result, err := h.deps.NewsService.ImportSources(c.Request.Context(), feeds)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to import sources")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, result)
+}
+
+// ExportSources serializes the configured sources as JSON (default) or,
+// with format=opml, as an OPML document of the RSS feeds.
+func (h *Handler) ExportSources(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "opml" {
+		h.deps.ResponseWriter.BadRequest(c, "format must be json or opml")
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("format", format).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Export sources request")
+	}
+
+	sources, err := h.deps.NewsService.ExportSources(c.Request.Context())
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to export sources")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	if format == "json" {
+		h.deps.ResponseWriter.Success(c, sources)
+		return
+	}
+
+	doc, err := xml.MarshalIndent(sourceModels.NewOPML("News Aggregator Sources", sources), "", "  ")
+	if err != nil {
+		h.deps.ResponseWriter.InternalError(c, fmt.Errorf("failed to encode OPML: %w", err))
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="sources.opml"`)
+	c.Data(http.StatusOK, "text/x-opml; charset=utf-8", append([]byte(xml.Header), doc...))
+}
+
+// GetSourceHealth summarizes sources whose recent fetches failed.
+func (h *Handler) GetSourceHealth(c *gin.Context) {
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Source health request")
+	}
+
+	summary, err := h.deps.NewsService.GetSourceHealth(c.Request.Context())
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get source health")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, summary)
+}
+
+// GetSourceIngestStats returns the daily fetched, new, duplicate and failed
+// article counts of each source between the from and to query parameters,
+// defaulting to the last 30 days. The source parameter limits the result
+// to one source.
+func (h *Handler) GetSourceIngestStats(c *gin.Context) {
+	from, to, ok := h.statsWindow(c)
+	if !ok {
+		return
+	}
+
+	source := c.Query("source")
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("source", source).
+			Time("from", from).
+			Time("to", to).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Get source ingest stats request")
+	}
+
+	stats, err := h.deps.NewsService.GetIngestStats(c.Request.Context(), source, from, to)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get source ingest stats")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"from":  from,
+		"to":    to,
+		"stats": stats,
+	})
+}
+
+// GetSentimentTrends returns the average sentiment of each category's
+// analyzed articles over the last days query parameter, or the configured
+// window without one. Categories without analyzed articles are included
+// with zeros.
+func (h *Handler) GetSentimentTrends(c *gin.Context) {
+	var window time.Duration
+	if value := c.Query("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 || days > maxDailyStatsDays {
+			h.deps.ResponseWriter.BadRequest(c, fmt.Sprintf("days must be between 1 and %d", maxDailyStatsDays))
+			return
+		}
+		window = time.Duration(days) * 24 * time.Hour
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Dur("window", window).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Get sentiment trends request")
+	}
+
+	sentiments, err := h.deps.NewsService.GetSentimentTrends(c.Request.Context(), window)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get sentiment trends")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"categories": sentiments,
+	})
+}
+
+// maxBulkSourceUpdates bounds the number of sources changed in one request.
+const maxBulkSourceUpdates = 500
+
+// BulkUpdateSources applies partial updates to many sources. The collector
+// picks up the new schedules and rate limits on its next source reload.
+func (h *Handler) BulkUpdateSources(c *gin.Context) {
+	var updates []models.SourceBulkUpdate
+	if err := c.ShouldBindJSON(&updates); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if len(updates) == 0 {
+		h.deps.ResponseWriter.BadRequest(c, "At least one update is required")
+		return
+	}
+	if len(updates) > maxBulkSourceUpdates {
+		h.deps.ResponseWriter.BadRequest(c, fmt.Sprintf("At most %d updates are allowed per request", maxBulkSourceUpdates))
+		return
+	}
+
+	for i := range updates {
+		if err := updates[i].Validate(); err != nil {
+			h.deps.ResponseWriter.BadRequest(c, fmt.Sprintf("update %d: %s", i, err.Error()))
+			return
+		}
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Int("count", len(updates)).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Bulk update sources request")
+	}
+
+	results, err := h.deps.NewsService.BulkUpdateSources(c.Request.Context(), updates)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to bulk update sources")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"results": results,
+	})
+}
+
+// UpdateSource updates a news source.
+func (h *Handler) UpdateSource(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Source ID is required")
+		return
+	}
+
+	var req models.SourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Update source request")
+	}
+
+	if err := h.deps.NewsService.UpdateSource(c.Request.Context(), id, &req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to update source")
+
+		if errors.Is(err, sourceModels.ErrInconsistentRateLimit) {
+			h.deps.ResponseWriter.BadRequest(c, err.Error())
+			return
+		}
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message": "Source updated successfully",
+	})
+}
+
+// DeleteSource deletes a news source.
+func (h *Handler) DeleteSource(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Source ID is required")
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Delete source request")
+	}
+
+	if err := h.deps.NewsService.DeleteSource(c.Request.Context(), id); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to delete source")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message": "Source deleted successfully",
+	})
+}
+
+// AddCategory adds a new news category.
+func (h *Handler) AddCategory(c *gin.Context) {
+	var req models.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("name", req.Name).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Add category request")
+	}
+
+	category, err := h.deps.NewsService.AddCategory(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, newsModels.ErrCategoryExists) {
+			h.deps.ResponseWriter.ErrorWithCode(c, http.StatusConflict, "A category with this name already exists")
+			return
+		}
+		h.logger.Error().
+			Err(err).
+			Str("name", req.Name).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to add category")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, category)
+}
+
+// UpdateCategory updates a news category. Renaming a category also moves
+// the articles filed under the old name and re-indexes them when search is
+// enabled.
+func (h *Handler) UpdateCategory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Category ID is required")
+		return
+	}
+
+	var req models.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Update category request")
+	}
+
+	category, moved, err := h.deps.NewsService.UpdateCategory(c.Request.Context(), id, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, newsModels.ErrCategoryNotFound):
+			h.deps.ResponseWriter.NotFound(c, "Category not found")
+			return
+		case errors.Is(err, newsModels.ErrCategoryExists):
+			h.deps.ResponseWriter.ErrorWithCode(c, http.StatusConflict, "A category with this name already exists")
+			return
+		}
+		h.logger.Error().
+			Err(err).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to update category")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.reindex(c, moved, "category rename")
+
+	h.deps.ResponseWriter.Success(c, category)
+}
+
+// DeleteCategory deletes a news category. A category that is still assigned
+// to articles is only deleted when reassign_to names the category to move
+// those articles to; moved articles are re-indexed when search is enabled.
+func (h *Handler) DeleteCategory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Category ID is required")
+		return
+	}
+
+	reassignTo := c.Query("reassign_to")
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Str("reassign_to", reassignTo).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Delete category request")
+	}
+
+	reassigned, err := h.deps.NewsService.DeleteCategory(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		switch {
+		case errors.Is(err, newsModels.ErrCategoryInUse):
+			h.deps.ResponseWriter.ErrorWithCode(c, http.StatusConflict,
+				"Category is in use; pass reassign_to to move its articles to another category")
+			return
+		case errors.Is(err, newsModels.ErrCategoryNotFound):
+			h.deps.ResponseWriter.NotFound(c, "Category not found")
+			return
+		case errors.Is(err, newsModels.ErrReassignToSelf):
+			h.deps.ResponseWriter.BadRequest(c, "reassign_to must name a different category")
+			return
+		}
+		h.logger.Error().
+			Err(err).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to delete category")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	reindexed := h.reindex(c, reassigned, "category reassignment")
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message":             "Category deleted successfully",
+		"reassigned_articles": len(reassigned),
+		"reindexed":           reindexed,
+	})
+}
+
+// MergeTags merges one or more tags into a target tag across all articles
+// and re-indexes the affected articles when search is enabled.
+func (h *Handler) MergeTags(c *gin.Context) {
+	var req models.TagMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Strs("from", req.From).
+			Str("to", req.To).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Merge tags request")
+	}
+
+	updated, err := h.deps.NewsService.MergeTags(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to merge tags")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	reindexed := h.reindex(c, updated, "tag merge")
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message":          "Tags merged successfully",
+		"updated_articles": len(updated),
+		"reindexed":        reindexed,
+	})
+}
+
+// reindex writes articles changed by a bulk update to the search index when
+// search is enabled. Failures are logged, since the database is already
+// updated; it reports whether the articles were re-indexed.
+func (h *Handler) reindex(c *gin.Context, articles []models.News, change string) bool {
+	if h.deps.SearchService == nil || len(articles) == 0 {
+		return false
+	}
+
+	if err := h.deps.SearchService.BulkIndex(c.Request.Context(), articles); err != nil {
+		h.logger.Warn().
+			Err(err).
+			Int("articles", len(articles)).
+			Str("change", change).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to re-index updated articles")
+		return false
+	}
+	return true
+}
+
+// PatchNews updates only the fields present in the request body of an
+// article and re-indexes it when search is enabled.
+func (h *Handler) PatchNews(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	var fields map[string]interface{}
+	if err := c.ShouldBindJSON(&fields); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Int("fields", len(fields)).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Patch news request")
+	}
+
+	if err := h.deps.NewsService.PatchNews(c.Request.Context(), id, fields); err != nil {
+		var patchErr *newsModels.PatchError
+		switch {
+		case errors.As(err, &patchErr):
+			h.deps.ResponseWriter.BadRequest(c, patchErr.Error())
+		case errors.Is(err, newsModels.ErrNewsNotFound):
+			h.deps.ResponseWriter.NotFound(c, "News article not found")
+		case errors.Is(err, newsModels.ErrDuplicateNews):
+			h.deps.ResponseWriter.ErrorWithCode(c, http.StatusConflict, "Another article already has this URL or content")
+		default:
+			h.logger.Error().
+				Err(err).
+				Str("id", id).
+				Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+				Msg("Failed to patch news")
+
+			h.deps.ResponseWriter.InternalError(c, err)
+		}
+		return
+	}
+
+	article, err := h.deps.NewsService.GetNewsByID(c.Request.Context(), id)
+	if err != nil {
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	// Keep search results in line with the correction; this is best effort
+	if h.deps.HasService(handlerCore.ServiceSearch) {
+		if err := h.deps.SearchService.IndexNews(c.Request.Context(), article); err != nil {
+			h.logger.Warn().
+				Err(err).
+				Str("id", id).
+				Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+				Msg("Failed to re-index patched article")
+		}
+	}
+
+	h.deps.ResponseWriter.Success(c, article)
+}
+
+// DeleteNews removes an article from the database and then from the search
+// index. When only the database delete succeeds the response is 207 Multi-Status
+// with the index error, and the failure is logged so the orphaned document can
+// be removed later. Without a search service the index delete is reported as
+// skipped.
+func (h *Handler) DeleteNews(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Delete news request")
+	}
+
+	dbErr := h.deps.NewsService.DeleteNews(c.Request.Context(), id)
+	if dbErr != nil && !errors.Is(dbErr, newsModels.ErrNewsNotFound) {
+		h.logger.Error().
+			Err(dbErr).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to delete news")
+
+		h.deps.ResponseWriter.InternalError(c, dbErr)
+		return
+	}
+
+	// Also runs for articles already gone from the database, so a document
+	// left behind by an earlier partial delete is purged too
+	searchEnabled := h.deps.HasService(handlerCore.ServiceSearch)
+	var indexErr error
+	if searchEnabled {
+		indexErr = h.deps.SearchService.DeleteFromIndex(c.Request.Context(), id)
+	}
+
+	if dbErr != nil {
+		h.deps.ResponseWriter.NotFound(c, "News article not found")
+		return
+	}
+
+	result := gin.H{
+		"id":            id,
+		"deleted":       true,
+		"index_deleted": searchEnabled && indexErr == nil,
+	}
+	if !searchEnabled {
+		result["index_skipped"] = true
+	}
+
+	if indexErr != nil {
+		h.logger.Error().
+			Err(indexErr).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Article deleted from database but not from search index; delete it again to reconcile")
+
+		result["index_error"] = indexErr.Error()
+		h.deps.ResponseWriter.SuccessWithStatus(c, http.StatusMultiStatus, result)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, result)
+}
+
+// EnableSource re-enables a source, e.g. one disabled automatically after
+// repeated fetch failures, and resets its failure streak.
+func (h *Handler) EnableSource(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Source ID is required")
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Enable source request")
+	}
+
+	if err := h.deps.NewsService.EnableSource(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, sourceModels.ErrInvalidSourceID):
+			h.deps.ResponseWriter.BadRequest(c, sourceModels.ErrInvalidSourceID.Error())
+		case errors.Is(err, sourceModels.ErrSourceNotFound):
+			h.deps.ResponseWriter.NotFound(c, "Source not found")
+		default:
+			h.logger.Error().
+				Err(err).
+				Str("id", id).
+				Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+				Msg("Failed to enable source")
+
+			h.deps.ResponseWriter.InternalError(c, err)
+		}
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message": "Source enabled successfully",
+	})
+}
+
+// GetSourceArticles returns the newest articles of the source with the
+// given ID, paginated, together with how many it stored in the last 24
+// hours and 7 days. Articles are matched by the source's current name, so
+// a renamed source without articles under its new name yields an empty
+// page rather than an error.
+func (h *Handler) GetSourceArticles(c *gin.Context) {
+	id := c.Param("id")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	if page < 1 {
+		page = 1
+	}
+	limit = h.config.ClampLimit(limit)
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Int("page", page).
+			Int("limit", limit).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Get source articles request")
+	}
+
+	source, err := h.deps.NewsService.GetSourceByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sourceModels.ErrInvalidSourceID):
+			h.deps.ResponseWriter.BadRequest(c, sourceModels.ErrInvalidSourceID.Error())
+		case errors.Is(err, sourceModels.ErrSourceNotFound):
+			h.deps.ResponseWriter.NotFound(c, "Source not found")
+		default:
+			h.logger.Error().
+				Err(err).
+				Str("id", id).
+				Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+				Msg("Failed to get source")
+
+			h.deps.ResponseWriter.InternalError(c, err)
+		}
+		return
+	}
+
+	articles, total, err := h.deps.NewsService.GetNews(c.Request.Context(), models.NewsFilter{
+		Source: source.Name,
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("source", source.Name).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get source articles")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	stats, err := h.deps.NewsService.GetSourceArticleStats(c.Request.Context(), source.Name)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("source", source.Name).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get source article stats")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	pagination := handlerCore.NewPaginationInfo(page, limit, int64(total))
+	h.deps.ResponseWriter.SuccessWithPagination(c, gin.H{
+		"source":   source.WithoutSecrets(),
+		"stats":    stats,
+		"articles": articles,
+	}, pagination)
+}
+
+// CleanupOldArticles triggers cleanup of old articles.
+func (h *Handler) CleanupOldArticles(c *gin.Context) {
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Cleanup old articles request")
+	}
+
+	if err := h.deps.NewsService.CleanupOldArticles(c.Request.Context()); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to cleanup old articles")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	// Remove the same articles from the search index; this is best effort
+	// since the periodic cleanup catches up later
+	deleted := 0
+	indexErr := fmt.Errorf("search service not available")
+	if h.deps.HasService(handlerCore.ServiceSearch) {
+		deleted, indexErr = h.deps.SearchService.DeleteExpired(c.Request.Context())
+	}
+	if indexErr != nil {
+		h.logger.Warn().
+			Err(indexErr).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to cleanup old articles from search index")
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message":       "Old articles cleaned up successfully",
+		"index_deleted": deleted,
+		"index_cleaned": indexErr == nil,
+	})
+}
+
+// RefreshTrending recomputes the cached trending topics immediately.
+func (h *Handler) RefreshTrending(c *gin.Context) {
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Trending refresh request")
+	}
+
+	if err := h.deps.TrendingService.Refresh(c.Request.Context()); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to refresh trending topics")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"message":    "Trending topics refreshed",
+		"updated_at": h.deps.TrendingService.LastUpdated(),
+	})
+}
+
+// RefreshScores starts recalculating the scores of all recent articles in
+// the background and answers 202 with the refresh to poll.
+func (h *Handler) RefreshScores(c *gin.Context) {
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Score refresh request")
+	}
+
+	refresh, err := h.deps.ScoringService.StartScoreRefresh()
+	if err != nil {
+		if errors.Is(err, models.ErrScoreRefreshInProgress) {
+			h.deps.ResponseWriter.ErrorWithCode(c, http.StatusConflict, "A score refresh is already running")
+			return
+		}
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to start score refresh")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Accepted(c, refresh)
+}
+
+// GetScoreRefresh reports the progress of a score refresh.
+func (h *Handler) GetScoreRefresh(c *gin.Context) {
+	refresh, err := h.deps.ScoringService.GetScoreRefresh(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, models.ErrScoreRefreshNotFound) {
+			h.deps.ResponseWriter.NotFound(c, "Score refresh not found")
+			return
+		}
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, refresh)
+}
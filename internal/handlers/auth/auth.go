@@ -30,6 +30,7 @@ func NewHandler(deps *handlerCore.HandlerDependencies, config handlerCore.Handle
 // RegisterRoutes registers authentication routes.
 func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	auth := router.Group(h.GetBasePath())
+	auth.Use(handlerCore.RequireServices(h.deps, handlerCore.ServiceUser))
 	{
 		auth.POST("/login", h.Login)
 		auth.POST("/register", h.Register)
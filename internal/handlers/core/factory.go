@@ -116,21 +116,7 @@ func (f *DefaultHandlerFactory) ValidateDependencies(deps *HandlerDependencies)
 		return fmt.Errorf("config is required")
 	}
 	
-	if deps.NewsService == nil {
-		return fmt.Errorf("news service is required")
-	}
-	
-	if deps.UserService == nil {
-		return fmt.Errorf("user service is required")
-	}
-	
-	if deps.SearchService == nil {
-		return fmt.Errorf("search service is required")
-	}
-	
-	if deps.TrendingService == nil {
-		return fmt.Errorf("trending service is required")
-	}
+	// Services are optional; handlers answer 503 for routes whose service is absent
 	
 	if deps.ResponseWriter == nil {
 		return fmt.Errorf("response writer is required")
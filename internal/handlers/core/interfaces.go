@@ -2,7 +2,10 @@
 package core
 
 import (
+	"time"
+
 	"news-aggregator/internal/config"
+	newsModels "news-aggregator/internal/models/news"
 	"news-aggregator/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -22,6 +25,43 @@ type Handler interface {
 	GetName() string
 }
 
+// RouteMetadata describes per-route settings that differ from the router defaults.
+type RouteMetadata struct {
+	// Method is the HTTP method of the route
+	Method string
+
+	// Path is the route path relative to the handler base path
+	Path string
+
+	// MaxBodySize overrides the global request body limit (0 keeps the default)
+	MaxBodySize int64
+
+	// ContentTypes lists accepted request body media types (empty means JSON only)
+	ContentTypes []string
+
+	// CacheClass overrides the cache class the router assigns to the route
+	// (empty keeps it)
+	CacheClass string
+}
+
+// Cache classes select the configured Cache-Control policy for a route.
+const (
+	// CacheClassArticle is for individual articles, which rarely change
+	CacheClassArticle = "article"
+
+	// CacheClassFeed is for public lists and searches that change often
+	CacheClassFeed = "feed"
+
+	// CacheClassPrivate is for per-user, admin and operational responses
+	CacheClassPrivate = "private"
+)
+
+// RouteMetadataProvider is implemented by handlers that declare per-route metadata.
+type RouteMetadataProvider interface {
+	// GetRouteMetadata returns metadata for routes that need non-default settings
+	GetRouteMetadata() []RouteMetadata
+}
+
 // AuthHandler defines authentication-related operations.
 type AuthHandler interface {
 	Handler
@@ -55,12 +95,24 @@ type NewsHandler interface {
 	// GetNewsByID retrieves a specific news article
 	GetNewsByID(c *gin.Context)
 
+	// GetNewsByIDs retrieves several news articles at once
+	GetNewsByIDs(c *gin.Context)
+
 	// GetCategories retrieves available news categories
 	GetCategories(c *gin.Context)
 
+	// GetTags retrieves the tags of recent articles with their counts
+	GetTags(c *gin.Context)
+
 	// SearchNews searches for news articles
 	SearchNews(c *gin.Context)
 
+	// SearchNewsAdvanced searches with filters and returns facets
+	SearchNewsAdvanced(c *gin.Context)
+
+	// GetSimilarNews retrieves articles similar to a given one
+	GetSimilarNews(c *gin.Context)
+
 	// GetTrendingTopics retrieves trending topics
 	GetTrendingTopics(c *gin.Context)
 }
@@ -98,17 +150,68 @@ type AdminHandler interface {
 	// GetStats retrieves system statistics
 	GetStats(c *gin.Context)
 
+	// GetDailyStats retrieves per-day article counts
+	GetDailyStats(c *gin.Context)
+
 	// AddSource adds a new news source
 	AddSource(c *gin.Context)
 
+	// ImportSources bulk-adds RSS sources from an OPML document
+	ImportSources(c *gin.Context)
+
+	// ExportSources exports the configured sources as JSON or OPML
+	ExportSources(c *gin.Context)
+
+	// GetSourceHealth summarizes sources with recent fetch failures
+	GetSourceHealth(c *gin.Context)
+
+	// GetSourceIngestStats retrieves daily per-source ingestion counts
+	GetSourceIngestStats(c *gin.Context)
+
+	// GetSentimentTrends retrieves the average sentiment per category
+	GetSentimentTrends(c *gin.Context)
+
+	// BulkUpdateSources applies partial updates to many sources
+	BulkUpdateSources(c *gin.Context)
+
 	// UpdateSource updates a news source
 	UpdateSource(c *gin.Context)
 
 	// DeleteSource deletes a news source
 	DeleteSource(c *gin.Context)
 
+	// EnableSource re-enables a source and resets its failure streak
+	EnableSource(c *gin.Context)
+
+	// GetSourceArticles retrieves a source's recent articles and counts
+	GetSourceArticles(c *gin.Context)
+
+	// AddCategory adds a news category
+	AddCategory(c *gin.Context)
+
+	// UpdateCategory updates a news category
+	UpdateCategory(c *gin.Context)
+
+	// DeleteCategory deletes a news category
+	DeleteCategory(c *gin.Context)
+
+	// MergeTags renames or merges tags across all articles
+	MergeTags(c *gin.Context)
+
+	// PatchNews updates selected fields of an article
+	PatchNews(c *gin.Context)
+
+	// DeleteNews removes an article from the database and the search index
+	DeleteNews(c *gin.Context)
+
 	// CleanupOldArticles triggers cleanup of old articles
 	CleanupOldArticles(c *gin.Context)
+
+	// RefreshScores starts a background refresh of all article scores
+	RefreshScores(c *gin.Context)
+
+	// GetScoreRefresh reports the progress of a score refresh
+	GetScoreRefresh(c *gin.Context)
 }
 
 // HealthHandler defines health check operations.
@@ -128,11 +231,13 @@ type HealthHandler interface {
 // HandlerDependencies contains all dependencies needed by handlers.
 // This replaces the gateway-specific HandlerContext.
 type HandlerDependencies struct {
-	// Services
+	// Services. Each one is optional: it is nil when the deployment does not
+	// construct it, and routes that need it are guarded by RequireServices.
 	NewsService     *services.NewsService
 	UserService     *services.UserService
 	SearchService   *services.SearchService
 	TrendingService *services.TrendingService
+	ScoringService  *services.ScoringService
 
 	// Configuration
 	Config *config.Config
@@ -155,6 +260,13 @@ type ResponseWriter interface {
 	// SuccessWithPagination writes a successful response with pagination
 	SuccessWithPagination(c *gin.Context, data interface{}, pagination PaginationInfo)
 
+	// Accepted writes a 202 response for work that continues in the background
+	Accepted(c *gin.Context, data interface{})
+
+	// SuccessWithStatus writes a successful response with the given status,
+	// e.g. 207 when only part of an operation succeeded
+	SuccessWithStatus(c *gin.Context, status int, data interface{})
+
 	// Error writes an error response
 	Error(c *gin.Context, err error)
 
@@ -318,6 +430,56 @@ type HandlerConfig struct {
 
 	// RequestTimeout timeout for handler operations
 	RequestTimeout int
+
+	// FreshnessLevels label articles in responses by age, ordered by
+	// increasing MaxAge; older articles get no freshness
+	FreshnessLevels []newsModels.FreshnessLevel
+
+	// LimitOverrides sets the default and maximum result count of list
+	// endpoints that differ from DefaultPageSize and MaxPageSize
+	LimitOverrides map[string]LimitRange
+}
+
+// LimitRange is the default and maximum number of results of a list endpoint
+type LimitRange struct {
+	Default int
+	Max     int
+}
+
+// Endpoints with their own entry in HandlerConfig.LimitOverrides
+const (
+	LimitTags       = "tags"
+	LimitTrending   = "trending"
+	LimitTopStories = "top_stories"
+	LimitTopScored  = "top_scored"
+	LimitSimilar    = "similar"
+)
+
+// ClampLimit bounds a requested page size: values below 1 (including
+// unparseable ones passed as 0) become DefaultPageSize, values above
+// MaxPageSize become MaxPageSize.
+func (c HandlerConfig) ClampLimit(requested int) int {
+	return LimitRange{Default: c.DefaultPageSize, Max: c.MaxPageSize}.clamp(requested)
+}
+
+// ClampLimitFor is ClampLimit for an endpoint with an entry in
+// LimitOverrides; endpoints without one use the shared page sizes.
+func (c HandlerConfig) ClampLimitFor(endpoint string, requested int) int {
+	limits, ok := c.LimitOverrides[endpoint]
+	if !ok {
+		return c.ClampLimit(requested)
+	}
+	return limits.clamp(requested)
+}
+
+func (r LimitRange) clamp(requested int) int {
+	if requested < 1 {
+		return r.Default
+	}
+	if r.Max > 0 && requested > r.Max {
+		return r.Max
+	}
+	return requested
 }
 
 // DefaultHandlerConfig returns default handler configuration.
@@ -329,5 +491,19 @@ func DefaultHandlerConfig() HandlerConfig {
 		DefaultPageSize:  20,
 		MaxPageSize:      100,
 		RequestTimeout:   30,
+		FreshnessLevels: []newsModels.FreshnessLevel{
+			{Label: "new", MaxAge: time.Hour},
+			{Label: "recent", MaxAge: 6 * time.Hour},
+			{Label: "today", MaxAge: 24 * time.Hour},
+		},
+		LimitOverrides: map[string]LimitRange{
+			// Tags are small and a tag cloud wants many of them
+			LimitTags: {Default: 50, Max: 500},
+			// Ranked lists are only meaningful near the top
+			LimitTrending:   {Default: 10, Max: 50},
+			LimitTopStories: {Default: 10, Max: 50},
+			LimitTopScored:  {Default: 20, Max: 100},
+			LimitSimilar:    {Default: 5, Max: 20},
+		},
 	}
 }
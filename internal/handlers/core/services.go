@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Service names used when reporting an unavailable dependency.
+const (
+	ServiceNews     = "news"
+	ServiceUser     = "user"
+	ServiceSearch   = "search"
+	ServiceTrending = "trending"
+	ServiceScoring  = "scoring"
+)
+
+// HasService reports whether the named service was configured for this deployment.
+func (d *HandlerDependencies) HasService(name string) bool {
+	switch name {
+	case ServiceNews:
+		return d.NewsService != nil
+	case ServiceUser:
+		return d.UserService != nil
+	case ServiceSearch:
+		return d.SearchService != nil
+	case ServiceTrending:
+		return d.TrendingService != nil
+	case ServiceScoring:
+		return d.ScoringService != nil
+	default:
+		return false
+	}
+}
+
+// RequireServices returns middleware that rejects requests with 503 Service
+// Unavailable when any of the named services is absent, so handlers never
+// dereference a service the deployment did not construct.
+func RequireServices(deps *HandlerDependencies, names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range names {
+			if !deps.HasService(name) {
+				deps.ResponseWriter.ErrorWithCode(c, http.StatusServiceUnavailable,
+					fmt.Sprintf("The %s service is not available in this deployment", name))
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,222 @@
+package news
+
+import (
+	"errors"
+
+	newsModels "news-aggregator/internal/models/news"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrackView records a view event for an article
+func (h *Handler) TrackView(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	err := h.deps.ScoringService.TrackEngagement(c.Request.Context(), articleID, "view", 1)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to track view")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, map[string]interface{}{
+		"message": "View tracked successfully",
+	})
+}
+
+// RecordView counts a page view and returns the article's total view count
+func (h *Handler) RecordView(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	viewCount, err := h.deps.ScoringService.RecordView(c.Request.Context(), articleID)
+	if err != nil {
+		if errors.Is(err, newsModels.ErrNewsNotFound) {
+			h.deps.ResponseWriter.NotFound(c, "News article not found")
+			return
+		}
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to record view")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, map[string]interface{}{
+		"article_id": articleID,
+		"view_count": viewCount,
+	})
+}
+
+// GetEngagementMetrics returns engagement metrics for an article
+func (h *Handler) GetEngagementMetrics(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	metrics, err := h.deps.ScoringService.GetEngagementMetrics(c.Request.Context(), articleID)
+	if err != nil {
+		if errors.Is(err, newsModels.ErrNewsNotFound) {
+			h.deps.ResponseWriter.NotFound(c, "News article not found")
+			return
+		}
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to get engagement metrics")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, metrics)
+}
+
+// TrackClick records a click event for an article
+func (h *Handler) TrackClick(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	err := h.deps.ScoringService.TrackEngagement(c.Request.Context(), articleID, "click", 1)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to track click")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, map[string]interface{}{
+		"message": "Click tracked successfully",
+	})
+}
+
+// TrackShare records a share event for an article
+func (h *Handler) TrackShare(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	err := h.deps.ScoringService.TrackEngagement(c.Request.Context(), articleID, "share", 1)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to track share")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, map[string]interface{}{
+		"message": "Share tracked successfully",
+	})
+}
+
+// TrackReadTime records reading time for an article
+func (h *Handler) TrackReadTime(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	var request struct {
+		ReadTime int64 `json:"read_time"` // in seconds
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if request.ReadTime <= 0 {
+		h.deps.ResponseWriter.BadRequest(c, "Read time must be positive")
+		return
+	}
+
+	err := h.deps.ScoringService.TrackEngagement(c.Request.Context(), articleID, "read_time", request.ReadTime)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Int64("read_time", request.ReadTime).
+			Msg("Failed to track read time")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, map[string]interface{}{
+		"message": "Read time tracked successfully",
+	})
+}
+
+// GetSocialMetrics returns the social share counts and sentiment of an
+// article, refreshing them when the stored ones are stale
+func (h *Handler) GetSocialMetrics(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	metrics, err := h.deps.ScoringService.GetSocialMetrics(c.Request.Context(), articleID)
+	if err != nil {
+		if errors.Is(err, newsModels.ErrNewsNotFound) {
+			h.deps.ResponseWriter.NotFound(c, "News article not found")
+			return
+		}
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to get social metrics")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, metrics)
+}
+
+// GetContentAnalysis returns the sentiment, importance, readability,
+// keywords, entities, topic and language extracted from an article,
+// analyzing it first when that has not happened yet
+func (h *Handler) GetContentAnalysis(c *gin.Context) {
+	articleID := c.Param("id")
+	if articleID == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
+		return
+	}
+
+	analysis, err := h.deps.ScoringService.GetContentAnalysis(c.Request.Context(), articleID)
+	if err != nil {
+		if errors.Is(err, newsModels.ErrNewsNotFound) {
+			h.deps.ResponseWriter.NotFound(c, "News article not found")
+			return
+		}
+		h.logger.Warn().
+			Err(err).
+			Str("article_id", articleID).
+			Msg("Failed to get content analysis")
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, analysis)
+}
@@ -1,10 +1,13 @@
 package news
 
 import (
+	"errors"
+	"net/http"
 	"strconv"
 	"time"
 
 	"news-aggregator/internal/handlers/core"
+	"news-aggregator/internal/models"
 	"news-aggregator/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -42,12 +45,6 @@ func (h *EnhancedHandler) RegisterRoutes(router gin.IRouter) {
 		news.GET("/top-stories", h.GetEnhancedTopStories)
 		news.GET("/top-stories/refresh", h.RefreshTopStories)
 
-		// Engagement tracking endpoints
-		news.POST("/:id/track/view", h.TrackView)
-		news.POST("/:id/track/click", h.TrackClick)
-		news.POST("/:id/track/share", h.TrackShare)
-		news.POST("/:id/track/read-time", h.TrackReadTime)
-
 		// Scoring information endpoints
 		news.GET("/:id/score", h.GetArticleScore)
 		news.GET("/scores/top", h.GetTopScoredArticles)
@@ -60,13 +57,8 @@ func (h *EnhancedHandler) RegisterRoutes(router gin.IRouter) {
 
 // GetEnhancedTopStories returns top stories using the enhanced algorithm
 func (h *EnhancedHandler) GetEnhancedTopStories(c *gin.Context) {
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	if err != nil || limit < 1 {
-		limit = 10
-	}
-	if limit > 50 {
-		limit = 50
-	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimitFor(core.LimitTopStories, limit)
 
 	h.logger.Info().
 		Int("limit", limit).
@@ -106,123 +98,20 @@ func (h *EnhancedHandler) RefreshTopStories(c *gin.Context) {
 		Str("request_id", h.deps.ContextManager.GetRequestID(c)).
 		Msg("Top stories refresh requested")
 
-	// Trigger score refresh in background
-	go func() {
-		if err := h.scoringService.RefreshScores(c.Request.Context()); err != nil {
-			h.logger.Error().Err(err).Msg("Failed to refresh scores")
-		}
-	}()
-
-	h.deps.ResponseWriter.Success(c, map[string]interface{}{
-		"message": "Score refresh initiated",
-		"status":  "processing",
-	})
-}
-
-// TrackView records a view event for an article
-func (h *EnhancedHandler) TrackView(c *gin.Context) {
-	articleID := c.Param("id")
-	if articleID == "" {
-		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
-		return
-	}
-
-	err := h.scoringService.TrackEngagement(c.Request.Context(), articleID, "view", 1)
-	if err != nil {
-		h.logger.Warn().
-			Err(err).
-			Str("article_id", articleID).
-			Msg("Failed to track view")
-		h.deps.ResponseWriter.InternalError(c, err)
-		return
-	}
-
-	h.deps.ResponseWriter.Success(c, map[string]interface{}{
-		"message": "View tracked successfully",
-	})
-}
-
-// TrackClick records a click event for an article
-func (h *EnhancedHandler) TrackClick(c *gin.Context) {
-	articleID := c.Param("id")
-	if articleID == "" {
-		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
-		return
-	}
-
-	err := h.scoringService.TrackEngagement(c.Request.Context(), articleID, "click", 1)
+	refresh, err := h.scoringService.StartScoreRefresh()
 	if err != nil {
-		h.logger.Warn().
-			Err(err).
-			Str("article_id", articleID).
-			Msg("Failed to track click")
-		h.deps.ResponseWriter.InternalError(c, err)
-		return
-	}
-
-	h.deps.ResponseWriter.Success(c, map[string]interface{}{
-		"message": "Click tracked successfully",
-	})
-}
-
-// TrackShare records a share event for an article
-func (h *EnhancedHandler) TrackShare(c *gin.Context) {
-	articleID := c.Param("id")
-	if articleID == "" {
-		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
-		return
-	}
-
-	err := h.scoringService.TrackEngagement(c.Request.Context(), articleID, "share", 1)
-	if err != nil {
-		h.logger.Warn().
-			Err(err).
-			Str("article_id", articleID).
-			Msg("Failed to track share")
-		h.deps.ResponseWriter.InternalError(c, err)
-		return
-	}
-
-	h.deps.ResponseWriter.Success(c, map[string]interface{}{
-		"message": "Share tracked successfully",
-	})
-}
-
-// TrackReadTime records reading time for an article
-func (h *EnhancedHandler) TrackReadTime(c *gin.Context) {
-	articleID := c.Param("id")
-	if articleID == "" {
-		h.deps.ResponseWriter.BadRequest(c, "Article ID is required")
-		return
-	}
-
-	var request struct {
-		ReadTime int64 `json:"read_time"` // in seconds
-	}
-
-	if err := c.ShouldBindJSON(&request); err != nil {
-		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
-		return
-	}
-
-	if request.ReadTime <= 0 {
-		h.deps.ResponseWriter.BadRequest(c, "Read time must be positive")
-		return
-	}
-
-	err := h.scoringService.TrackEngagement(c.Request.Context(), articleID, "read_time", request.ReadTime)
-	if err != nil {
-		h.logger.Warn().
-			Err(err).
-			Str("article_id", articleID).
-			Int64("read_time", request.ReadTime).
-			Msg("Failed to track read time")
+		if errors.Is(err, models.ErrScoreRefreshInProgress) {
+			h.deps.ResponseWriter.ErrorWithCode(c, http.StatusConflict, "A score refresh is already running")
+			return
+		}
 		h.deps.ResponseWriter.InternalError(c, err)
 		return
 	}
 
 	h.deps.ResponseWriter.Success(c, map[string]interface{}{
-		"message": "Read time tracked successfully",
+		"message":    "Score refresh initiated",
+		"status":     "processing",
+		"refresh_id": refresh.ID,
 	})
 }
 
@@ -244,13 +133,8 @@ func (h *EnhancedHandler) GetArticleScore(c *gin.Context) {
 
 // GetTopScoredArticles returns articles with the highest scores
 func (h *EnhancedHandler) GetTopScoredArticles(c *gin.Context) {
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	if err != nil || limit < 1 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimitFor(core.LimitTopScored, limit)
 
 	minScore := 0.5
 	if scoreStr := c.Query("min_score"); scoreStr != "" {
@@ -0,0 +1,149 @@
+package news
+
+import (
+	"encoding/json"
+	"html"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"news-aggregator/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// jsonFeedContentType is the media type registered for JSON Feed
+	jsonFeedContentType = "application/feed+json"
+
+	// jsonFeedVersion identifies the JSON Feed spec the documents follow
+	jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+)
+
+// jsonFeed is a JSON Feed 1.1 document (https://jsonfeed.org/version/1.1)
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+// jsonFeedItem is a single entry of a JSON Feed
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+// jsonFeedAuthor names the author of an item
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// GetNewsJSONFeed serves the latest articles of the last 7 days as a JSON
+// Feed. Like GetNews it accepts category, source and limit parameters.
+func (h *Handler) GetNewsJSONFeed(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
+
+	filter := models.NewsFilter{
+		Page:     1,
+		Limit:    limit,
+		DateFrom: time.Now().AddDate(0, 0, -7), // Last 7 days
+	}
+	filter.Category, filter.Categories = splitFilterValues(c.Query("category"))
+	filter.Source, filter.Sources = splitFilterValues(c.Query("source"))
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Int("limit", limit).
+			Str("category", filter.Category).
+			Strs("categories", filter.Categories).
+			Str("source", filter.Source).
+			Strs("sources", filter.Sources).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("JSON Feed request")
+	}
+
+	news, _, err := h.deps.NewsService.GetNews(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get news for JSON Feed")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	title := rssFeedTitle
+	if filter.Category != "" {
+		title += " - " + filter.Category
+	}
+
+	items := make([]jsonFeedItem, 0, len(news))
+	for i := range news {
+		items = append(items, newJSONFeedItem(&news[i]))
+	}
+
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       title,
+		HomePageURL: requestBaseURL(c),
+		FeedURL:     requestBaseURL(c) + c.Request.URL.RequestURI(),
+		Description: "Latest articles collected by " + rssFeedTitle,
+		Items:       items,
+	}
+
+	doc, err := json.Marshal(feed)
+	if err != nil {
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, jsonFeedContentType+"; charset=utf-8", doc)
+}
+
+// newJSONFeedItem converts an article to a JSON Feed item. Items need some
+// content, so articles without any carry their escaped summary or title
+// instead. The category leads the tags.
+func newJSONFeedItem(news *models.News) jsonFeedItem {
+	item := jsonFeedItem{
+		ID:          news.ID,
+		URL:         news.URL,
+		Title:       news.Title,
+		ContentHTML: news.Content,
+		Summary:     news.Summary,
+		Image:       news.ImageURL,
+		Tags:        news.Tags,
+	}
+	if item.ContentHTML == "" {
+		item.ContentHTML = html.EscapeString(news.Summary)
+	}
+	if item.ContentHTML == "" {
+		item.ContentHTML = html.EscapeString(news.Title)
+	}
+	if news.Category != "" && !slices.Contains(item.Tags, news.Category) {
+		item.Tags = append([]string{news.Category}, item.Tags...)
+	}
+	if news.Author != "" {
+		item.Authors = []jsonFeedAuthor{{Name: news.Author}}
+	}
+	if !news.PublishedAt.IsZero() {
+		item.DatePublished = news.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	if !news.UpdatedAt.IsZero() {
+		item.DateModified = news.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return item
+}
@@ -2,7 +2,11 @@
 package news
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"news-aggregator/internal/handlers/core"
@@ -30,20 +34,44 @@ func NewHandler(deps *core.HandlerDependencies, config core.HandlerConfig) core.
 
 // RegisterRoutes registers news routes.
 func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	// Each route only requires the service it uses, so partial deployments
+	// (e.g. search only) keep serving what they can
+	requireNews := core.RequireServices(h.deps, core.ServiceNews)
+	requireSearch := core.RequireServices(h.deps, core.ServiceSearch)
+	requireTrending := core.RequireServices(h.deps, core.ServiceTrending)
+	requireScoring := core.RequireServices(h.deps, core.ServiceScoring)
+
 	news := router.Group(h.GetBasePath())
 	{
-		news.GET("", h.GetNews)
-		news.GET("/:id", h.GetNewsByID)
-		news.GET("/categories", h.GetCategories)
-		news.GET("/sources", h.GetSources)
-		news.GET("/trending", h.GetTrendingTopics)
-		news.POST("/search", h.SearchNews)
-		news.GET("/search", h.SearchNews) // Support both GET and POST for search
-		news.GET("/feed/:category", h.GetNewsByCategory)
-		news.GET("/feed/source/:source", h.GetNewsBySource)
-		news.GET("/latest", h.GetLatestNews)
-		news.GET("/popular", h.GetPopularNews)
-		news.GET("/top-stories", h.GetTopStories)
+		news.GET("", requireNews, h.GetNews)
+		news.GET("/feed.rss", requireNews, h.GetNewsRSS)
+		news.GET("/feed.json", requireNews, h.GetNewsJSONFeed)
+		news.GET("/:id", requireNews, h.GetNewsByID)
+		news.GET("/:id/similar", requireSearch, h.GetSimilarNews)
+		news.POST("/:id/view", requireScoring, h.RecordView)
+		news.GET("/:id/metrics", requireScoring, h.GetEngagementMetrics)
+		news.GET("/:id/social", requireScoring, h.GetSocialMetrics)
+		news.GET("/:id/analysis", requireScoring, h.GetContentAnalysis)
+		news.POST("/:id/track/view", requireScoring, h.TrackView)
+		news.POST("/:id/track/click", requireScoring, h.TrackClick)
+		news.POST("/:id/track/share", requireScoring, h.TrackShare)
+		news.POST("/:id/track/read-time", requireScoring, h.TrackReadTime)
+		news.POST("/batch", requireNews, h.GetNewsByIDs)
+		news.GET("/categories", requireNews, h.GetCategories)
+		news.GET("/tags", requireNews, h.GetTags)
+		news.GET("/sources", requireNews, h.GetSources)
+		news.GET("/trending", requireTrending, h.GetTrendingTopics)
+		news.POST("/exists", requireNews, h.CheckNewsExists)
+		news.POST("/search", requireSearch, h.SearchNews)
+		news.GET("/search", requireSearch, h.SearchNews) // Support both GET and POST for search
+		news.POST("/search/advanced", requireSearch, h.SearchNewsAdvanced)
+		news.GET("/feed/:category", requireNews, h.GetNewsByCategory)
+		news.GET("/feed/:category/feed.rss", requireNews, h.GetCategoryRSS)
+		news.GET("/feed/source/:source", requireNews, h.GetNewsBySource)
+		news.GET("/feed/tag/:tag", requireNews, h.GetNewsByTag)
+		news.GET("/latest", requireNews, h.GetLatestNews)
+		news.GET("/popular", requireNews, h.GetPopularNews)
+		news.GET("/top-stories", requireNews, h.GetTopStories)
 	}
 }
 
@@ -57,6 +85,14 @@ func (h *Handler) GetName() string {
 	return "news_handler"
 }
 
+// GetRouteMetadata marks single-article reads as longer-lived in caches than
+// the lists and searches around them.
+func (h *Handler) GetRouteMetadata() []core.RouteMetadata {
+	return []core.RouteMetadata{
+		{Method: http.MethodGet, Path: "/:id", CacheClass: core.CacheClassArticle},
+	}
+}
+
 // GetNews retrieves paginated news articles.
 func (h *Handler) GetNews(c *gin.Context) {
 	// Parse and validate query parameters
@@ -65,30 +101,40 @@ func (h *Handler) GetNews(c *gin.Context) {
 		page = 1
 	}
 
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.config.DefaultPageSize)))
-	if err != nil || limit < 1 {
-		limit = h.config.DefaultPageSize
-	}
-	if limit > h.config.MaxPageSize {
-		limit = h.config.MaxPageSize
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
+
+	// Build filter; category and source take comma-separated lists
+	filter := models.NewsFilter{
+		Page:     page,
+		Limit:    limit,
+		DateFrom: h.parseDateQuery(c.Query("date_from")),
+		DateTo:   h.parseDateQuery(c.Query("date_to")),
 	}
+	filter.Category, filter.Categories = splitFilterValues(c.Query("category"))
+	filter.Source, filter.Sources = splitFilterValues(c.Query("source"))
 
-	// Validate pagination if validation is enabled
+	// With validation enabled, malformed filters are rejected with field
+	// errors instead of being ignored; pagination is still clamped
 	if h.config.EnableValidation {
-		page, limit, err = h.deps.Validator.ValidatePagination(page, limit)
-		if err != nil {
-			h.deps.ResponseWriter.BadRequest(c, "Invalid pagination parameters")
+		fields := make(map[string]string)
+		for _, param := range []string{"date_from", "date_to"} {
+			if value := c.Query(param); value != "" {
+				if _, err := parseDate(value); err != nil {
+					fields[param] = err.Error()
+				}
+			}
+		}
+		if len(fields) > 0 {
+			h.deps.ResponseWriter.ValidationError(c, fields)
 			return
 		}
-	}
 
-	// Build filter
-	filter := models.NewsFilter{
-		Page:     page,
-		Limit:    limit,
-		Category: c.Query("category"),
-		Source:   c.Query("source"),
-		DateFrom: h.parseDateQuery(c.Query("date_from")),
+		if err := h.deps.Validator.ValidateNewsFilter(&filter); err != nil {
+			h.deps.ResponseWriter.Error(c, err)
+			return
+		}
+		page, limit = filter.Page, filter.Limit
 	}
 
 	// Apply default date filter (last 7 days)
@@ -102,7 +148,9 @@ func (h *Handler) GetNews(c *gin.Context) {
 			Int("page", page).
 			Int("limit", limit).
 			Str("category", filter.Category).
+			Strs("categories", filter.Categories).
 			Str("source", filter.Source).
+			Strs("sources", filter.Sources).
 			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
 			Msg("News request")
 	}
@@ -119,10 +167,16 @@ func (h *Handler) GetNews(c *gin.Context) {
 		return
 	}
 
+	if prefersRSS(c) {
+		h.renderRSS(c, filter.Category, news)
+		return
+	}
+
 	// Prepare pagination info
 	pagination := core.NewPaginationInfo(page, limit, int64(total))
 
-	h.deps.ResponseWriter.SuccessWithPagination(c, news, pagination)
+	h.setFreshness(news)
+	h.deps.ResponseWriter.SuccessWithPagination(c, selectFields(c, news), pagination)
 
 	if h.config.EnableLogging {
 		h.logger.Info().
@@ -130,7 +184,9 @@ func (h *Handler) GetNews(c *gin.Context) {
 			Int("limit", limit).
 			Int("total", total).
 			Str("category", filter.Category).
+			Strs("categories", filter.Categories).
 			Str("source", filter.Source).
+			Strs("sources", filter.Sources).
 			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
 			Msg("News retrieved successfully")
 	}
@@ -164,6 +220,18 @@ func (h *Handler) GetNewsByID(c *gin.Context) {
 		return
 	}
 
+	news.SetFreshness(h.config.FreshnessLevels, time.Now())
+
+	etag := news.ETag()
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		// Flush the headers through c.Writer so the route's Cache-Control
+		// policy is applied; gin writes bodiless responses without it
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
 	h.deps.ResponseWriter.Success(c, news)
 
 	if h.config.EnableLogging {
@@ -174,6 +242,108 @@ func (h *Handler) GetNewsByID(c *gin.Context) {
 	}
 }
 
+// GetSimilarNews retrieves the indexed articles most similar in title and
+// content to the given one. Articles that are not indexed yet have none.
+func (h *Handler) GetSimilarNews(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.deps.ResponseWriter.BadRequest(c, "News ID is required")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimitFor(core.LimitSimilar, limit)
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("id", id).
+			Int("limit", limit).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Similar news request")
+	}
+
+	news, err := h.deps.SearchService.MoreLikeThis(c.Request.Context(), id, limit)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("id", id).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get similar news")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.setFreshness(news)
+	h.deps.ResponseWriter.Success(c, news)
+}
+
+// maxBatchSize caps the number of IDs accepted by GetNewsByIDs.
+const maxBatchSize = 100
+
+// GetNewsByIDs retrieves several news articles in the requested order. IDs
+// that match no article are left out of the result.
+func (h *Handler) GetNewsByIDs(c *gin.Context) {
+	var req struct {
+		IDs []string `json:"ids" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		h.deps.ResponseWriter.BadRequest(c, "At least one ID is required")
+		return
+	}
+
+	if len(req.IDs) > maxBatchSize {
+		h.deps.ResponseWriter.BadRequest(c, fmt.Sprintf("At most %d IDs can be fetched per request", maxBatchSize))
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Int("count", len(req.IDs)).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("News batch request")
+	}
+
+	news, err := h.deps.NewsService.GetNewsByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get news by IDs")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.setFreshness(news)
+	h.deps.ResponseWriter.Success(c, news)
+}
+
+// etagMatches reports whether an If-None-Match header matches the given
+// entity tag, using the weak comparison that RFC 9110 requires for it.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
 // GetCategories retrieves available news categories.
 func (h *Handler) GetCategories(c *gin.Context) {
 	if h.config.EnableLogging {
@@ -197,10 +367,38 @@ func (h *Handler) GetCategories(c *gin.Context) {
 	h.deps.ResponseWriter.Success(c, categories)
 }
 
+// GetTags retrieves the most used tags of recent articles with their counts.
+func (h *Handler) GetTags(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimitFor(core.LimitTags, limit)
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Int("limit", limit).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Tags request")
+	}
+
+	tags, err := h.deps.NewsService.GetTags(c.Request.Context(), limit)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get tags")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.deps.ResponseWriter.Success(c, tags)
+}
+
 // SearchNews searches for news articles.
 func (h *Handler) SearchNews(c *gin.Context) {
 	var query string
 	var page, limit int
+	var dateFrom, dateTo time.Time
+	var exact bool
 	var err error
 
 	// Handle both GET and POST requests
@@ -209,6 +407,9 @@ func (h *Handler) SearchNews(c *gin.Context) {
 			Query    string `json:"query" binding:"required"`
 			Category string `json:"category"`
 			Source   string `json:"source"`
+			DateFrom string `json:"date_from"`
+			DateTo   string `json:"date_to"`
+			Exact    bool   `json:"exact"`
 			Page     int    `json:"page"`
 			Limit    int    `json:"limit"`
 		}
@@ -219,6 +420,9 @@ func (h *Handler) SearchNews(c *gin.Context) {
 		}
 
 		query = searchReq.Query
+		dateFrom = h.parseDateQuery(searchReq.DateFrom)
+		dateTo = h.parseDateQuery(searchReq.DateTo)
+		exact = searchReq.Exact
 		page = searchReq.Page
 		limit = searchReq.Limit
 	} else {
@@ -229,20 +433,18 @@ func (h *Handler) SearchNews(c *gin.Context) {
 			return
 		}
 
+		dateFrom = h.parseDateQuery(c.Query("date_from"))
+		dateTo = h.parseDateQuery(c.Query("date_to"))
+		exact, _ = strconv.ParseBool(c.DefaultQuery("exact", "false"))
 		page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
-		limit, _ = strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.config.DefaultPageSize)))
+		limit, _ = strconv.Atoi(c.Query("limit"))
 	}
 
 	// Set defaults and validate
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 {
-		limit = h.config.DefaultPageSize
-	}
-	if limit > h.config.MaxPageSize {
-		limit = h.config.MaxPageSize
-	}
+	limit = h.config.ClampLimit(limit)
 
 	// Validate pagination if validation is enabled
 	if h.config.EnableValidation {
@@ -262,10 +464,13 @@ func (h *Handler) SearchNews(c *gin.Context) {
 			Msg("Search request")
 	}
 
-	// Perform search
-	results, total, err := h.deps.SearchService.Search(
+	// Perform search; an explicit date range overrides the search window
+	results, total, err := h.deps.SearchService.SearchInRange(
 		c.Request.Context(),
 		query,
+		dateFrom,
+		dateTo,
+		exact,
 		page,
 		limit,
 	)
@@ -283,6 +488,7 @@ func (h *Handler) SearchNews(c *gin.Context) {
 	// Prepare pagination info
 	pagination := core.NewPaginationInfo(page, limit, int64(total))
 
+	h.setFreshness(results)
 	h.deps.ResponseWriter.SuccessWithPagination(c, results, pagination)
 
 	if h.config.EnableLogging {
@@ -295,14 +501,135 @@ func (h *Handler) SearchNews(c *gin.Context) {
 	}
 }
 
+// SearchNewsAdvanced searches with category, source, tag, author and date
+// filters and responds with the results, their highlights and the facet
+// counts of the whole match set. With personalize set, articles in a
+// signed-in user's preferred categories and sources rank higher; this
+// changes the ordering, not the result set.
+func (h *Handler) SearchNewsAdvanced(c *gin.Context) {
+	var query models.SearchQuery
+	if err := c.ShouldBindJSON(&query); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	query.Limit = h.config.ClampLimit(query.Limit)
+	query.SetDefaults()
+	if err := query.Validate(); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, err.Error())
+		return
+	}
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("query", query.Query).
+			Int("page", query.Page).
+			Int("limit", query.Limit).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Advanced search request")
+	}
+
+	result, err := h.deps.SearchService.PersonalizedSearch(c.Request.Context(), query, h.searchPreferences(c, query))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("query", query.Query).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Advanced search failed")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.setFreshness(result.News)
+	h.deps.ResponseWriter.Success(c, result)
+}
+
+// searchPreferences returns the preferences of the signed-in user when the
+// query asks for personalization, or nil for anonymous users and when the
+// preferences can't be loaded, so the search falls back to the neutral
+// ordering.
+func (h *Handler) searchPreferences(c *gin.Context, query models.SearchQuery) *models.Preferences {
+	if !query.Personalize || h.deps.UserService == nil {
+		return nil
+	}
+
+	userID, err := h.deps.ContextManager.GetUserID(c)
+	if err != nil {
+		return nil
+	}
+
+	user, err := h.deps.UserService.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", userID).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to load preferences, searching without personalization")
+		return nil
+	}
+
+	return &user.Preferences
+}
+
+// maxExistsBatchSize caps the number of URLs accepted by CheckNewsExists.
+const maxExistsBatchSize = 100
+
+// CheckNewsExists reports which of the given article URLs are already stored.
+func (h *Handler) CheckNewsExists(c *gin.Context) {
+	var req struct {
+		URLs []string `json:"urls" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.deps.ResponseWriter.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	if len(req.URLs) == 0 {
+		h.deps.ResponseWriter.BadRequest(c, "At least one URL is required")
+		return
+	}
+
+	if len(req.URLs) > maxExistsBatchSize {
+		h.deps.ResponseWriter.BadRequest(c, fmt.Sprintf("At most %d URLs can be checked per request", maxExistsBatchSize))
+		return
+	}
+
+	existing, err := h.deps.NewsService.GetExistingURLs(c.Request.Context(), req.URLs)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to check existing URLs")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	found := make(map[string]bool, len(existing))
+	for _, url := range existing {
+		found[url] = true
+	}
+
+	missing := []string{}
+	for _, url := range req.URLs {
+		if !found[url] {
+			missing = append(missing, url)
+		}
+	}
+
+	h.deps.ResponseWriter.Success(c, gin.H{
+		"existing": existing,
+		"missing":  missing,
+	})
+}
+
 // GetTrendingTopics retrieves trending topics.
 func (h *Handler) GetTrendingTopics(c *gin.Context) {
 	// Parse limit parameter
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 || limit > 50 {
-		limit = 10
-	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimitFor(core.LimitTrending, limit)
 
 	if h.config.EnableLogging {
 		h.logger.Info().
@@ -311,8 +638,8 @@ func (h *Handler) GetTrendingTopics(c *gin.Context) {
 			Msg("Trending topics request")
 	}
 
-	// Get trending topics
-	topics, err := h.deps.TrendingService.GetTrendingTopics(c.Request.Context(), limit)
+	// Get trending topics from the periodically refreshed cache
+	topics, updatedAt, err := h.deps.TrendingService.GetCachedTrendingTopics(c.Request.Context(), limit)
 	if err != nil {
 		h.logger.Error().
 			Err(err).
@@ -326,9 +653,10 @@ func (h *Handler) GetTrendingTopics(c *gin.Context) {
 	h.deps.ResponseWriter.Success(c, gin.H{
 		"data": topics,
 		"meta": gin.H{
-			"count":      len(topics),
-			"limit":      limit,
-			"updated_at": time.Now(),
+			"count":       len(topics),
+			"limit":       limit,
+			"updated_at":  updatedAt,
+			"age_seconds": int(time.Since(updatedAt).Seconds()),
 		},
 	})
 }
@@ -343,7 +671,8 @@ func (h *Handler) GetNewsByCategory(c *gin.Context) {
 
 	// Parse pagination
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.config.DefaultPageSize)))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
 
 	if h.config.EnableValidation {
 		page, limit, _ = h.deps.Validator.ValidatePagination(page, limit)
@@ -364,8 +693,14 @@ func (h *Handler) GetNewsByCategory(c *gin.Context) {
 		return
 	}
 
+	if prefersRSS(c) {
+		h.renderRSS(c, category, news)
+		return
+	}
+
 	pagination := core.NewPaginationInfo(page, limit, int64(total))
-	h.deps.ResponseWriter.SuccessWithPagination(c, news, pagination)
+	h.setFreshness(news)
+	h.deps.ResponseWriter.SuccessWithPagination(c, selectFields(c, news), pagination)
 }
 
 // GetNewsBySource retrieves news by source.
@@ -378,7 +713,8 @@ func (h *Handler) GetNewsBySource(c *gin.Context) {
 
 	// Parse pagination
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.config.DefaultPageSize)))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
 
 	if h.config.EnableValidation {
 		page, limit, _ = h.deps.Validator.ValidatePagination(page, limit)
@@ -400,27 +736,36 @@ func (h *Handler) GetNewsBySource(c *gin.Context) {
 	}
 
 	pagination := core.NewPaginationInfo(page, limit, int64(total))
-	h.deps.ResponseWriter.SuccessWithPagination(c, news, pagination)
+	h.setFreshness(news)
+	h.deps.ResponseWriter.SuccessWithPagination(c, selectFields(c, news), pagination)
 }
 
-// GetLatestNews retrieves the latest news articles.
-func (h *Handler) GetLatestNews(c *gin.Context) {
+// GetNewsByTag retrieves news carrying a tag.
+func (h *Handler) GetNewsByTag(c *gin.Context) {
+	tag := strings.TrimSpace(c.Param("tag"))
+	if tag == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Tag is required")
+		return
+	}
+
 	// Parse pagination
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.config.DefaultPageSize)))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
 
 	if h.config.EnableValidation {
 		page, limit, _ = h.deps.Validator.ValidatePagination(page, limit)
 	}
 
-	// Build filter for latest news
+	// Build filter
 	filter := models.NewsFilter{
 		Page:     page,
 		Limit:    limit,
-		DateFrom: time.Now().AddDate(0, 0, -1), // Last 24 hours
+		Tags:     []string{tag},
+		DateFrom: time.Now().AddDate(0, 0, -7), // Last 7 days
 	}
 
-	// Fetch latest news
+	// Fetch news
 	news, total, err := h.deps.NewsService.GetNews(c.Request.Context(), filter)
 	if err != nil {
 		h.deps.ResponseWriter.InternalError(c, err)
@@ -428,26 +773,29 @@ func (h *Handler) GetLatestNews(c *gin.Context) {
 	}
 
 	pagination := core.NewPaginationInfo(page, limit, int64(total))
-	h.deps.ResponseWriter.SuccessWithPagination(c, news, pagination)
+	h.setFreshness(news)
+	h.deps.ResponseWriter.SuccessWithPagination(c, selectFields(c, news), pagination)
 }
 
-// GetPopularNews retrieves popular news articles.
-func (h *Handler) GetPopularNews(c *gin.Context) {
+// GetLatestNews retrieves the latest news articles.
+func (h *Handler) GetLatestNews(c *gin.Context) {
 	// Parse pagination
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.config.DefaultPageSize)))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
 
 	if h.config.EnableValidation {
 		page, limit, _ = h.deps.Validator.ValidatePagination(page, limit)
 	}
 
-	// Build filter for popular news
+	// Build filter for latest news
 	filter := models.NewsFilter{
 		Page:     page,
 		Limit:    limit,
-		DateFrom: time.Now().AddDate(0, 0, -3), // Last 3 days
+		DateFrom: time.Now().AddDate(0, 0, -1), // Last 24 hours
 	}
 
+	// Fetch latest news
 	news, total, err := h.deps.NewsService.GetNews(c.Request.Context(), filter)
 	if err != nil {
 		h.deps.ResponseWriter.InternalError(c, err)
@@ -455,20 +803,38 @@ func (h *Handler) GetPopularNews(c *gin.Context) {
 	}
 
 	pagination := core.NewPaginationInfo(page, limit, int64(total))
-	h.deps.ResponseWriter.SuccessWithPagination(c, news, pagination)
+	h.setFreshness(news)
+	h.deps.ResponseWriter.SuccessWithPagination(c, selectFields(c, news), pagination)
 }
 
-// GetTopStories retrieves top stories (simplified version)
-func (h *Handler) GetTopStories(c *gin.Context) {
+// GetPopularNews retrieves the most engaged-with recent articles.
+func (h *Handler) GetPopularNews(c *gin.Context) {
 	// Parse pagination
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	if err != nil || limit < 1 {
-		limit = 10
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
+
+	if h.config.EnableValidation {
+		page, limit, _ = h.deps.Validator.ValidatePagination(page, limit)
 	}
-	if limit > 50 {
-		limit = 50
+
+	news, total, err := h.deps.NewsService.GetPopularNews(c.Request.Context(), page, limit)
+	if err != nil {
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
 	}
 
+	pagination := core.NewPaginationInfo(page, limit, int64(total))
+	h.setFreshness(news)
+	h.deps.ResponseWriter.SuccessWithPagination(c, selectFields(c, news), pagination)
+}
+
+// GetTopStories retrieves top stories (simplified version)
+func (h *Handler) GetTopStories(c *gin.Context) {
+	// Parse pagination
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimitFor(core.LimitTopStories, limit)
+
 	// For now, use the same logic as GetNews but with a smaller limit
 	// This is a temporary implementation until the full scoring service is integrated
 	filter := models.NewsFilter{
@@ -484,7 +850,8 @@ func (h *Handler) GetTopStories(c *gin.Context) {
 	}
 
 	// Return with enhanced metadata
-	h.deps.ResponseWriter.Success(c, map[string]interface{}{
+	h.setFreshness(news)
+	response := map[string]interface{}{
 		"data": news,
 		"meta": map[string]interface{}{
 			"count":     len(news),
@@ -492,19 +859,66 @@ func (h *Handler) GetTopStories(c *gin.Context) {
 			"algorithm": "time_based_temporary",
 			"timestamp": time.Now(),
 		},
-	})
+	}
+	if scores := h.topStoryScores(c, news); scores != nil {
+		response["scores"] = scores
+	}
+	h.deps.ResponseWriter.Success(c, response)
+}
+
+// topStoryScores returns the stored component scores of the articles keyed
+// by article ID, looked up in one batch. It returns nil when scoring is not
+// available; articles that were never scored are left out.
+func (h *Handler) topStoryScores(c *gin.Context, news []models.News) map[string]models.ArticleScore {
+	if h.deps.ScoringService == nil || len(news) == 0 {
+		return nil
+	}
+
+	scored, err := h.deps.ScoringService.AttachScores(c.Request.Context(), news)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get top story scores")
+		return nil
+	}
+
+	scores := make(map[string]models.ArticleScore, len(scored))
+	for _, article := range scored {
+		if article.Scores.ArticleID != "" {
+			scores[article.Article.ID] = article.Scores
+		}
+	}
+	return scores
 }
 
-// GetSources retrieves available news sources.
+// GetSources retrieves the configured news sources with their fetch
+// status (last_success, last_error, consecutive_failures). Credential-bearing
+// headers are removed.
 func (h *Handler) GetSources(c *gin.Context) {
-	// This would need to be implemented in the service
-	sources := []gin.H{
-		{"id": "bbc", "name": "BBC News", "category": "general"},
-		{"id": "cnn", "name": "CNN", "category": "general"},
-		{"id": "techcrunch", "name": "TechCrunch", "category": "technology"},
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Sources request")
 	}
 
-	h.deps.ResponseWriter.Success(c, sources)
+	sources, err := h.deps.NewsService.GetSources(c.Request.Context())
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get sources")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	public := make([]models.Source, 0, len(sources))
+	for _, source := range sources {
+		public = append(public, source.WithoutSecrets())
+	}
+
+	h.deps.ResponseWriter.Success(c, public)
 }
 
 // parseDateQuery parses date query parameter.
@@ -513,18 +927,99 @@ func (h *Handler) parseDateQuery(dateStr string) time.Time {
 		return time.Time{}
 	}
 
-	// Try different date formats
-	formats := []string{
-		"2006-01-02",
-		"2006-01-02T15:04:05Z",
-		"2006-01-02 15:04:05",
+	t, _ := parseDate(dateStr)
+	return t
+}
+
+// splitFilterValues splits a comma-separated filter parameter. A single
+// value is returned as is, so it filters exactly like before lists were
+// accepted; several values are returned as a list instead.
+func splitFilterValues(param string) (string, []string) {
+	if !strings.Contains(param, ",") {
+		return param, nil
 	}
 
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return t
+	var values []string
+	for _, value := range strings.Split(param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return "", nil
+	case 1:
+		return values[0], nil
+	default:
+		return "", values
+	}
+}
+
+// dateFormats are the formats accepted for date query parameters
+var dateFormats = []string{
+	"2006-01-02",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02 15:04:05",
+}
+
+// parseDate parses value in one of dateFormats
+func parseDate(value string) (time.Time, error) {
+	for _, format := range dateFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date format (use YYYY-MM-DD or YYYY-MM-DDTHH:MM:SSZ)")
+}
+
+// setFreshness labels the articles with their freshness level
+func (h *Handler) setFreshness(news []models.News) {
+	now := time.Now()
+	for i := range news {
+		news[i].SetFreshness(h.config.FreshnessLevels, now)
+	}
+}
+
+// selectFields applies the fields query parameter (e.g.
+// fields=title,url,image_url,published_at) to a list of articles, keeping
+// only the named JSON fields. Unknown names are ignored; without the
+// parameter the articles are returned whole.
+func selectFields(c *gin.Context, news []models.News) interface{} {
+	param := c.Query("fields")
+	if param == "" {
+		return news
+	}
+
+	var fields []string
+	for _, field := range strings.Split(param, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return news
+	}
+
+	trimmed := make([]map[string]json.RawMessage, 0, len(news))
+	for _, item := range news {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return news
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return news
+		}
+
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				selected[field] = value
+			}
 		}
+		trimmed = append(trimmed, selected)
 	}
 
-	return time.Time{}
+	return trimmed
 }
@@ -0,0 +1,172 @@
+package news
+
+import (
+	"encoding/xml"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"news-aggregator/internal/datasources/sources/rss"
+	"news-aggregator/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// rssContentType is served for RSS feeds and negotiated via Accept
+	rssContentType = "application/rss+xml"
+
+	// rssFeedTitle titles the feeds; category feeds append the category
+	rssFeedTitle = "News Aggregator"
+
+	// defaultEnclosureType is used for images whose URL has no known extension
+	defaultEnclosureType = "image/jpeg"
+)
+
+// GetNewsRSS serves the latest articles of the last 7 days as RSS 2.0.
+func (h *Handler) GetNewsRSS(c *gin.Context) {
+	h.serveRSS(c, "")
+}
+
+// GetCategoryRSS serves the latest articles of a category as RSS 2.0.
+func (h *Handler) GetCategoryRSS(c *gin.Context) {
+	category := c.Param("category")
+	if category == "" {
+		h.deps.ResponseWriter.BadRequest(c, "Category is required")
+		return
+	}
+	h.serveRSS(c, category)
+}
+
+// serveRSS fetches the first page of recent articles, optionally of one
+// category, and writes them as an RSS feed. The limit parameter is
+// clamped like on the JSON endpoints.
+func (h *Handler) serveRSS(c *gin.Context, category string) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.config.ClampLimit(limit)
+
+	if h.config.EnableLogging {
+		h.logger.Info().
+			Str("category", category).
+			Int("limit", limit).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("RSS feed request")
+	}
+
+	filter := models.NewsFilter{
+		Page:     1,
+		Limit:    limit,
+		Category: category,
+		DateFrom: time.Now().AddDate(0, 0, -7), // Last 7 days
+	}
+
+	news, _, err := h.deps.NewsService.GetNews(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("request_id", h.deps.ContextManager.GetRequestID(c)).
+			Msg("Failed to get news for RSS feed")
+
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	h.renderRSS(c, category, news)
+}
+
+// prefersRSS reports whether the Accept header asks for RSS over JSON.
+// Requests without an Accept header, or accepting anything, get JSON. The
+// response is marked as varying by Accept so caches keep both formats.
+func prefersRSS(c *gin.Context) bool {
+	c.Writer.Header().Add("Vary", "Accept")
+	return c.NegotiateFormat(gin.MIMEJSON, rssContentType) == rssContentType
+}
+
+// renderRSS writes news as an RSS 2.0 document.
+func (h *Handler) renderRSS(c *gin.Context, category string, news []models.News) {
+	title := rssFeedTitle
+	if category != "" {
+		title += " - " + category
+	}
+
+	items := make([]rss.Item, 0, len(news))
+	for i := range news {
+		items = append(items, newRSSItem(&news[i]))
+	}
+
+	feed := rss.Feed{
+		Version:          "2.0",
+		ContentNamespace: rss.ContentNamespaceURI,
+		Channel: rss.Channel{
+			Title:         title,
+			Description:   "Latest articles collected by " + rssFeedTitle,
+			Link:          requestBaseURL(c) + c.Request.URL.Path,
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+			Generator:     rssFeedTitle,
+			Items:         items,
+		},
+	}
+
+	doc, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		h.deps.ResponseWriter.InternalError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, rssContentType+"; charset=utf-8", append([]byte(xml.Header), doc...))
+}
+
+// newRSSItem converts an article to an RSS item. The article URL is its
+// permalink guid; articles without one fall back to their ID, which is not
+// a permalink. Image enclosures have an unknown length, given as 0.
+func newRSSItem(news *models.News) rss.Item {
+	item := rss.Item{
+		Title:       news.Title,
+		Description: news.Summary,
+		Content:     news.Content,
+		Link:        news.URL,
+		GUID:        &rss.GUID{Value: news.URL, IsPermaLink: "true"},
+		DCCreator:   news.Author,
+	}
+	if news.URL == "" {
+		item.GUID = &rss.GUID{Value: news.ID, IsPermaLink: "false"}
+	}
+	if !news.PublishedAt.IsZero() {
+		item.PubDate = news.PublishedAt.UTC().Format(time.RFC1123Z)
+	}
+	if news.Category != "" {
+		item.Category = []rss.Category{{Value: news.Category}}
+	}
+	if news.ImageURL != "" {
+		item.Enclosure = &rss.Enclosure{URL: news.ImageURL, Type: imageMIMEType(news.ImageURL)}
+	}
+	return item
+}
+
+// imageMIMEType guesses the MIME type of an image from its URL's extension.
+func imageMIMEType(imageURL string) string {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return defaultEnclosureType
+	}
+	if mimeType := mime.TypeByExtension(path.Ext(u.Path)); mimeType != "" {
+		return mimeType
+	}
+	return defaultEnclosureType
+}
+
+// requestBaseURL returns the scheme and host the client used, honouring
+// X-Forwarded-Proto from proxies terminating TLS.
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -30,6 +30,7 @@ func NewHandler(deps *handlerCore.HandlerDependencies, config handlerCore.Handle
 // RegisterRoutes registers user routes.
 func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	user := router.Group(h.GetBasePath())
+	user.Use(handlerCore.RequireServices(h.deps, handlerCore.ServiceUser))
 	{
 		// Profile endpoints
 		user.GET("/profile", h.GetProfile)
@@ -206,14 +207,12 @@ func (h *Handler) GetBookmarks(c *gin.Context) {
 
 	// Parse pagination parameters
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
 
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 20
-	}
+	limit = h.config.ClampLimit(limit)
 
 	if h.config.EnableLogging {
 		h.logger.Info().
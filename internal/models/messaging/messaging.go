@@ -15,6 +15,10 @@ type NewsMessage struct {
 	Timestamp time.Time  `json:"timestamp"`
 	Retry     int        `json:"retry"`
 	MaxRetry  int        `json:"max_retry"`
+
+	// CorrelationID is assigned at collection and carried by every message
+	// derived from the article, so its journey can be traced across services
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 // ProcessingResult represents the result of processing a news message
@@ -197,6 +201,7 @@ func (f *MessageFilter) GetOffset() int {
 func NewNewsMessage(source string, messageType string, data news.News) *NewsMessage {
 	return &NewsMessage{
 		ID:        generateMessageID(),
+		CorrelationID: generateMessageID(),
 		Source:    source,
 		Type:      messageType,
 		Data:      data,
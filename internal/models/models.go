@@ -33,6 +33,14 @@ type News = news.News
 // DEPRECATED: Use news.Category instead
 type Category = news.Category
 
+// CategoryRequest represents a request to create or update a category
+// DEPRECATED: Use news.CategoryRequest instead
+type CategoryRequest = news.CategoryRequest
+
+// TagMergeRequest represents a request to merge tags
+// DEPRECATED: Use news.TagMergeRequest instead
+type TagMergeRequest = news.TagMergeRequest
+
 // NewsFilter represents filtering options for news
 // DEPRECATED: Use news.Filter instead
 type NewsFilter = news.Filter
@@ -41,6 +49,22 @@ type NewsFilter = news.Filter
 // DEPRECATED: Use news.CategoryStats instead
 type CategoryStats = news.CategoryStats
 
+// StatsFilter narrows statistics by date range, category and source
+// DEPRECATED: Use news.StatsFilter instead
+type StatsFilter = news.StatsFilter
+
+// DailyCount is the number of articles published on one day
+// DEPRECATED: Use news.DailyCount instead
+type DailyCount = news.DailyCount
+
+// CategorySentiment is the average sentiment of a category's recent articles
+// DEPRECATED: Use news.CategorySentiment instead
+type CategorySentiment = news.CategorySentiment
+
+// TagCount is the number of recent articles carrying a tag
+// DEPRECATED: Use news.TagCount instead
+type TagCount = news.TagCount
+
 // SourceStats represents statistics for a source
 // DEPRECATED: Use news.SourceStats instead
 type SourceStats = news.SourceStats
@@ -93,6 +117,34 @@ type Source = source.Source
 // DEPRECATED: Use source.SourceRequest instead
 type SourceRequest = source.SourceRequest
 
+// SourceImportResult summarizes a bulk source import
+// DEPRECATED: Use source.ImportResult instead
+type SourceImportResult = source.ImportResult
+
+// SourceHealthSummary summarizes fetch health across sources
+// DEPRECATED: Use source.HealthSummary instead
+type SourceHealthSummary = source.HealthSummary
+
+// SourceIngestCounts are article counters of one source
+// DEPRECATED: Use source.IngestCounts instead
+type SourceIngestCounts = source.IngestCounts
+
+// SourceArticleStats counts the articles stored for a source recently
+// DEPRECATED: Use source.ArticleStats instead
+type SourceArticleStats = source.ArticleStats
+
+// SourceIngestStat is one day of a source's ingestion counters
+// DEPRECATED: Use source.IngestStat instead
+type SourceIngestStat = source.IngestStat
+
+// SourceBulkUpdate is a partial update of one source
+// DEPRECATED: Use source.BulkUpdate instead
+type SourceBulkUpdate = source.BulkUpdate
+
+// SourceBulkUpdateResult reports the outcome of one partial update
+// DEPRECATED: Use source.BulkUpdateResult instead
+type SourceBulkUpdateResult = source.BulkUpdateResult
+
 // =============================================================================
 // SEARCH DOMAIN - Re-exported types from search package
 // =============================================================================
@@ -13,5 +13,27 @@ var (
 	ErrInvalidDateRange  = errors.New("date from must be before date to")
 	ErrNewsNotFound      = errors.New("news article not found")
 	ErrCategoryNotFound  = errors.New("category not found")
+	ErrCategoryInUse     = errors.New("category is assigned to existing articles")
+	ErrCategoryExists    = errors.New("category already exists")
+	ErrReassignToSelf    = errors.New("category cannot be reassigned to itself")
 	ErrDuplicateNews     = errors.New("news article already exists")
+	ErrEmptyTag          = errors.New("tag cannot be empty")
+	ErrTagMergeIntoSelf  = errors.New("tag cannot be merged into itself")
+	ErrInvalidPatch      = errors.New("invalid article patch")
 )
+
+// PatchError describes why an article patch was rejected. It matches
+// ErrInvalidPatch with errors.Is, and its message is safe to show to clients.
+type PatchError struct {
+	Reason string
+}
+
+// Error implements the error interface
+func (e *PatchError) Error() string {
+	return ErrInvalidPatch.Error() + ": " + e.Reason
+}
+
+// Is reports whether target is ErrInvalidPatch
+func (e *PatchError) Is(target error) bool {
+	return target == ErrInvalidPatch
+}
@@ -1,23 +1,47 @@
 package news
 
-import "time"
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
 
 // News represents a news article
 type News struct {
-	ID          string    `json:"id" db:"id"`
-	Title       string    `json:"title" db:"title"`
-	Content     string    `json:"content" db:"content"`
-	Summary     string    `json:"summary" db:"summary"`
-	URL         string    `json:"url" db:"url"`
-	ImageURL    string    `json:"image_url" db:"image_url"`
-	Author      string    `json:"author" db:"author"`
-	Source      string    `json:"source" db:"source"`
-	Category    string    `json:"category" db:"category"`
-	Tags        []string  `json:"tags" db:"tags"`
-	PublishedAt time.Time `json:"published_at" db:"published_at"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
-	Hash        string    `json:"-" db:"content_hash"` // For deduplication
+	ID             string    `json:"id" db:"id"`
+	Title          string    `json:"title" db:"title"`
+	Content        string    `json:"content" db:"content"`
+	Summary        string    `json:"summary" db:"summary"`
+	URL            string    `json:"url" db:"url"`
+	ImageURL       string    `json:"image_url" db:"image_url"`
+	Author         string    `json:"author" db:"author"`
+	Source         string    `json:"source" db:"source"`
+	Category       string    `json:"category" db:"category"`
+	SourceCategory string    `json:"source_category,omitempty" db:"source_category"` // As provided by the feed, before classification
+	Tags           []string  `json:"tags" db:"tags"`
+	PublishedAt    time.Time `json:"published_at" db:"published_at"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+	Hash           string    `json:"-" db:"content_hash"` // For deduplication
+
+	// Language is the ISO 639-1 code the article is indexed under; it is
+	// detected at indexing time and returned with search hits
+	Language string `json:"language,omitempty" db:"-"`
+
+	// Highlights holds matched fragments per field when the article is a search hit
+	Highlights map[string][]string `json:"highlights,omitempty" db:"-"`
+
+	// ExpiresAt is when cleanup will remove the article, derived from the
+	// retention period that applies to its source
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"-"`
+
+	// Freshness labels recently published articles ("new", "recent", ...)
+	// in API responses; see SetFreshness
+	Freshness string `json:"freshness,omitempty" db:"-"`
 }
 
 // Category represents a news category
@@ -29,6 +53,21 @@ type Category struct {
 	Icon        string `json:"icon" db:"icon"`
 }
 
+// CategoryRequest represents a request to create or update a category
+type CategoryRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Icon        string `json:"icon"`
+}
+
+// TagMergeRequest represents a request to merge one or more tags into a
+// single target tag. Renaming a tag is a merge with a single source tag.
+type TagMergeRequest struct {
+	From []string `json:"from" binding:"required"`
+	To   string   `json:"to" binding:"required"`
+}
+
 // Filter represents filtering options for news queries
 type Filter struct {
 	Page     int       `json:"page"`
@@ -38,6 +77,20 @@ type Filter struct {
 	Tags     []string  `json:"tags"`
 	DateFrom time.Time `json:"date_from"`
 	DateTo   time.Time `json:"date_to"`
+
+	// Categories and Sources match any of several values; they are used
+	// instead of Category and Source when a request names more than one
+	Categories []string `json:"categories,omitempty"`
+	Sources    []string `json:"sources,omitempty"`
+}
+
+// StatsFilter narrows statistics to articles published in [From, To) in a
+// category and from a source. Zero values leave a dimension unfiltered.
+type StatsFilter struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Category string    `json:"category"`
+	Source   string    `json:"source"`
 }
 
 // Stats contains news-related statistics
@@ -50,6 +103,26 @@ type Stats struct {
 	TopSources        []SourceStats   `json:"top_sources"`
 }
 
+// DailyCount is the number of articles published on one day
+type DailyCount struct {
+	Date  string `json:"date"` // YYYY-MM-DD
+	Count int64  `json:"count"`
+}
+
+// CategorySentiment is the average sentiment of the analyzed articles of a
+// category published within a period
+type CategorySentiment struct {
+	Category         string  `json:"category"`
+	AverageSentiment float64 `json:"average_sentiment"` // -1.0 to 1.0; 0 without articles
+	Articles         int64   `json:"articles"`
+}
+
+// TagCount is the number of recent articles carrying a tag
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
 // CategoryStats represents statistics for a specific category
 type CategoryStats struct {
 	Category string `json:"category"`
@@ -86,6 +159,103 @@ func (c *Category) Validate() error {
 	return nil
 }
 
+// Validate validates the CategoryRequest
+func (r *CategoryRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrEmptyCategoryName
+	}
+	return nil
+}
+
+// patchFields are the article fields a partial update may change, by JSON
+// name. Identity and bookkeeping fields (id, source, timestamps other than
+// published_at) are left out on purpose.
+var patchFields = map[string]bool{
+	"title":           true,
+	"content":         true,
+	"summary":         true,
+	"url":             true,
+	"image_url":       true,
+	"author":          true,
+	"category":        true,
+	"source_category": true,
+	"tags":            true,
+	"published_at":    true,
+}
+
+// NormalizePatch checks a partial article update decoded from JSON and
+// returns it with values converted to their field types: strings, []string
+// for tags and time.Time for published_at. Unknown fields, wrong types and
+// emptying title or url fail with a *PatchError matching ErrInvalidPatch.
+func NormalizePatch(fields map[string]interface{}) (map[string]interface{}, error) {
+	if len(fields) == 0 {
+		return nil, &PatchError{Reason: "no fields to update"}
+	}
+
+	normalized := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		if !patchFields[name] {
+			return nil, &PatchError{Reason: fmt.Sprintf("field %q cannot be updated", name)}
+		}
+
+		switch name {
+		case "tags":
+			values, ok := value.([]interface{})
+			if !ok {
+				return nil, &PatchError{Reason: "tags must be an array of strings"}
+			}
+			tags := make([]string, 0, len(values))
+			for _, v := range values {
+				tag, ok := v.(string)
+				if !ok {
+					return nil, &PatchError{Reason: "tags must be an array of strings"}
+				}
+				tags = append(tags, tag)
+			}
+			normalized[name] = tags
+
+		case "published_at":
+			text, ok := value.(string)
+			if !ok {
+				return nil, &PatchError{Reason: "published_at must be an RFC 3339 time"}
+			}
+			publishedAt, err := time.Parse(time.RFC3339, text)
+			if err != nil {
+				return nil, &PatchError{Reason: "published_at must be an RFC 3339 time"}
+			}
+			normalized[name] = publishedAt
+
+		default:
+			text, ok := value.(string)
+			if !ok {
+				return nil, &PatchError{Reason: name + " must be a string"}
+			}
+			if (name == "title" || name == "url") && strings.TrimSpace(text) == "" {
+				return nil, &PatchError{Reason: name + " cannot be empty"}
+			}
+			normalized[name] = text
+		}
+	}
+
+	return normalized, nil
+}
+
+// Validate validates the TagMergeRequest
+func (r *TagMergeRequest) Validate() error {
+	if strings.TrimSpace(r.To) == "" || len(r.From) == 0 {
+		return ErrEmptyTag
+	}
+	for _, tag := range r.From {
+		if strings.TrimSpace(tag) == "" {
+			return ErrEmptyTag
+		}
+		if tag == r.To {
+			return ErrTagMergeIntoSelf
+		}
+	}
+	return nil
+}
+
 // Validate validates the Filter struct
 func (f *Filter) Validate() error {
 	if f.Page < 0 {
@@ -100,6 +270,14 @@ func (f *Filter) Validate() error {
 	return nil
 }
 
+// Validate validates the stats filter
+func (f *StatsFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
 // Helper methods
 
 // IsRecent returns true if the news article was published within the last 24 hours
@@ -112,6 +290,97 @@ func (n *News) HasImage() bool {
 	return n.ImageURL != ""
 }
 
+// SetExpiry sets ExpiresAt from the publication time and the retention
+// period that applies to the article
+func (n *News) SetExpiry(retention time.Duration) {
+	expiresAt := n.PublishedAt.Add(retention)
+	n.ExpiresAt = &expiresAt
+}
+
+// FreshnessLevel labels articles published less than MaxAge ago
+type FreshnessLevel struct {
+	Label  string
+	MaxAge time.Duration
+}
+
+// SetFreshness sets Freshness to the label of the first level the article
+// is younger than; levels are ordered by increasing MaxAge. Older articles
+// and articles without a publication time get no label.
+func (n *News) SetFreshness(levels []FreshnessLevel, now time.Time) {
+	n.Freshness = ""
+	if n.PublishedAt.IsZero() {
+		return
+	}
+
+	age := now.Sub(n.PublishedAt)
+	for _, level := range levels {
+		if age < level.MaxAge {
+			n.Freshness = level.Label
+			return
+		}
+	}
+}
+
+// ETag returns a weak entity tag for the article. Every update to an
+// article bumps updated_at, so the tag changes whenever the article does;
+// the expiry and freshness are included because they change without one.
+func (n *News) ETag() string {
+	version := fmt.Sprintf("%s:%d", n.ID, n.UpdatedAt.UnixNano())
+	if n.ExpiresAt != nil {
+		version += fmt.Sprintf(":%d", n.ExpiresAt.Unix())
+	}
+	if n.Freshness != "" {
+		version += ":" + n.Freshness
+	}
+	sum := sha1.Sum([]byte(version))
+	return `W/"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// htmlTag matches the markup CleanText removes
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// htmlEntities decodes the entities CleanText understands
+var htmlEntities = strings.NewReplacer(
+	"&amp;", "&",
+	"&lt;", "<",
+	"&gt;", ">",
+	"&quot;", "\"",
+	"&apos;", "'",
+	"&nbsp;", " ",
+	"&hellip;", "...",
+	"&mdash;", "—",
+	"&ndash;", "–",
+	"&rsquo;", "'",
+	"&lsquo;", "'",
+	"&rdquo;", "\"",
+	"&ldquo;", "\"",
+)
+
+// CleanText removes HTML tags, decodes common entities and collapses
+// whitespace. The processor cleans article text with it before storing it.
+func CleanText(text string) string {
+	text = htmlTag.ReplaceAllString(text, "")
+	text = htmlEntities.Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// ContentHash returns the deduplication hash stored in content_hash: the
+// MD5 of the lowercased title, content and URL with whitespace collapsed.
+// Title and content are hashed as CleanText returns them, so an article
+// hashes the same as parsed, when the processor deduplicates it, and as
+// stored, when content hashes are backfilled. Content truncated by the
+// processor's max_content_length hashes differently.
+func (n *News) ContentHash() string {
+	content := strings.ToLower(CleanText(n.Title)) +
+		strings.ToLower(CleanText(n.Content)) +
+		strings.ToLower(strings.TrimSpace(n.URL))
+
+	// Remove extra whitespace
+	content = strings.Join(strings.Fields(content), " ")
+
+	return fmt.Sprintf("%x", md5.Sum([]byte(content)))
+}
+
 // GetAge returns the age of the news article
 func (n *News) GetAge() time.Duration {
 	return time.Since(n.PublishedAt)
@@ -0,0 +1,34 @@
+package news
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNormalizePatchReturnsPatchErrors(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"no fields":     {},
+		"unknown field": {"id": "x"},
+		"bad tags":      {"tags": "politics"},
+		"bad date":      {"published_at": "yesterday"},
+		"empty title":   {"title": " "},
+	}
+
+	for name, fields := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NormalizePatch(fields)
+
+			// Callers may wrap the error any number of times
+			wrapped := fmt.Errorf("failed to patch news: %w", fmt.Errorf("repository: %w", err))
+
+			var patchErr *PatchError
+			if !errors.As(wrapped, &patchErr) || patchErr.Reason == "" {
+				t.Fatalf("err = %v, want a *PatchError with a reason", err)
+			}
+			if !errors.Is(wrapped, ErrInvalidPatch) {
+				t.Errorf("err = %v, want it to match ErrInvalidPatch", err)
+			}
+		})
+	}
+}
@@ -1,9 +1,47 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 )
 
+// ErrInvalidScore is returned when a score is NaN or infinite
+var ErrInvalidScore = errors.New("score is not a finite number")
+
+// Score refresh errors
+var (
+	ErrScoreRefreshInProgress = errors.New("a score refresh is already running")
+	ErrScoreRefreshNotFound   = errors.New("score refresh not found")
+)
+
+// boundedScore is a score field together with its valid range
+type boundedScore struct {
+	name     string
+	value    *float64
+	min, max float64
+}
+
+// clampScores clamps each score into its range and returns the names of
+// the scores that were outside it. Scores are stored as DECIMAL(5,4), which
+// only holds values up to ±9.9999, so larger values would fail the insert;
+// the narrower ranges keep scores comparable when they are combined.
+func clampScores(scores []boundedScore) ([]string, error) {
+	var clamped []string
+	for _, score := range scores {
+		value := *score.value
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScore, score.name)
+		}
+		if value < score.min || value > score.max {
+			*score.value = math.Max(score.min, math.Min(score.max, value))
+			clamped = append(clamped, score.name)
+		}
+	}
+	return clamped, nil
+}
+
 // ArticleScore represents the comprehensive scoring for an article
 type ArticleScore struct {
 	ID               string    `json:"id" db:"id"`
@@ -17,6 +55,18 @@ type ArticleScore struct {
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 }
 
+// Normalize clamps the component and final scores to [0, 1] and returns
+// the names of the scores that were out of range
+func (s *ArticleScore) Normalize() ([]string, error) {
+	return clampScores([]boundedScore{
+		{"engagement_score", &s.EngagementScore, 0, 1},
+		{"credibility_score", &s.CredibilityScore, 0, 1},
+		{"content_score", &s.ContentScore, 0, 1},
+		{"social_score", &s.SocialScore, 0, 1},
+		{"final_score", &s.FinalScore, 0, 1},
+	})
+}
+
 // EngagementMetrics tracks user engagement with articles
 type EngagementMetrics struct {
 	ID              string    `json:"id" db:"id"`
@@ -42,6 +92,24 @@ type SourceCredibility struct {
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 }
 
+// Normalize clamps the scores to [0, 1], and the bias to [-1, 1], and
+// returns the names of the scores that were out of range
+func (c *SourceCredibility) Normalize() ([]string, error) {
+	return clampScores([]boundedScore{
+		{"credibility_score", &c.CredibilityScore, 0, 1},
+		{"reliability_score", &c.ReliabilityScore, 0, 1},
+		{"bias_score", &c.BiasScore, -1, 1},
+		{"factual_score", &c.FactualScore, 0, 1},
+	})
+}
+
+// KeywordMention is a single extracted keyword occurrence for an article
+type KeywordMention struct {
+	Keyword   string    `json:"keyword"`
+	ArticleID string    `json:"article_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // ContentAnalysis stores NLP analysis results
 type ContentAnalysis struct {
 	ID                  string            `json:"id" db:"id"`
@@ -57,6 +125,16 @@ type ContentAnalysis struct {
 	CreatedAt           time.Time         `json:"created_at" db:"created_at"`
 }
 
+// Normalize clamps the sentiment to [-1, 1] and the other scores to [0, 1]
+// and returns the names of the scores that were out of range
+func (a *ContentAnalysis) Normalize() ([]string, error) {
+	return clampScores([]boundedScore{
+		{"sentiment_score", &a.SentimentScore, -1, 1},
+		{"importance_score", &a.ImportanceScore, 0, 1},
+		{"readability_score", &a.ReadabilityScore, 0, 1},
+	})
+}
+
 // SocialMetrics tracks social media engagement
 type SocialMetrics struct {
 	ID             string             `json:"id" db:"id"`
@@ -75,26 +153,56 @@ type SocialMetrics struct {
 
 // ScoringWeights defines the weights for different scoring components
 type ScoringWeights struct {
-	EngagementWeight  float64 `json:"engagement_weight" yaml:"engagement_weight"`
-	CredibilityWeight float64 `json:"credibility_weight" yaml:"credibility_weight"`
-	ContentWeight     float64 `json:"content_weight" yaml:"content_weight"`
-	SocialWeight      float64 `json:"social_weight" yaml:"social_weight"`
-	RecencyWeight     float64 `json:"recency_weight" yaml:"recency_weight"`
+	EngagementWeight  float64 `json:"engagement_weight" yaml:"engagement_weight" mapstructure:"engagement_weight"`
+	CredibilityWeight float64 `json:"credibility_weight" yaml:"credibility_weight" mapstructure:"credibility_weight"`
+	ContentWeight     float64 `json:"content_weight" yaml:"content_weight" mapstructure:"content_weight"`
+	SocialWeight      float64 `json:"social_weight" yaml:"social_weight" mapstructure:"social_weight"`
+	RecencyWeight     float64 `json:"recency_weight" yaml:"recency_weight" mapstructure:"recency_weight"`
 }
 
 // CategoryBalance defines requirements for category diversity
 type CategoryBalance struct {
-	MinCategories      int                `json:"min_categories" yaml:"min_categories"`
-	MaxPerCategory     int                `json:"max_per_category" yaml:"max_per_category"`
-	CategoryWeights    map[string]float64 `json:"category_weights" yaml:"category_weights"`
-	RequiredCategories []string           `json:"required_categories" yaml:"required_categories"`
+	MinCategories      int                `json:"min_categories" yaml:"min_categories" mapstructure:"min_categories"`
+	MaxPerCategory     int                `json:"max_per_category" yaml:"max_per_category" mapstructure:"max_per_category"`
+	CategoryWeights    map[string]float64 `json:"category_weights" yaml:"category_weights" mapstructure:"category_weights"`
+	RequiredCategories []string           `json:"required_categories" yaml:"required_categories" mapstructure:"required_categories"`
 }
 
 // TopStoriesConfig defines configuration for the enhanced algorithm
 type TopStoriesConfig struct {
-	ScoringWeights  ScoringWeights  `json:"scoring_weights" yaml:"scoring_weights"`
-	CategoryBalance CategoryBalance `json:"category_balance" yaml:"category_balance"`
-	MinScore        float64         `json:"min_score" yaml:"min_score"`
-	MaxAge          time.Duration   `json:"max_age" yaml:"max_age"`
-	RefreshInterval time.Duration   `json:"refresh_interval" yaml:"refresh_interval"`
+	ScoringWeights  ScoringWeights  `json:"scoring_weights" yaml:"scoring_weights" mapstructure:"scoring_weights"`
+	CategoryBalance CategoryBalance `json:"category_balance" yaml:"category_balance" mapstructure:"category_balance"`
+	MinScore        float64         `json:"min_score" yaml:"min_score" mapstructure:"min_score"`
+	MaxAge          time.Duration   `json:"max_age" yaml:"max_age" mapstructure:"max_age"`
+	RefreshInterval time.Duration   `json:"refresh_interval" yaml:"refresh_interval" mapstructure:"refresh_interval"`
+
+	// SocialRefreshInterval is how old stored social metrics may get before
+	// they are fetched again (0 uses the 6 hour default)
+	SocialRefreshInterval time.Duration `json:"social_refresh_interval" yaml:"social_refresh_interval" mapstructure:"social_refresh_interval"`
+
+	// RecencyDecay is the exponential decay rate of the recency score over
+	// MaxAge (0 uses the default of 0.1); CategoryRecencyDecay overrides it
+	// per category so fast-moving news such as sports ages quicker
+	RecencyDecay         float64            `json:"recency_decay" yaml:"recency_decay" mapstructure:"recency_decay"`
+	CategoryRecencyDecay map[string]float64 `json:"category_recency_decay" yaml:"category_recency_decay" mapstructure:"category_recency_decay"`
+}
+
+// Score refresh statuses
+const (
+	ScoreRefreshRunning   = "running"
+	ScoreRefreshCompleted = "completed"
+	ScoreRefreshFailed    = "failed"
+)
+
+// ScoreRefresh tracks the progress of a score refresh pass over the recent
+// articles
+type ScoreRefresh struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed"`
+	Failed     int        `json:"failed"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
 }
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestArticleScoreNormalizeClampsOutOfRange(t *testing.T) {
+	score := ArticleScore{
+		EngagementScore:  1.7,
+		CredibilityScore: -0.2,
+		ContentScore:     0.5,
+		SocialScore:      12.5,
+		FinalScore:       1,
+	}
+
+	clamped, err := score.Normalize()
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+
+	want := ArticleScore{
+		EngagementScore:  1,
+		CredibilityScore: 0,
+		ContentScore:     0.5,
+		SocialScore:      1,
+		FinalScore:       1,
+	}
+	if score != want {
+		t.Errorf("normalized score = %+v, want %+v", score, want)
+	}
+	if names := []string{"engagement_score", "credibility_score", "social_score"}; !reflect.DeepEqual(clamped, names) {
+		t.Errorf("clamped = %v, want %v", clamped, names)
+	}
+}
+
+func TestNormalizeUsesEachScoresRange(t *testing.T) {
+	credibility := SourceCredibility{CredibilityScore: 2, ReliabilityScore: 0.4, BiasScore: -3, FactualScore: -1}
+	if _, err := credibility.Normalize(); err != nil {
+		t.Fatalf("SourceCredibility.Normalize returned error: %v", err)
+	}
+	if credibility.CredibilityScore != 1 || credibility.ReliabilityScore != 0.4 ||
+		credibility.BiasScore != -1 || credibility.FactualScore != 0 {
+		t.Errorf("normalized credibility = %+v", credibility)
+	}
+
+	// Sentiment may be negative, the other analysis scores may not
+	analysis := ContentAnalysis{SentimentScore: -0.8, ImportanceScore: -0.8, ReadabilityScore: 9.9}
+	clamped, err := analysis.Normalize()
+	if err != nil {
+		t.Fatalf("ContentAnalysis.Normalize returned error: %v", err)
+	}
+	if analysis.SentimentScore != -0.8 || analysis.ImportanceScore != 0 || analysis.ReadabilityScore != 1 {
+		t.Errorf("normalized analysis = %+v", analysis)
+	}
+	if names := []string{"importance_score", "readability_score"}; !reflect.DeepEqual(clamped, names) {
+		t.Errorf("clamped = %v, want %v", clamped, names)
+	}
+}
+
+func TestNormalizeRejectsNonFiniteScores(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		score := ArticleScore{FinalScore: value}
+		if _, err := score.Normalize(); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("Normalize(%v) error = %v, want ErrInvalidScore", value, err)
+		}
+	}
+}
+
+func TestClampScoresLeavesInRangeValues(t *testing.T) {
+	low, high := 0.0, 1.0
+	clamped, err := clampScores([]boundedScore{
+		{"low", &low, 0, 1},
+		{"high", &high, 0, 1},
+	})
+	if err != nil {
+		t.Fatalf("clampScores returned error: %v", err)
+	}
+	if len(clamped) != 0 || low != 0 || high != 1 {
+		t.Errorf("clampScores changed in-range bounds: clamped=%v low=%v high=%v", clamped, low, high)
+	}
+}
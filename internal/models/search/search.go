@@ -3,6 +3,7 @@ package search
 import (
 	"time"
 	"news-aggregator/internal/models/news"
+	"news-aggregator/internal/models/user"
 )
 
 // Query represents a search query
@@ -18,6 +19,14 @@ type Query struct {
 	Limit      int       `json:"limit"`
 	SortBy     string    `json:"sort_by"`     // relevance, date, popularity
 	SortOrder  string    `json:"sort_order"`  // asc, desc
+	Exact      bool      `json:"exact"`       // disable typo-tolerant matching
+	Language   string    `json:"language"`    // ISO 639-1 code; searches that language's analyzed fields
+
+	// Personalize boosts results in the user's preferred categories and
+	// sources. It only changes the ordering, never the result set, and is
+	// a no-op when Preferences is nil (anonymous users).
+	Personalize bool              `json:"personalize"`
+	Preferences *user.Preferences `json:"-"`
 }
 
 // Result represents search results
@@ -193,6 +202,13 @@ func (q *Query) HasFilters() bool {
 		   !q.DateTo.IsZero()
 }
 
+// IsPersonalized returns true if preference boosting should be applied
+func (q *Query) IsPersonalized() bool {
+	return q.Personalize &&
+		q.Preferences != nil &&
+		(len(q.Preferences.Categories) > 0 || len(q.Preferences.Sources) > 0)
+}
+
 // GetOffset returns the offset for pagination
 func (q *Query) GetOffset() int {
 	return (q.Page - 1) * q.Limit
@@ -12,8 +12,13 @@ var (
 	ErrInvalidSourceURL   = errors.New("invalid source URL format")
 	ErrInvalidSchedule    = errors.New("invalid schedule format")
 	ErrInvalidRateLimit   = errors.New("rate limit must be non-negative")
+	ErrInconsistentRateLimit = errors.New("schedule and rate limit are inconsistent")
 	ErrInvalidPage        = errors.New("page number must be positive")
 	ErrInvalidLimit       = errors.New("limit must be between 1 and 1000")
+	ErrInvalidOPML        = errors.New("invalid OPML document")
+	ErrEmptySourceID      = errors.New("source ID cannot be empty")
+	ErrInvalidSourceID    = errors.New("source ID must be a UUID")
+	ErrEmptyBulkUpdate    = errors.New("update must set schedule, rate_limit or enabled")
 	
 	// Business logic errors
 	ErrSourceNotFound       = errors.New("source not found")
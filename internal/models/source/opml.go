@@ -0,0 +1,112 @@
+package source
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// OPML represents an OPML 2.0 document as used by feed readers to exchange
+// subscription lists
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    OPMLHead `xml:"head"`
+	Body    OPMLBody `xml:"body"`
+}
+
+// OPMLHead holds the document metadata
+type OPMLHead struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// OPMLBody holds the top-level outlines
+type OPMLBody struct {
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// OPMLOutline is a single outline entry. Feed entries carry an xmlUrl;
+// entries without one are folders that group nested outlines.
+type OPMLOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Outlines []OPMLOutline `xml:"outline,omitempty"`
+}
+
+// OPMLFeed is a feed entry extracted from an OPML document
+type OPMLFeed struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// ImportResult summarizes a bulk source import
+type ImportResult struct {
+	Added   []string        `json:"added"`
+	Skipped []string        `json:"skipped"`
+	Failed  []ImportFailure `json:"failed"`
+}
+
+// ImportFailure describes a feed that could not be imported
+type ImportFailure struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// ParseOPML reads an OPML document and returns every outline that has an
+// xmlUrl, flattening nested folders
+func ParseOPML(r io.Reader) ([]OPMLFeed, error) {
+	var doc OPML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, ErrInvalidOPML
+	}
+
+	var feeds []OPMLFeed
+	var walk func(outlines []OPMLOutline)
+	walk = func(outlines []OPMLOutline) {
+		for _, outline := range outlines {
+			if url := strings.TrimSpace(outline.XMLURL); url != "" {
+				title := strings.TrimSpace(outline.Title)
+				if title == "" {
+					title = strings.TrimSpace(outline.Text)
+				}
+				feeds = append(feeds, OPMLFeed{Title: title, URL: url})
+			}
+			walk(outline.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+
+	return feeds, nil
+}
+
+// NewOPML builds an OPML document listing the RSS sources. Sources of other
+// types have no feed URL a reader could subscribe to and are left out.
+func NewOPML(title string, sources []Source) *OPML {
+	doc := &OPML{
+		Version: "2.0",
+		Head: OPMLHead{
+			Title:       title,
+			DateCreated: time.Now().UTC().Format(time.RFC1123Z),
+		},
+		Body: OPMLBody{Outlines: []OPMLOutline{}},
+	}
+
+	for _, source := range sources {
+		if source.Type != "rss" {
+			continue
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, OPMLOutline{
+			Text:   source.Name,
+			Title:  source.Name,
+			Type:   "rss",
+			XMLURL: source.URL,
+		})
+	}
+
+	return doc
+}
@@ -2,7 +2,10 @@ package source
 
 import (
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Source represents a news source
@@ -11,13 +14,21 @@ type Source struct {
 	Name        string            `json:"name" db:"name"`
 	Type        string            `json:"type" db:"type"` // rss, api, scraper
 	URL         string            `json:"url" db:"url"`
-	Schedule    string            `json:"schedule" db:"schedule"`
-	RateLimit   int               `json:"rate_limit" db:"rate_limit"`
+	Schedule    string            `json:"schedule" db:"schedule"`   // time between polls
+	RateLimit   int               `json:"rate_limit" db:"rate_limit"` // requests per second while polling
 	Headers     map[string]string `json:"headers" db:"headers"`
 	Enabled     bool              `json:"enabled" db:"enabled"`
 	LastFetched time.Time         `json:"last_fetched" db:"last_fetched"`
+	LastSuccess time.Time         `json:"last_success" db:"last_success"`
 	LastError   string            `json:"last_error" db:"last_error"`
 	ErrorCount  int               `json:"error_count" db:"error_count"`
+
+	// LastErrorCategory classifies LastError, e.g. "network", "timeout",
+	// "http", "parse" or "validation"
+	LastErrorCategory string `json:"last_error_category,omitempty" db:"last_error_category"`
+
+	// ConsecutiveFailures counts fetches that failed since the last success
+	ConsecutiveFailures int `json:"consecutive_failures" db:"consecutive_failures"`
 	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
 }
@@ -33,6 +44,43 @@ type SourceRequest struct {
 	Enabled   bool              `json:"enabled"`
 }
 
+// BulkUpdate is a partial update of one source; nil fields are left unchanged
+type BulkUpdate struct {
+	ID        string  `json:"id" binding:"required"`
+	Schedule  *string `json:"schedule,omitempty"`
+	RateLimit *int    `json:"rate_limit,omitempty"`
+	Enabled   *bool   `json:"enabled,omitempty"`
+}
+
+// BulkUpdateResult reports the outcome of one partial update
+type BulkUpdateResult struct {
+	ID      string `json:"id"`
+	Updated bool   `json:"updated"`
+	Error   string `json:"error,omitempty"`
+}
+
+// IngestCounts are article counters of one source. Fetched is recorded by
+// the collector; New, Duplicate and Failed are the processor's outcomes.
+type IngestCounts struct {
+	Fetched   int64 `json:"fetched"`
+	New       int64 `json:"new"`
+	Duplicate int64 `json:"duplicate"`
+	Failed    int64 `json:"failed"`
+}
+
+// IngestStat is one day of a source's ingestion counters
+type IngestStat struct {
+	Source string `json:"source"`
+	Date   string `json:"date"`
+	IngestCounts
+}
+
+// ArticleStats counts the articles stored for a source recently
+type ArticleStats struct {
+	Last24h int64 `json:"last_24h"`
+	Last7d  int64 `json:"last_7d"`
+}
+
 // SourceFilter represents filtering options for sources
 type SourceFilter struct {
 	Type     string `json:"type"`
@@ -41,6 +89,16 @@ type SourceFilter struct {
 	Limit    int    `json:"limit"`
 }
 
+// HealthSummary summarizes fetch health across all sources
+type HealthSummary struct {
+	Total   int      `json:"total"`
+	Healthy int      `json:"healthy"`
+	Failing []Source `json:"failing"`
+
+	// FailingByCategory counts the failing sources per last error category
+	FailingByCategory map[string]int `json:"failing_by_category"`
+}
+
 // SourceStats represents statistics for a source
 type SourceStats struct {
 	SourceID        string        `json:"source_id"`
@@ -151,6 +209,28 @@ func (r *SourceRequest) Validate() error {
 	return nil
 }
 
+// Validate validates the BulkUpdate
+func (u *BulkUpdate) Validate() error {
+	if u.ID == "" {
+		return ErrEmptySourceID
+	}
+	if _, err := uuid.Parse(u.ID); err != nil {
+		return ErrInvalidSourceID
+	}
+	if u.Schedule == nil && u.RateLimit == nil && u.Enabled == nil {
+		return ErrEmptyBulkUpdate
+	}
+	if u.Schedule != nil {
+		if _, err := time.ParseDuration(*u.Schedule); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+	if u.RateLimit != nil && *u.RateLimit < 0 {
+		return ErrInvalidRateLimit
+	}
+	return nil
+}
+
 // Validate validates the SourceFilter
 func (f *SourceFilter) Validate() error {
 	if f.Page < 0 {
@@ -189,6 +269,30 @@ func (s *Source) IsHealthy() bool {
 	return s.Enabled
 }
 
+// sensitiveHeaderMarkers identifies request headers that carry credentials
+var sensitiveHeaderMarkers = []string{"authorization", "cookie", "token", "secret", "api-key", "apikey", "password"}
+
+// WithoutSecrets returns a copy of the source with credential-bearing
+// headers removed, suitable for exporting
+func (s Source) WithoutSecrets() Source {
+	headers := make(map[string]string, len(s.Headers))
+	for name, value := range s.Headers {
+		lower := strings.ToLower(name)
+		sensitive := false
+		for _, marker := range sensitiveHeaderMarkers {
+			if strings.Contains(lower, marker) {
+				sensitive = true
+				break
+			}
+		}
+		if !sensitive {
+			headers[name] = value
+		}
+	}
+	s.Headers = headers
+	return s
+}
+
 // GetScheduleDuration returns the schedule as a time.Duration
 func (s *Source) GetScheduleDuration() (time.Duration, error) {
 	if s.Schedule == "" {
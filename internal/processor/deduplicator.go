@@ -3,18 +3,28 @@ package processor
 import (
 	"context"
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"strings"
 
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
 	"news-aggregator/internal/services"
+	"news-aggregator/pkg/metrics"
 
 	"github.com/rs/zerolog"
 )
 
+// duplicateStore looks up stored articles for the Deduplicator; it is
+// satisfied by *services.NewsService
+type duplicateStore interface {
+	CheckDuplicate(ctx context.Context, hash string) (bool, error)
+	GetNewsByURL(ctx context.Context, url string) (*models.News, error)
+}
+
 // Deduplicator handles duplicate detection for news articles
 type Deduplicator struct {
-	newsService *services.NewsService
+	newsService duplicateStore
 	logger      zerolog.Logger
 }
 
@@ -25,32 +35,40 @@ func NewDeduplicator(newsService *services.NewsService, logger zerolog.Logger) *
 	}
 }
 
-// IsDuplicate checks if a news article is a duplicate
-func (d *Deduplicator) IsDuplicate(ctx context.Context, news *models.News) (bool, error) {
+// Check reports whether a news article is a duplicate of a stored one. When
+// the article's URL is already stored with different content, the feed
+// re-published an edited version: Check returns the stored article so the
+// caller can update it instead of adding another copy.
+func (d *Deduplicator) Check(ctx context.Context, news *models.News) (bool, *models.News, error) {
+	// Hash the article as parsed, before transformers change it, so that a
+	// redelivered message hashes the same as the article already stored
+	if news.Hash == "" {
+		news.Hash = d.generateContentHash(news)
+	}
+
 	d.logger.Debug().Str("title", news.Title).Str("hash", news.Hash).Msg("Checking for duplicate")
 
 	// Method 1: Check by content hash
-	if news.Hash != "" {
-		exists, err := d.newsService.CheckDuplicate(ctx, news.Hash)
-		if err != nil {
-			d.logger.Error().Err(err).Str("hash", news.Hash).Msg("Failed to check duplicate by hash")
-			// Continue with other methods if hash check fails
-		} else if exists {
-			d.logger.Info().Str("hash", news.Hash).Msg("Duplicate found by content hash")
-			return true, nil
-		}
+	exists, err := d.newsService.CheckDuplicate(ctx, news.Hash)
+	if err != nil {
+		d.logger.Error().Err(err).Str("hash", news.Hash).Msg("Failed to check duplicate by hash")
+		// Continue with other methods if hash check fails
+	} else if exists {
+		metrics.DuplicatesSkipped.WithLabelValues(metrics.MatchContentHash).Inc()
+		d.logger.Info().Str("hash", news.Hash).Msg("Duplicate found by content hash")
+		return true, nil, nil
 	}
 
-	// Method 2: Check by URL
+	// Method 2: Check by URL. The content hash differs, so a stored article
+	// with this URL is an earlier version of this one.
 	if news.URL != "" {
-		// Generate hash from URL for comparison
-		urlHash := d.generateHash(news.URL)
-		exists, err := d.newsService.CheckDuplicate(ctx, urlHash)
-		if err != nil {
+		stored, err := d.newsService.GetNewsByURL(ctx, news.URL)
+		switch {
+		case err == nil:
+			d.logger.Info().Str("url", news.URL).Str("id", stored.ID).Msg("Edited version of stored article found by URL")
+			return false, stored, nil
+		case !errors.Is(err, newsModels.ErrNewsNotFound):
 			d.logger.Error().Err(err).Str("url", news.URL).Msg("Failed to check duplicate by URL")
-		} else if exists {
-			d.logger.Info().Str("url", news.URL).Msg("Duplicate found by URL")
-			return true, nil
 		}
 	}
 
@@ -60,8 +78,9 @@ func (d *Deduplicator) IsDuplicate(ctx context.Context, news *models.News) (bool
 		if err != nil {
 			d.logger.Error().Err(err).Str("title", news.Title).Msg("Failed to check title similarity")
 		} else if isDuplicate {
+			metrics.DuplicatesSkipped.WithLabelValues(metrics.MatchTitle).Inc()
 			d.logger.Info().Str("title", news.Title).Msg("Duplicate found by title similarity")
-			return true, nil
+			return true, nil, nil
 		}
 	}
 
@@ -71,18 +90,14 @@ func (d *Deduplicator) IsDuplicate(ctx context.Context, news *models.News) (bool
 		if err != nil {
 			d.logger.Error().Err(err).Msg("Failed to check content similarity")
 		} else if isDuplicate {
+			metrics.DuplicatesSkipped.WithLabelValues(metrics.MatchContent).Inc()
 			d.logger.Info().Str("title", news.Title).Msg("Duplicate found by content similarity")
-			return true, nil
+			return true, nil, nil
 		}
 	}
 
-	// Ensure the news has a content hash for future duplicate checks
-	if news.Hash == "" {
-		news.Hash = d.generateContentHash(news)
-	}
-
 	d.logger.Debug().Str("title", news.Title).Msg("No duplicate found")
-	return false, nil
+	return false, nil, nil
 }
 
 // generateHash generates a hash from input string
@@ -93,15 +108,7 @@ func (d *Deduplicator) generateHash(input string) string {
 
 // generateContentHash generates a hash from news content for deduplication
 func (d *Deduplicator) generateContentHash(news *models.News) string {
-	// Combine title, content, and URL for a comprehensive hash
-	content := strings.ToLower(strings.TrimSpace(news.Title)) + 
-		strings.ToLower(strings.TrimSpace(news.Content)) + 
-		strings.ToLower(strings.TrimSpace(news.URL))
-	
-	// Remove extra whitespace
-	content = strings.Join(strings.Fields(content), " ")
-	
-	return d.generateHash(content)
+	return news.ContentHash()
 }
 
 // checkTitleSimilarity checks if a similar title already exists
@@ -0,0 +1,322 @@
+package processor
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/models"
+	"news-aggregator/internal/services"
+	"news-aggregator/pkg/metrics"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// indexBatchSize flushes the pending batch once it holds this many articles
+	indexBatchSize = 100
+
+	// indexFlushInterval bounds how long a processed article waits before it becomes searchable
+	indexFlushInterval = 2 * time.Second
+
+	// bulkIndexThreshold is the batch size above which the _bulk API is used;
+	// smaller batches are indexed one document at a time
+	bulkIndexThreshold = 5
+
+	// indexFlushTimeout bounds a single flush, including the final one on shutdown
+	indexFlushTimeout = 30 * time.Second
+
+	// latencyCheckInterval is how often the p95 index latency is compared
+	// against the configured threshold
+	latencyCheckInterval = time.Minute
+
+	// maxDelayedArticles caps how many articles are held back by indexDelay;
+	// beyond it articles are indexed right away rather than held in memory
+	maxDelayedArticles = 10000
+
+	// delayedRecoveryWindow is how far past indexDelay the indexer looks on
+	// startup for articles that were held back when the processor last
+	// stopped without draining
+	delayedRecoveryWindow = 24 * time.Hour
+
+	// recoveryBatchSize is how many recent articles are checked against the
+	// index per request during recovery
+	recoveryBatchSize = 500
+)
+
+// SearchIndexer buffers processed articles and indexes them in batches so
+// bulk ingest doesn't pay for a refresh per document
+type SearchIndexer struct {
+	searchService    *services.SearchService
+	newsService      *services.NewsService
+	logger           zerolog.Logger
+	latencyThreshold time.Duration
+	indexDelay       time.Duration
+
+	mu      sync.Mutex
+	pending []models.News
+	flushCh chan struct{}
+
+	// delayed holds articles younger than indexDelay, at most
+	// maxDelayedArticles of them
+	delayed []models.News
+
+	// latencies holds the index latencies observed since the last check
+	latencyMu sync.Mutex
+	latencies []time.Duration
+}
+
+// NewSearchIndexer creates an indexer. The news service reloads delayed
+// articles before they are indexed.
+func NewSearchIndexer(searchService *services.SearchService, newsService *services.NewsService, cfg config.ProcessorConfig, logger zerolog.Logger) *SearchIndexer {
+	return &SearchIndexer{
+		searchService:    searchService,
+		newsService:      newsService,
+		logger:           logger.With().Str("component", "search_indexer").Logger(),
+		latencyThreshold: cfg.IndexLatencyThreshold,
+		indexDelay:       cfg.IndexDelay,
+		flushCh:          make(chan struct{}, 1),
+	}
+}
+
+// Add queues an article for indexing, or holds it back until it is
+// indexDelay old. Once maxDelayedArticles are held back, further articles
+// are queued right away.
+func (si *SearchIndexer) Add(news models.News) {
+	si.mu.Lock()
+	if si.indexDelay > 0 && time.Since(news.PublishedAt) < si.indexDelay {
+		if len(si.delayed) < maxDelayedArticles {
+			si.delayed = append(si.delayed, news)
+			si.mu.Unlock()
+			return
+		}
+		si.logger.Warn().Str("id", news.ID).Int("limit", maxDelayedArticles).Msg("Too many delayed articles, indexing right away")
+	}
+	si.pending = append(si.pending, news)
+	full := len(si.pending) >= indexBatchSize
+	si.mu.Unlock()
+
+	if full {
+		select {
+		case si.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run flushes pending articles periodically until the context is cancelled,
+// then flushes whatever is left
+func (si *SearchIndexer) Run(ctx context.Context) {
+	si.recoverDelayed(ctx)
+
+	ticker := time.NewTicker(indexFlushInterval)
+	defer ticker.Stop()
+
+	latencyTicker := time.NewTicker(latencyCheckInterval)
+	defer latencyTicker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			si.releaseDelayed(ctx, false)
+			si.flush(ctx)
+		case <-latencyTicker.C:
+			si.checkLatency()
+		case <-si.flushCh:
+			si.flush(ctx)
+		case <-ctx.Done():
+			si.drain(context.Background())
+			return
+		}
+	}
+}
+
+// drain indexes everything the indexer holds, including delayed articles
+// that have not reached indexDelay yet, so none are lost on shutdown
+func (si *SearchIndexer) drain(ctx context.Context) {
+	si.releaseDelayed(ctx, true)
+	si.flush(ctx)
+}
+
+// recoverDelayed picks up articles that were held back by indexDelay when
+// the processor last stopped without draining, e.g. after a crash. Recent
+// articles missing from the index are added again: those that reached
+// indexDelay in the meantime are indexed right away, the others are held
+// back for the rest of the delay.
+func (si *SearchIndexer) recoverDelayed(ctx context.Context) {
+	if si.indexDelay <= 0 {
+		return
+	}
+
+	idsCtx, cancel := context.WithTimeout(ctx, indexFlushTimeout)
+	ids, err := si.newsService.GetIDsPublishedSince(idsCtx, time.Now().Add(-si.indexDelay-delayedRecoveryWindow))
+	cancel()
+	if err != nil {
+		si.logger.Warn().Err(err).Msg("Failed to look up recent articles, skipping delayed article recovery")
+		return
+	}
+
+	recovered := 0
+	for start := 0; start < len(ids); start += recoveryBatchSize {
+		end := start + recoveryBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		n, err := si.recoverBatch(ctx, ids[start:end])
+		if err != nil {
+			si.logger.Warn().Err(err).Int("recovered", recovered).Msg("Failed to recover delayed articles")
+			return
+		}
+		recovered += n
+
+		// Index each batch as it is recovered rather than all at once
+		si.flush(ctx)
+	}
+
+	if recovered > 0 {
+		si.logger.Info().Int("count", recovered).Msg("Recovered articles missing from the index")
+	}
+}
+
+// recoverBatch adds the articles among ids that are not indexed and
+// returns how many there were
+func (si *SearchIndexer) recoverBatch(ctx context.Context, ids []string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, indexFlushTimeout)
+	defer cancel()
+
+	missing, err := si.searchService.MissingFromIndex(ctx, ids)
+	if err != nil || len(missing) == 0 {
+		return 0, err
+	}
+
+	articles, err := si.newsService.GetNewsByIDs(ctx, missing)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, news := range articles {
+		si.Add(news)
+	}
+	return len(articles), nil
+}
+
+// releaseDelayed moves delayed articles that reached indexDelay (or all of
+// them when all is set) to the pending batch. They are reloaded first so
+// the index gets the version stored now rather than the one first seen;
+// articles deleted in the meantime are dropped.
+func (si *SearchIndexer) releaseDelayed(ctx context.Context, all bool) {
+	si.mu.Lock()
+	var due, waiting []models.News
+	for _, news := range si.delayed {
+		if all || time.Since(news.PublishedAt) >= si.indexDelay {
+			due = append(due, news)
+		} else {
+			waiting = append(waiting, news)
+		}
+	}
+	si.delayed = waiting
+	si.mu.Unlock()
+
+	if len(due) == 0 {
+		return
+	}
+
+	ids := make([]string, len(due))
+	for i := range due {
+		ids[i] = due[i].ID
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, indexFlushTimeout)
+	defer cancel()
+
+	current, err := si.newsService.GetNewsByIDs(ctx, ids)
+	if err != nil {
+		// Index the versions we have rather than not at all
+		si.logger.Warn().Err(err).Int("count", len(due)).Msg("Failed to reload delayed articles, indexing them as processed")
+		current = due
+	}
+
+	si.mu.Lock()
+	si.pending = append(si.pending, current...)
+	si.mu.Unlock()
+}
+
+// flush indexes the pending batch. Indexing failures are logged and the
+// batch is dropped since the articles are already stored in the database.
+func (si *SearchIndexer) flush(ctx context.Context) {
+	si.mu.Lock()
+	batch := si.pending
+	si.pending = nil
+	si.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, indexFlushTimeout)
+	defer cancel()
+
+	if len(batch) > bulkIndexThreshold {
+		if err := si.searchService.BulkIndex(ctx, batch); err != nil {
+			si.logger.Error().Err(err).Int("count", len(batch)).Msg("Failed to bulk index news for search")
+			return
+		}
+		metrics.ArticlesIndexed.Add(float64(len(batch)))
+		si.observeLatency(batch...)
+		return
+	}
+
+	for i := range batch {
+		if err := si.searchService.IndexNews(ctx, &batch[i]); err != nil {
+			si.logger.Error().Err(err).Str("id", batch[i].ID).Msg("Failed to index news for search")
+			continue
+		}
+		metrics.ArticlesIndexed.Inc()
+		si.observeLatency(batch[i])
+	}
+}
+
+// observeLatency records how long the articles took from publication until
+// they became searchable
+func (si *SearchIndexer) observeLatency(items ...models.News) {
+	now := time.Now()
+
+	si.latencyMu.Lock()
+	defer si.latencyMu.Unlock()
+
+	for i := range items {
+		if items[i].PublishedAt.IsZero() {
+			continue
+		}
+		latency := now.Sub(items[i].PublishedAt)
+		metrics.IndexLatency.Observe(latency.Seconds())
+		si.latencies = append(si.latencies, latency)
+	}
+}
+
+// checkLatency logs a warning when the p95 of the index latencies observed
+// since the last check exceeds the configured threshold, which points to a
+// backlog somewhere in the pipeline
+func (si *SearchIndexer) checkLatency() {
+	si.latencyMu.Lock()
+	latencies := si.latencies
+	si.latencies = nil
+	si.latencyMu.Unlock()
+
+	if si.latencyThreshold <= 0 || len(latencies) == 0 {
+		return
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	p95 := latencies[(len(latencies)*95-1)/100]
+	if p95 > si.latencyThreshold {
+		si.logger.Warn().
+			Dur("p95", p95).
+			Dur("threshold", si.latencyThreshold).
+			Int("articles", len(latencies)).
+			Msg("Index latency above threshold")
+	}
+}
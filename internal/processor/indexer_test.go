@@ -0,0 +1,27 @@
+package processor
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/models"
+
+	"github.com/rs/zerolog"
+)
+
+func TestAddCapsDelayedArticles(t *testing.T) {
+	si := NewSearchIndexer(nil, nil, config.ProcessorConfig{IndexDelay: time.Hour}, zerolog.Nop())
+
+	for i := 0; i <= maxDelayedArticles; i++ {
+		si.Add(models.News{ID: strconv.Itoa(i), PublishedAt: time.Now()})
+	}
+
+	if n := len(si.delayed); n != maxDelayedArticles {
+		t.Errorf("delayed %d articles, want %d", n, maxDelayedArticles)
+	}
+	if len(si.pending) != 1 || si.pending[0].ID != strconv.Itoa(maxDelayedArticles) {
+		t.Errorf("pending = %v, want only the article past the cap", si.pending)
+	}
+}
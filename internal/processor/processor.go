@@ -3,13 +3,18 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
 	"news-aggregator/internal/services"
+	loggerPkg "news-aggregator/pkg/logger"
+	"news-aggregator/pkg/metrics"
 	"news-aggregator/pkg/queue"
 
 	"github.com/rs/zerolog"
@@ -22,15 +27,32 @@ type Processor struct {
 	publisher       queue.Publisher
 	newsService     *services.NewsService
 	searchService   *services.SearchService
+	indexer         *SearchIndexer
 	transformers    []Transformer
 	deduplicator    *Deduplicator
 	workerPool      *ProcessorWorkerPool
 	ctx             context.Context
 	cancel          context.CancelFunc
 	wg              sync.WaitGroup
+
+	// ingest sums article outcomes per source until the next flush, so the
+	// daily statistics are not written once per article
+	ingestMu sync.Mutex
+	ingest   map[string]models.SourceIngestCounts
 }
 
+// ingestFlushInterval is how often summed article outcomes are added to the
+// sources' daily ingestion statistics
+const ingestFlushInterval = 10 * time.Second
+
 func New(cfg *config.Config, logger zerolog.Logger) (*Processor, error) {
+	if cfg.Processor.Workers < 1 {
+		return nil, fmt.Errorf("processor.workers must be at least 1, got %d", cfg.Processor.Workers)
+	}
+	if cfg.Processor.QueueSize < 1 {
+		return nil, fmt.Errorf("processor.queue_size must be at least 1, got %d", cfg.Processor.QueueSize)
+	}
+
 	// Initialize message queue consumer
 	consumer, err := queue.NewRabbitMQConsumer(cfg.RabbitMQ.URL, cfg.RabbitMQ.Exchange, cfg.RabbitMQ.PrefetchCount)
 	if err != nil {
@@ -56,8 +78,9 @@ func New(cfg *config.Config, logger zerolog.Logger) (*Processor, error) {
 
 	// Initialize transformers
 	transformers := []Transformer{
-		NewContentCleanerTransformer(logger),
-		NewCategoryClassifierTransformer(logger),
+		NewPublishedDateTransformer(cfg.Processor.MaxFutureSkew, logger),
+		NewContentCleanerTransformer(cfg.Processor.MaxContentLength, logger),
+		NewCategoryClassifierTransformer(cfg.Processor.FallbackCategory, logger),
 		NewSentimentAnalyzerTransformer(logger),
 		NewImageExtractorTransformer(logger),
 	}
@@ -75,6 +98,7 @@ func New(cfg *config.Config, logger zerolog.Logger) (*Processor, error) {
 		publisher:     publisher,
 		newsService:   newsService,
 		searchService: searchService,
+		indexer:       NewSearchIndexer(searchService, newsService, cfg.Processor, logger),
 		transformers:  transformers,
 		deduplicator:  deduplicator,
 		workerPool:    workerPool,
@@ -89,6 +113,20 @@ func (p *Processor) Start(ctx context.Context) error {
 	// Start worker pool
 	p.workerPool.Start(p.ctx)
 
+	// Start batched search indexing
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.indexer.Run(p.ctx)
+	}()
+
+	// Write ingestion statistics periodically
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.runIngestFlush(p.ctx)
+	}()
+
 	// Start consuming messages
 	err := p.consumer.Consume("news.raw", p.handleMessage)
 	if err != nil {
@@ -111,13 +149,23 @@ func (p *Processor) Stop() {
 		p.cancel()
 	}
 
-	// Stop worker pool
-	p.workerPool.Stop()
+	// Draining the queue and the indexer is bounded by the shutdown timeout
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), p.config.Server.ShutdownDuration())
+	defer cancel()
 
-	// Close connections
+	// Stop taking messages, then let the workers finish the queued ones
 	if p.consumer != nil {
 		p.consumer.Close()
 	}
+	p.workerPool.Stop(shutdownCtx)
+
+	// Record the outcomes of the last jobs
+	p.flushIngest(shutdownCtx)
+
+	// Index articles processed by the last jobs, including delayed ones
+	p.indexer.drain(shutdownCtx)
+
+	// Close connections
 	if p.publisher != nil {
 		p.publisher.Close()
 	}
@@ -141,31 +189,39 @@ func (p *Processor) handleMessage(messageBody []byte) error {
 		Processor: p,
 	}
 
-	select {
-	case p.workerPool.jobQueue <- job:
-		p.logger.Debug().Str("message_id", message.ID).Msg("Job submitted to worker pool")
-		return nil
-	case <-p.ctx.Done():
+	if p.ctx.Err() != nil {
 		return fmt.Errorf("processor context cancelled")
-	default:
-		p.logger.Warn().Str("message_id", message.ID).Msg("Worker pool queue full, rejecting message")
-		return fmt.Errorf("worker pool queue full")
 	}
+
+	if err := p.workerPool.Submit(job); err != nil {
+		p.logger.Warn().Err(err).Str("message_id", message.ID).Msg("Rejecting message")
+		return err
+	}
+
+	p.logger.Debug().Str("message_id", message.ID).Msg("Job submitted to worker pool")
+	return nil
 }
 
 func (p *Processor) processNews(ctx context.Context, message models.NewsMessage) error {
 	startTime := time.Now()
-	p.logger.Info().Str("message_id", message.ID).Str("title", message.Data.Title).Msg("Processing news article")
+
+	// Tag every entry with the ID the collector assigned to this article
+	log := loggerPkg.WithCorrelationID(p.logger, message.CorrelationID)
+	log.Info().Str("message_id", message.ID).Str("title", message.Data.Title).Msg("Processing news article")
 
 	// Check for duplicates
-	isDuplicate, err := p.deduplicator.IsDuplicate(ctx, &message.Data)
+	isDuplicate, previous, err := p.deduplicator.Check(ctx, &message.Data)
 	if err != nil {
-		p.logger.Error().Err(err).Str("message_id", message.ID).Msg("Failed to check for duplicates")
+		metrics.ArticlesProcessed.WithLabelValues(metrics.ResultFailed).Inc()
+		p.recordIngest(message.Source, models.SourceIngestCounts{Failed: 1})
+		log.Error().Err(err).Str("message_id", message.ID).Msg("Failed to check for duplicates")
 		return fmt.Errorf("failed to check for duplicates: %w", err)
 	}
 
 	if isDuplicate {
-		p.logger.Info().Str("message_id", message.ID).Str("hash", message.Data.Hash).Msg("Duplicate article detected, skipping")
+		metrics.ArticlesProcessed.WithLabelValues(metrics.ResultDuplicate).Inc()
+		p.recordIngest(message.Source, models.SourceIngestCounts{Duplicate: 1})
+		log.Info().Str("message_id", message.ID).Str("hash", message.Data.Hash).Msg("Duplicate article detected, skipping")
 		return nil
 	}
 
@@ -174,28 +230,43 @@ func (p *Processor) processNews(ctx context.Context, message models.NewsMessage)
 	for _, transformer := range p.transformers {
 		transformedNews, err := transformer.Transform(ctx, &processedNews)
 		if err != nil {
-			p.logger.Error().Err(err).Str("transformer", fmt.Sprintf("%T", transformer)).Msg("Transformer failed")
+			log.Error().Err(err).Str("transformer", fmt.Sprintf("%T", transformer)).Msg("Transformer failed")
 			continue // Continue with other transformers
 		}
 		processedNews = *transformedNews
 	}
 
+	// The feed re-published a stored URL with edited content
+	if previous != nil {
+		return p.updateEdited(ctx, log, message, previous, processedNews)
+	}
+
 	// Save to database
 	if err := p.newsService.CreateNews(ctx, &processedNews); err != nil {
-		p.logger.Error().Err(err).Str("message_id", message.ID).Msg("Failed to save news to database")
+		// Another worker stored the same article first
+		if errors.Is(err, newsModels.ErrDuplicateNews) {
+			metrics.ArticlesProcessed.WithLabelValues(metrics.ResultDuplicate).Inc()
+			p.recordIngest(message.Source, models.SourceIngestCounts{Duplicate: 1})
+			log.Info().Str("message_id", message.ID).Msg("Article stored concurrently by another worker, skipping")
+			return nil
+		}
+
+		metrics.ArticlesProcessed.WithLabelValues(metrics.ResultFailed).Inc()
+		p.recordIngest(message.Source, models.SourceIngestCounts{Failed: 1})
+		log.Error().Err(err).Str("message_id", message.ID).Msg("Failed to save news to database")
 		return fmt.Errorf("failed to save news: %w", err)
 	}
+	metrics.RecordStored(processedNews.PublishedAt)
+	p.recordIngest(message.Source, models.SourceIngestCounts{New: 1})
 
-	// Index for search
-	if err := p.searchService.IndexNews(ctx, &processedNews); err != nil {
-		p.logger.Error().Err(err).Str("message_id", message.ID).Msg("Failed to index news for search")
-		// Don't return error as this is not critical
-	}
+	// Queue for batched search indexing; failures there are not critical
+	p.indexer.Add(processedNews)
 
 	// Publish processed message
 	processedMessage := models.NewsMessage{
-		ID:        message.ID,
-		Source:    message.Source,
+		ID:            message.ID,
+		CorrelationID: message.CorrelationID,
+		Source:        message.Source,
 		Type:      "processed",
 		Data:      processedNews,
 		Timestamp: time.Now(),
@@ -203,12 +274,12 @@ func (p *Processor) processNews(ctx context.Context, message models.NewsMessage)
 	}
 
 	if err := p.publisher.Publish("news.processed", processedMessage); err != nil {
-		p.logger.Error().Err(err).Str("message_id", message.ID).Msg("Failed to publish processed message")
+		log.Error().Err(err).Str("message_id", message.ID).Msg("Failed to publish processed message")
 		// Don't return error as the main processing is complete
 	}
 
 	duration := time.Since(startTime)
-	p.logger.Info().
+	log.Info().
 		Str("message_id", message.ID).
 		Str("title", processedNews.Title).
 		Dur("duration", duration).
@@ -217,12 +288,108 @@ func (p *Processor) processNews(ctx context.Context, message models.NewsMessage)
 	return nil
 }
 
+// updateEdited stores an edited version of an article under the ID of the
+// version already stored for its URL, keeping its original publication
+// time, and reindexes it. It is not announced again as a new article.
+func (p *Processor) updateEdited(ctx context.Context, log zerolog.Logger, message models.NewsMessage, previous *models.News, edited models.News) error {
+	edited.ID = previous.ID
+	edited.PublishedAt = previous.PublishedAt
+	edited.CreatedAt = previous.CreatedAt
+
+	if err := p.newsService.UpdateNews(ctx, &edited); err != nil {
+		// The edit matches the content of another stored article
+		if errors.Is(err, newsModels.ErrDuplicateNews) {
+			metrics.ArticlesProcessed.WithLabelValues(metrics.ResultDuplicate).Inc()
+			p.recordIngest(message.Source, models.SourceIngestCounts{Duplicate: 1})
+			log.Info().Str("message_id", message.ID).Str("id", previous.ID).Msg("Edited article duplicates another stored article, skipping")
+			return nil
+		}
+
+		metrics.ArticlesProcessed.WithLabelValues(metrics.ResultFailed).Inc()
+		p.recordIngest(message.Source, models.SourceIngestCounts{Failed: 1})
+		log.Error().Err(err).Str("message_id", message.ID).Str("id", previous.ID).Msg("Failed to update edited article")
+		return fmt.Errorf("failed to update edited news: %w", err)
+	}
+
+	// An edit is not a new article, so it counts as a duplicate in the
+	// source's ingest statistics
+	metrics.ArticlesProcessed.WithLabelValues(metrics.ResultUpdated).Inc()
+	p.recordIngest(message.Source, models.SourceIngestCounts{Duplicate: 1})
+	p.indexer.Add(edited)
+
+	log.Info().
+		Str("message_id", message.ID).
+		Str("id", edited.ID).
+		Str("title", edited.Title).
+		Msg("Edited news article updated")
+
+	return nil
+}
+
+// recordIngest adds an article outcome to the counts written to the
+// source's daily ingestion statistics on the next flush
+func (p *Processor) recordIngest(source string, counts models.SourceIngestCounts) {
+	if p.newsService == nil {
+		return
+	}
+
+	p.ingestMu.Lock()
+	defer p.ingestMu.Unlock()
+
+	if p.ingest == nil {
+		p.ingest = make(map[string]models.SourceIngestCounts)
+	}
+	total := p.ingest[source]
+	total.Fetched += counts.Fetched
+	total.New += counts.New
+	total.Duplicate += counts.Duplicate
+	total.Failed += counts.Failed
+	p.ingest[source] = total
+}
+
+// runIngestFlush flushes the ingestion counts every ingestFlushInterval
+// until the context is cancelled; Stop flushes what is left
+func (p *Processor) runIngestFlush(ctx context.Context) {
+	ticker := time.NewTicker(ingestFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.flushIngest(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flushIngest adds the counts recorded since the last flush to the daily
+// ingestion statistics; failures are logged and the counts dropped
+func (p *Processor) flushIngest(ctx context.Context) {
+	p.ingestMu.Lock()
+	counts := p.ingest
+	p.ingest = nil
+	p.ingestMu.Unlock()
+
+	for source, sourceCounts := range counts {
+		if err := p.newsService.AddIngestCounts(ctx, source, sourceCounts); err != nil {
+			p.logger.Warn().Err(err).Str("source", source).Msg("Failed to record ingest statistics")
+		}
+	}
+}
+
 // ProcessingJob represents a job for processing news
 type ProcessingJob struct {
 	Message   models.NewsMessage
 	Processor *Processor
 }
 
+// Errors returned by ProcessorWorkerPool.Submit
+var (
+	errWorkerPoolFull    = errors.New("worker pool queue full")
+	errWorkerPoolStopped = errors.New("worker pool stopped")
+)
+
 // ProcessorWorkerPool manages workers for processing news
 type ProcessorWorkerPool struct {
 	config   *config.Config
@@ -230,20 +397,36 @@ type ProcessorWorkerPool struct {
 	jobQueue chan *ProcessingJob
 	workers  []*ProcessorWorker
 	wg       sync.WaitGroup
+
+	// mu guards closing jobQueue against concurrent submits
+	mu     sync.RWMutex
+	closed bool
+
+	// cancelJobs cancels the jobs still running when draining the queue
+	// outlasts the shutdown timeout
+	cancelJobs context.CancelFunc
 }
 
 func NewProcessorWorkerPool(cfg *config.Config, logger zerolog.Logger) *ProcessorWorkerPool {
 	return &ProcessorWorkerPool{
 		config:   cfg,
 		logger:   logger,
-		jobQueue: make(chan *ProcessingJob, 1000), // Buffer for 1000 jobs
+		jobQueue: make(chan *ProcessingJob, cfg.Processor.QueueSize),
+		// Replaced by Start
+		cancelJobs: func() {},
 	}
 }
 
+// Start runs the workers. Jobs run detached from ctx's cancellation so the
+// queue can be drained during shutdown; Stop cancels them when draining
+// takes too long.
 func (pwp *ProcessorWorkerPool) Start(ctx context.Context) {
-	workerCount := 5 // TODO: Make this configurable
+	workerCount := pwp.config.Processor.Workers
 	pwp.workers = make([]*ProcessorWorker, workerCount)
 
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	pwp.cancelJobs = cancelJobs
+
 	for i := 0; i < workerCount; i++ {
 		worker := &ProcessorWorker{
 			id:       i,
@@ -256,16 +439,66 @@ func (pwp *ProcessorWorkerPool) Start(ctx context.Context) {
 
 		go func(w *ProcessorWorker) {
 			defer pwp.wg.Done()
-			w.start(ctx)
+			w.start(jobCtx)
 		}(worker)
 	}
 
-	pwp.logger.Info().Int("workers", workerCount).Msg("Processor worker pool started")
+	pwp.logger.Info().
+		Int("workers", workerCount).
+		Int("queue_size", cap(pwp.jobQueue)).
+		Msg("Processor worker pool started")
+}
+
+// Submit queues a job without blocking. It fails when the queue is full or
+// the pool is stopping.
+func (pwp *ProcessorWorkerPool) Submit(job *ProcessingJob) error {
+	pwp.mu.RLock()
+	defer pwp.mu.RUnlock()
+
+	if pwp.closed {
+		return errWorkerPoolStopped
+	}
+
+	select {
+	case pwp.jobQueue <- job:
+		return nil
+	default:
+		return errWorkerPoolFull
+	}
 }
 
-func (pwp *ProcessorWorkerPool) Stop() {
+// Stop closes the queue and waits for the workers to process the jobs
+// already in it. Once ctx is done, the running jobs are cancelled and the
+// remaining ones fail fast, which sends them to the retry queue.
+func (pwp *ProcessorWorkerPool) Stop(ctx context.Context) {
+	pwp.mu.Lock()
+	if pwp.closed {
+		pwp.mu.Unlock()
+		return
+	}
+	pwp.closed = true
 	close(pwp.jobQueue)
-	pwp.wg.Wait()
+	pwp.mu.Unlock()
+
+	pwp.logger.Info().Int("pending", len(pwp.jobQueue)).Msg("Draining processor worker pool")
+
+	drained := make(chan struct{})
+	go func() {
+		pwp.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		pwp.logger.Warn().
+			Int("pending", len(pwp.jobQueue)).
+			Msg("Timed out draining processor worker pool, cancelling jobs")
+		pwp.cancelJobs()
+		<-drained
+	}
+
+	pwp.cancelJobs()
 	pwp.logger.Info().Msg("Processor worker pool stopped")
 }
 
@@ -276,27 +509,22 @@ type ProcessorWorker struct {
 	jobQueue <-chan *ProcessingJob
 }
 
+// start processes jobs with ctx until the queue is closed
 func (pw *ProcessorWorker) start(ctx context.Context) {
 	pw.logger.Debug().Int("worker_id", pw.id).Msg("Processor worker started")
 
-	for {
-		select {
-		case job, ok := <-pw.jobQueue:
-			if !ok {
-				pw.logger.Debug().Int("worker_id", pw.id).Msg("Job queue closed, worker stopping")
-				return
-			}
-
-			pw.processJob(ctx, job)
-
-		case <-ctx.Done():
-			pw.logger.Debug().Int("worker_id", pw.id).Msg("Context cancelled, worker stopping")
-			return
-		}
+	for job := range pw.jobQueue {
+		pw.processJob(ctx, job)
 	}
+
+	pw.logger.Debug().Int("worker_id", pw.id).Msg("Job queue closed, worker stopping")
 }
 
 func (pw *ProcessorWorker) processJob(ctx context.Context, job *ProcessingJob) {
+	if job.Processor.config.Processor.RecoverPanics {
+		defer pw.recoverJob(job)
+	}
+
 	pw.logger.Debug().
 		Int("worker_id", pw.id).
 		Str("message_id", job.Message.ID).
@@ -322,12 +550,7 @@ func (pw *ProcessorWorker) processJob(ctx context.Context, job *ProcessingJob) {
 			}
 		} else {
 			// Max retries reached, send to failed queue
-			failedMessage := job.Message
-			failedMessage.Type = "failed"
-			
-			if err := job.Processor.publisher.Publish("news.failed", failedMessage); err != nil {
-				pw.logger.Error().Err(err).Str("message_id", job.Message.ID).Msg("Failed to publish failed message")
-			}
+			pw.deadLetter(job)
 		}
 		
 		return
@@ -338,3 +561,37 @@ func (pw *ProcessorWorker) processJob(ctx context.Context, job *ProcessingJob) {
 		Str("message_id", job.Message.ID).
 		Msg("Job processed successfully")
 }
+
+// recoverJob stops a panic raised while processing a job from taking the
+// worker down with it. The article is dead-lettered right away since
+// retrying it would most likely panic again.
+func (pw *ProcessorWorker) recoverJob(job *ProcessingJob) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	metrics.ProcessorPanics.Inc()
+	metrics.ArticlesProcessed.WithLabelValues(metrics.ResultFailed).Inc()
+	job.Processor.recordIngest(job.Message.Source, models.SourceIngestCounts{Failed: 1})
+	pw.logger.Error().
+		Int("worker_id", pw.id).
+		Str("message_id", job.Message.ID).
+		Str("article_id", job.Message.Data.ID).
+		Str("url", job.Message.Data.URL).
+		Interface("panic", r).
+		Bytes("stack", debug.Stack()).
+		Msg("Panic while processing news, dead-lettering article")
+
+	pw.deadLetter(job)
+}
+
+// deadLetter publishes the job's message to the failed queue
+func (pw *ProcessorWorker) deadLetter(job *ProcessingJob) {
+	failedMessage := job.Message
+	failedMessage.Type = "failed"
+
+	if err := job.Processor.publisher.Publish("news.failed", failedMessage); err != nil {
+		pw.logger.Error().Err(err).Str("message_id", job.Message.ID).Msg("Failed to publish failed message")
+	}
+}
@@ -0,0 +1,166 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
+	"news-aggregator/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+)
+
+// recordingPublisher keeps every published message by route
+type recordingPublisher struct {
+	mu       sync.Mutex
+	messages map[string][]models.NewsMessage
+}
+
+func (p *recordingPublisher) Publish(route string, message models.NewsMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.messages == nil {
+		p.messages = make(map[string][]models.NewsMessage)
+	}
+	p.messages[route] = append(p.messages[route], message)
+	return nil
+}
+
+func (p *recordingPublisher) Close() {}
+
+type panickingTransformer struct{}
+
+func (panickingTransformer) Transform(ctx context.Context, news *models.News) (*models.News, error) {
+	panic("transformer bug")
+}
+
+func (panickingTransformer) GetName() string { return "panicking" }
+
+// blockingTransformer holds up its job until the job's context is
+// cancelled, then panics so the job ends without being stored
+type blockingTransformer struct {
+	started   chan struct{}
+	cancelled chan error
+}
+
+func (b blockingTransformer) Transform(ctx context.Context, news *models.News) (*models.News, error) {
+	close(b.started)
+	<-ctx.Done()
+	b.cancelled <- ctx.Err()
+	panic("job cancelled")
+}
+
+func (blockingTransformer) GetName() string { return "blocking" }
+
+// emptyStore holds no articles, so no article is a duplicate
+type emptyStore struct{}
+
+func (emptyStore) CheckDuplicate(ctx context.Context, hash string) (bool, error) { return false, nil }
+
+func (emptyStore) GetNewsByURL(ctx context.Context, url string) (*models.News, error) {
+	return nil, newsModels.ErrNewsNotFound
+}
+
+// newTestProcessor returns a processor with a single worker and the given
+// transformers. It stores nothing: articles that get through the
+// transformers fail to be saved.
+func newTestProcessor(publisher *recordingPublisher, transformers ...Transformer) *Processor {
+	cfg := &config.Config{}
+	cfg.Processor.Workers = 1
+	cfg.Processor.QueueSize = 4
+	cfg.Processor.RecoverPanics = true
+
+	return &Processor{
+		config:       cfg,
+		logger:       zerolog.Nop(),
+		publisher:    publisher,
+		transformers: transformers,
+		deduplicator: &Deduplicator{newsService: emptyStore{}, logger: zerolog.Nop()},
+	}
+}
+
+func TestWorkerRecoversFromTransformerPanic(t *testing.T) {
+	publisher := &recordingPublisher{}
+	p := newTestProcessor(publisher, panickingTransformer{})
+
+	pool := NewProcessorWorkerPool(p.config, zerolog.Nop())
+	pool.Start(context.Background())
+
+	panicsBefore := testutil.ToFloat64(metrics.ProcessorPanics)
+
+	// The single worker must survive the first panic to handle the second
+	for _, id := range []string{"first", "second"} {
+		job := &ProcessingJob{
+			Message: models.NewsMessage{
+				ID:     id,
+				Source: "test",
+				Data:   models.News{ID: id, Title: "Panics " + id, URL: "https://example.com/" + id},
+			},
+			Processor: p,
+		}
+		if err := pool.Submit(job); err != nil {
+			t.Fatalf("Submit(%s): %v", id, err)
+		}
+	}
+	pool.Stop(context.Background())
+
+	failed := publisher.messages["news.failed"]
+	if len(failed) != 2 {
+		t.Fatalf("dead-lettered %d messages, want 2", len(failed))
+	}
+	for i, id := range []string{"first", "second"} {
+		if failed[i].ID != id || failed[i].Type != "failed" {
+			t.Errorf("dead letter %d = {ID: %q, Type: %q}, want {ID: %q, Type: \"failed\"}", i, failed[i].ID, failed[i].Type, id)
+		}
+	}
+	if retried := publisher.messages["news.retry"]; len(retried) != 0 {
+		t.Errorf("retried %d panicking messages, want 0", len(retried))
+	}
+	if panics := testutil.ToFloat64(metrics.ProcessorPanics) - panicsBefore; panics != 2 {
+		t.Errorf("panic metric increased by %v, want 2", panics)
+	}
+}
+
+func TestWorkerPoolStopCancelsJobsAfterTimeout(t *testing.T) {
+	publisher := &recordingPublisher{}
+	transformer := blockingTransformer{started: make(chan struct{}), cancelled: make(chan error, 1)}
+	p := newTestProcessor(publisher, transformer)
+
+	pool := NewProcessorWorkerPool(p.config, zerolog.Nop())
+	pool.Start(context.Background())
+
+	job := &ProcessingJob{
+		Message:   models.NewsMessage{ID: "slow", Data: models.News{Title: "Slow", URL: "https://example.com/slow"}},
+		Processor: p,
+	}
+	if err := pool.Submit(job); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-transformer.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		pool.Stop(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not return after the shutdown timeout")
+	}
+
+	if err := <-transformer.cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("job context error = %v, want context.Canceled", err)
+	}
+}
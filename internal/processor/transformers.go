@@ -5,8 +5,11 @@ import (
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
 
 	"github.com/rs/zerolog"
 )
@@ -17,18 +20,69 @@ type Transformer interface {
 	GetName() string
 }
 
+// PublishedDateTransformer repairs publication dates that would distort
+// recency ranking: dates more than maxFutureSkew ahead become the current
+// time, and missing dates become the time the article was fetched.
+type PublishedDateTransformer struct {
+	logger        zerolog.Logger
+	maxFutureSkew time.Duration
+}
+
+func NewPublishedDateTransformer(maxFutureSkew time.Duration, logger zerolog.Logger) *PublishedDateTransformer {
+	return &PublishedDateTransformer{
+		logger:        logger.With().Str("transformer", "published_date").Logger(),
+		maxFutureSkew: maxFutureSkew,
+	}
+}
+
+func (p *PublishedDateTransformer) GetName() string {
+	return "published_date"
+}
+
+func (p *PublishedDateTransformer) Transform(ctx context.Context, news *models.News) (*models.News, error) {
+	enhanced := *news
+	now := time.Now()
+
+	switch {
+	case enhanced.PublishedAt.IsZero():
+		// Collectors set CreatedAt when they fetch the article
+		enhanced.PublishedAt = enhanced.CreatedAt
+		if enhanced.PublishedAt.IsZero() {
+			enhanced.PublishedAt = now
+		}
+		p.logger.Info().
+			Str("source", enhanced.Source).
+			Str("url", enhanced.URL).
+			Time("published_at", enhanced.PublishedAt).
+			Msg("Article has no publication date, using fetch time")
+
+	case enhanced.PublishedAt.After(now.Add(p.maxFutureSkew)):
+		p.logger.Warn().
+			Str("source", enhanced.Source).
+			Str("url", enhanced.URL).
+			Time("published_at", enhanced.PublishedAt).
+			Dur("ahead", enhanced.PublishedAt.Sub(now)).
+			Msg("Publication date is in the future, clamping to now")
+		enhanced.PublishedAt = now
+	}
+
+	return &enhanced, nil
+}
+
 // ContentCleanerTransformer cleans and normalizes news content
 type ContentCleanerTransformer struct {
 	logger zerolog.Logger
-	htmlRegex *regexp.Regexp
 	urlRegex  *regexp.Regexp
+	maxContentLength int
 }
 
-func NewContentCleanerTransformer(logger zerolog.Logger) *ContentCleanerTransformer {
+// NewContentCleanerTransformer creates the content cleaner. Content longer
+// than maxContentLength characters is truncated (0 means unlimited).
+func NewContentCleanerTransformer(maxContentLength int, logger zerolog.Logger) *ContentCleanerTransformer {
 	return &ContentCleanerTransformer{
-		logger:    logger.With().Str("transformer", "content_cleaner").Logger(),
-		htmlRegex: regexp.MustCompile(`<[^>]*>`),
-		urlRegex:  regexp.MustCompile(`https?://[^\s]+`),
+		logger:           logger.With().Str("transformer", "content_cleaner").Logger(),
+		urlRegex:         regexp.MustCompile(`https?://[^\s]+`),
+		maxContentLength: maxContentLength,
 	}
 }
 
@@ -55,6 +109,19 @@ func (c *ContentCleanerTransformer) Transform(ctx context.Context, news *models.
 		cleaned.Summary = c.generateSummary(cleaned.Content)
 	}
 
+	// Truncate oversized content after the summary was taken from it
+	if c.maxContentLength > 0 {
+		if truncated, ok := truncateContent(cleaned.Content, c.maxContentLength); ok {
+			c.logger.Warn().
+				Str("source", cleaned.Source).
+				Str("url", cleaned.URL).
+				Int("length", utf8.RuneCountInString(cleaned.Content)).
+				Int("max_length", c.maxContentLength).
+				Msg("Content truncated")
+			cleaned.Content = truncated
+		}
+	}
+
 	// Normalize author
 	cleaned.Author = strings.TrimSpace(cleaned.Author)
 	if cleaned.Author == "" {
@@ -66,44 +133,10 @@ func (c *ContentCleanerTransformer) Transform(ctx context.Context, news *models.
 	return &cleaned, nil
 }
 
+// cleanText strips HTML and normalizes whitespace the same way content
+// hashes do, so a stored article hashes like the article as parsed
 func (c *ContentCleanerTransformer) cleanText(text string) string {
-	// Remove HTML tags
-	text = c.htmlRegex.ReplaceAllString(text, "")
-	
-	// Decode HTML entities
-	text = c.decodeHTMLEntities(text)
-	
-	// Normalize whitespace
-	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
-	
-	// Trim whitespace
-	text = strings.TrimSpace(text)
-	
-	return text
-}
-
-func (c *ContentCleanerTransformer) decodeHTMLEntities(text string) string {
-	entities := map[string]string{
-		"&amp;":    "&",
-		"&lt;":     "<",
-		"&gt;":     ">",
-		"&quot;":   "\"",
-		"&apos;":   "'",
-		"&nbsp;":   " ",
-		"&hellip;": "...",
-		"&mdash;":  "—",
-		"&ndash;":  "–",
-		"&rsquo;":  "'",
-		"&lsquo;":  "'",
-		"&rdquo;":  "\"",
-		"&ldquo;":  "\"",
-	}
-
-	for entity, replacement := range entities {
-		text = strings.ReplaceAll(text, entity, replacement)
-	}
-
-	return text
+	return newsModels.CleanText(text)
 }
 
 func (c *ContentCleanerTransformer) generateSummary(content string) string {
@@ -149,13 +182,62 @@ func (c *ContentCleanerTransformer) generateSummary(content string) string {
 	return summary + "..."
 }
 
+// truncateContent shortens content to at most maxLength characters, cutting
+// at the last sentence end that fits and appending an ellipsis. Content
+// without a usable sentence end is cut at a word boundary instead. It
+// reports whether the content was truncated.
+func truncateContent(content string, maxLength int) (string, bool) {
+	runes := []rune(content)
+	if len(runes) <= maxLength {
+		return content, false
+	}
+
+	// Leave room for the ellipsis
+	limit := maxLength - 1
+	if limit < 1 {
+		return "…", true
+	}
+	cut := runes[:limit]
+
+	// Only use a sentence end that keeps at least half of the allowed text
+	for i := len(cut) - 1; i >= limit/2; i-- {
+		if cut[i] == '.' || cut[i] == '!' || cut[i] == '?' {
+			return string(cut[:i+1]) + "…", true
+		}
+	}
+
+	if space := strings.LastIndexFunc(string(cut), unicode.IsSpace); space > 0 {
+		return strings.TrimSpace(string(cut)[:space]) + "…", true
+	}
+	return string(cut) + "…", true
+}
+
+// Special fallback categories for articles the classifier can't match.
+const (
+	// FallbackKeepSource keeps whatever category the feed provided
+	FallbackKeepSource = "source"
+	// FallbackNone leaves the category empty for manual triage
+	FallbackNone = "none"
+
+	defaultFallbackCategory = "general"
+)
+
 // CategoryClassifierTransformer classifies news into categories
 type CategoryClassifierTransformer struct {
 	logger zerolog.Logger
 	categoryKeywords map[string][]string
+	fallback         string
 }
 
-func NewCategoryClassifierTransformer(logger zerolog.Logger) *CategoryClassifierTransformer {
+// NewCategoryClassifierTransformer creates the classifier. fallback is the
+// category used when no keywords match (see FallbackKeepSource and
+// FallbackNone); empty means "general".
+func NewCategoryClassifierTransformer(fallback string, logger zerolog.Logger) *CategoryClassifierTransformer {
+	fallback = strings.ToLower(strings.TrimSpace(fallback))
+	if fallback == "" {
+		fallback = defaultFallbackCategory
+	}
+
 	categoryKeywords := map[string][]string{
 		"technology": {
 			"tech", "software", "ai", "artificial intelligence", "machine learning",
@@ -202,6 +284,7 @@ func NewCategoryClassifierTransformer(logger zerolog.Logger) *CategoryClassifier
 	return &CategoryClassifierTransformer{
 		logger:           logger.With().Str("transformer", "category_classifier").Logger(),
 		categoryKeywords: categoryKeywords,
+		fallback:         fallback,
 	}
 }
 
@@ -214,6 +297,11 @@ func (c *CategoryClassifierTransformer) Transform(ctx context.Context, news *mod
 
 	classified := *news
 
+	// Preserve the feed's own category before it can be overwritten
+	if classified.SourceCategory == "" {
+		classified.SourceCategory = news.Category
+	}
+
 	// If category is already set and not "general", keep it
 	if classified.Category != "" && classified.Category != "general" {
 		return &classified, nil
@@ -223,7 +311,7 @@ func (c *CategoryClassifierTransformer) Transform(ctx context.Context, news *mod
 	text := strings.ToLower(classified.Title + " " + classified.Content)
 
 	// Find best matching category
-	bestCategory := "general"
+	bestCategory := ""
 	maxScore := 0
 
 	for category, keywords := range c.categoryKeywords {
@@ -240,6 +328,10 @@ func (c *CategoryClassifierTransformer) Transform(ctx context.Context, news *mod
 		}
 	}
 
+	if maxScore == 0 {
+		bestCategory = c.fallbackCategory(news.Category)
+	}
+
 	classified.Category = bestCategory
 	classified.UpdatedAt = time.Now()
 
@@ -252,6 +344,19 @@ func (c *CategoryClassifierTransformer) Transform(ctx context.Context, news *mod
 	return &classified, nil
 }
 
+// fallbackCategory returns the category for an article no keywords matched,
+// given the category the feed provided.
+func (c *CategoryClassifierTransformer) fallbackCategory(sourceCategory string) string {
+	switch c.fallback {
+	case FallbackKeepSource:
+		return sourceCategory
+	case FallbackNone:
+		return ""
+	default:
+		return c.fallback
+	}
+}
+
 // SentimentAnalyzerTransformer analyzes sentiment and adds tags
 type SentimentAnalyzerTransformer struct {
 	logger zerolog.Logger
@@ -0,0 +1,90 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"news-aggregator/internal/models"
+
+	"github.com/rs/zerolog"
+)
+
+func TestCategoryClassifierFallbackModes(t *testing.T) {
+	tests := []struct {
+		name           string
+		fallback       string
+		sourceCategory string
+		want           string
+	}{
+		{name: "default", fallback: "", sourceCategory: "", want: "general"},
+		{name: "custom category", fallback: "Uncategorized", sourceCategory: "", want: "uncategorized"},
+		{name: "keep source", fallback: FallbackKeepSource, sourceCategory: "general", want: "general"},
+		{name: "keep empty source", fallback: FallbackKeepSource, sourceCategory: "", want: ""},
+		{name: "none", fallback: FallbackNone, sourceCategory: "general", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classifier := NewCategoryClassifierTransformer(tt.fallback, zerolog.Nop())
+
+			// Nothing in the text matches any category keyword
+			article := &models.News{
+				Title:    "Quarterly gardening newsletter",
+				Content:  "Tulips, roses and daffodils",
+				Category: tt.sourceCategory,
+			}
+
+			got, err := classifier.Transform(context.Background(), article)
+			if err != nil {
+				t.Fatalf("Transform returned error: %v", err)
+			}
+			if got.Category != tt.want {
+				t.Errorf("category = %q, want %q", got.Category, tt.want)
+			}
+			if got.SourceCategory != tt.sourceCategory {
+				t.Errorf("source category = %q, want %q", got.SourceCategory, tt.sourceCategory)
+			}
+		})
+	}
+}
+
+func TestCategoryClassifierFallbackOnlyWhenUnmatched(t *testing.T) {
+	for _, fallback := range []string{"", "uncategorized", FallbackKeepSource, FallbackNone} {
+		classifier := NewCategoryClassifierTransformer(fallback, zerolog.Nop())
+
+		article := &models.News{
+			Title:    "New smartphone software update released",
+			Content:  "The technology company shipped the update to every device",
+			Category: "general",
+		}
+
+		got, err := classifier.Transform(context.Background(), article)
+		if err != nil {
+			t.Fatalf("fallback %q: Transform returned error: %v", fallback, err)
+		}
+		if got.Category != "technology" {
+			t.Errorf("fallback %q: category = %q, want technology", fallback, got.Category)
+		}
+	}
+}
+
+func TestContentHashMatchesCleanedArticle(t *testing.T) {
+	raw := &models.News{
+		Title:   "  Markets <b>rally</b> &amp; recover ",
+		Content: "<p>Stocks rose&nbsp;sharply\n\non Monday&hellip;</p>",
+		URL:     "https://example.com/markets",
+	}
+
+	cleaned, err := NewContentCleanerTransformer(0, zerolog.Nop()).Transform(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Transform returned error: %v", err)
+	}
+	if cleaned.Content == raw.Content {
+		t.Fatal("fixture content was not changed by cleaning")
+	}
+
+	// The processor hashes the parsed article, the backfill the stored one
+	if got, want := cleaned.ContentHash(), raw.ContentHash(); got != want {
+		t.Errorf("stored article hash %s, parsed article hash %s", got, want)
+	}
+}
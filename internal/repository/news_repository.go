@@ -3,56 +3,52 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"news-aggregator/internal/config"
+	"news-aggregator/internal/db"
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
+	sourceModels "news-aggregator/internal/models/source"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
+// uniqueViolationCode is the PostgreSQL error code for unique_violation
+const uniqueViolationCode = "23505"
+
+// undefinedTableCode is the PostgreSQL error code for undefined_table
+const undefinedTableCode = "42P01"
+
 type NewsRepository struct {
 	db     *pgxpool.Pool
 	logger zerolog.Logger
 }
 
+// NewNewsRepository creates a news repository with its own connection pool.
+// Prefer NewNewsRepositoryWithPool when other repositories share the database.
 func NewNewsRepository(cfg *config.Config, logger zerolog.Logger) (*NewsRepository, error) {
-	// Build connection string
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Database,
-		cfg.Database.SSLMode,
-	)
-
-	// Create connection pool
-	poolConfig, err := pgxpool.ParseConfig(connStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse database config: %w", err)
-	}
-
-	poolConfig.MaxConns = int32(cfg.Database.MaxConns)
-	poolConfig.MinConns = int32(cfg.Database.MaxIdleConns)
-	poolConfig.MaxConnLifetime = time.Duration(cfg.Database.MaxLifetime) * time.Second
-
-	db, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	pool, err := db.NewPool(cfg, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create database pool: %w", err)
+		return nil, err
 	}
 
-	// Test connection
-	if err := db.Ping(context.Background()); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
+	return NewNewsRepositoryWithPool(pool, logger)
+}
 
+// NewNewsRepositoryWithPool creates a news repository on an existing pool
+// and initializes its schema.
+func NewNewsRepositoryWithPool(pool *pgxpool.Pool, logger zerolog.Logger) (*NewsRepository, error) {
 	repo := &NewsRepository{
-		db:     db,
+		db:     pool,
 		logger: logger.With().Str("component", "news_repository").Logger(),
 	}
 
@@ -86,6 +82,7 @@ func (r *NewsRepository) initSchema(ctx context.Context) error {
 			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 			content_hash TEXT UNIQUE
 		)`,
+		`ALTER TABLE news ADD COLUMN IF NOT EXISTS source_category TEXT`,
 		`CREATE TABLE IF NOT EXISTS categories (
 			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
 			name TEXT UNIQUE NOT NULL,
@@ -106,6 +103,24 @@ func (r *NewsRepository) initSchema(ctx context.Context) error {
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 		)`,
+		`ALTER TABLE sources ADD COLUMN IF NOT EXISTS last_error TEXT`,
+		`ALTER TABLE sources ADD COLUMN IF NOT EXISTS last_success TIMESTAMP WITH TIME ZONE`,
+		`ALTER TABLE sources ADD COLUMN IF NOT EXISTS consecutive_failures INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE sources ADD COLUMN IF NOT EXISTS last_error_category TEXT`,
+		`CREATE TABLE IF NOT EXISTS source_backfills (
+			source TEXT PRIMARY KEY,
+			position TEXT NOT NULL,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS source_ingest_stats (
+			source TEXT NOT NULL,
+			day DATE NOT NULL,
+			fetched BIGINT NOT NULL DEFAULT 0,
+			new BIGINT NOT NULL DEFAULT 0,
+			duplicate BIGINT NOT NULL DEFAULT 0,
+			failed BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (source, day)
+		)`,
 		`CREATE INDEX IF NOT EXISTS idx_news_published_at ON news(published_at DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_news_source ON news(source)`,
 		`CREATE INDEX IF NOT EXISTS idx_news_category ON news(category)`,
@@ -165,13 +180,21 @@ func (r *NewsRepository) GetNews(ctx context.Context, filter models.NewsFilter)
 	var args []interface{}
 	argIndex := 1
 
-	if filter.Category != "" {
+	if len(filter.Categories) > 0 {
+		conditions = append(conditions, fmt.Sprintf("category = ANY($%d)", argIndex))
+		args = append(args, filter.Categories)
+		argIndex++
+	} else if filter.Category != "" {
 		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
 		args = append(args, filter.Category)
 		argIndex++
 	}
 
-	if filter.Source != "" {
+	if len(filter.Sources) > 0 {
+		conditions = append(conditions, fmt.Sprintf("source = ANY($%d)", argIndex))
+		args = append(args, filter.Sources)
+		argIndex++
+	} else if filter.Source != "" {
 		conditions = append(conditions, fmt.Sprintf("source = $%d", argIndex))
 		args = append(args, filter.Source)
 		argIndex++
@@ -223,7 +246,7 @@ func (r *NewsRepository) GetNews(ctx context.Context, filter models.NewsFilter)
 	offset := (page - 1) * limit
 	query := fmt.Sprintf(`
 		SELECT id, title, content, summary, url, image_url, author, source, 
-			   category, tags, published_at, created_at, updated_at
+			   category, COALESCE(source_category, ''), tags, published_at, created_at, updated_at
 		FROM news %s
 		ORDER BY published_at DESC
 		LIMIT $%d OFFSET $%d
@@ -244,7 +267,7 @@ func (r *NewsRepository) GetNews(ctx context.Context, filter models.NewsFilter)
 
 		err := rows.Scan(
 			&n.ID, &n.Title, &n.Content, &n.Summary, &n.URL, &n.ImageURL,
-			&n.Author, &n.Source, &n.Category, &tagsJSON, &n.PublishedAt,
+			&n.Author, &n.Source, &n.Category, &n.SourceCategory, &tagsJSON, &n.PublishedAt,
 			&n.CreatedAt, &n.UpdatedAt,
 		)
 		if err != nil {
@@ -272,9 +295,13 @@ func (r *NewsRepository) GetNews(ctx context.Context, filter models.NewsFilter)
 func (r *NewsRepository) GetNewsByID(ctx context.Context, id string) (*models.News, error) {
 	r.logger.Debug().Str("id", id).Msg("Getting news by ID")
 
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, newsModels.ErrNewsNotFound
+	}
+
 	query := `
 		SELECT id, title, content, summary, url, image_url, author, source, 
-			   category, tags, published_at, created_at, updated_at, content_hash
+			   category, COALESCE(source_category, ''), tags, published_at, created_at, updated_at, content_hash
 		FROM news WHERE id = $1
 	`
 
@@ -283,13 +310,13 @@ func (r *NewsRepository) GetNewsByID(ctx context.Context, id string) (*models.Ne
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&n.ID, &n.Title, &n.Content, &n.Summary, &n.URL, &n.ImageURL,
-		&n.Author, &n.Source, &n.Category, &tagsJSON, &n.PublishedAt,
+		&n.Author, &n.Source, &n.Category, &n.SourceCategory, &tagsJSON, &n.PublishedAt,
 		&n.CreatedAt, &n.UpdatedAt, &n.Hash,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("news not found")
+			return nil, newsModels.ErrNewsNotFound
 		}
 		return nil, fmt.Errorf("failed to get news by ID: %w", err)
 	}
@@ -305,6 +332,211 @@ func (r *NewsRepository) GetNewsByID(ctx context.Context, id string) (*models.Ne
 	return &n, nil
 }
 
+// GetPopularNews returns the articles published since `since`, ranked by
+// their weighted views, clicks and shares, then by article score and
+// recency. Articles without engagement or score rows rank by recency alone.
+func (r *NewsRepository) GetPopularNews(ctx context.Context, since time.Time, weights config.PopularConfig, page, limit int) ([]models.News, int, error) {
+	r.logger.Debug().Time("since", since).Int("page", page).Int("limit", limit).Msg("Getting popular news")
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM news WHERE published_at >= $1`, since).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to get popular news count: %w", err)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	query := `
+		SELECT n.id, n.title, n.content, n.summary, n.url, n.image_url, n.author, n.source,
+			   n.category, COALESCE(n.source_category, ''), n.tags, n.published_at, n.created_at, n.updated_at
+		FROM news n
+		LEFT JOIN engagement_metrics e ON e.article_id = n.id
+		LEFT JOIN article_scores s ON s.article_id = n.id
+		WHERE n.published_at >= $1
+		ORDER BY COALESCE(e.view_count, 0) * $2::float8
+			   + COALESCE(e.click_count, 0) * $3::float8
+			   + COALESCE(e.share_count, 0) * $4::float8 DESC,
+			   COALESCE(s.final_score, 0) DESC,
+			   n.published_at DESC
+		LIMIT $5 OFFSET $6
+	`
+
+	rows, err := r.db.Query(ctx, query, since,
+		weights.ViewWeight, weights.ClickWeight, weights.ShareWeight, limit, (page-1)*limit)
+	if err != nil {
+		// The engagement tables are created by the scoring repository; without
+		// them there is nothing to rank by but recency
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == undefinedTableCode {
+			r.logger.Debug().Msg("Engagement tables missing; ranking popular news by recency")
+			return r.GetNews(ctx, models.NewsFilter{Page: page, Limit: limit, DateFrom: since})
+		}
+		return nil, 0, fmt.Errorf("failed to query popular news: %w", err)
+	}
+	defer rows.Close()
+
+	news := []models.News{}
+	for rows.Next() {
+		var n models.News
+		var tagsJSON []byte
+
+		err := rows.Scan(
+			&n.ID, &n.Title, &n.Content, &n.Summary, &n.URL, &n.ImageURL,
+			&n.Author, &n.Source, &n.Category, &n.SourceCategory, &tagsJSON, &n.PublishedAt,
+			&n.CreatedAt, &n.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan popular news row: %w", err)
+		}
+
+		if len(tagsJSON) > 0 {
+			if err := json.Unmarshal(tagsJSON, &n.Tags); err != nil {
+				r.logger.Warn().Err(err).Str("id", n.ID).Msg("Failed to unmarshal tags")
+				n.Tags = []string{}
+			}
+		}
+
+		news = append(news, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate popular news: %w", err)
+	}
+
+	return news, total, nil
+}
+
+// GetNewsByURL returns the article stored under url, or ErrNewsNotFound.
+func (r *NewsRepository) GetNewsByURL(ctx context.Context, url string) (*models.News, error) {
+	r.logger.Debug().Str("url", url).Msg("Getting news by URL")
+
+	query := `
+		SELECT id, title, content, summary, url, image_url, author, source,
+			   category, COALESCE(source_category, ''), tags, published_at, created_at, updated_at, content_hash
+		FROM news WHERE url = $1
+	`
+
+	var n models.News
+	var tagsJSON []byte
+
+	err := r.db.QueryRow(ctx, query, url).Scan(
+		&n.ID, &n.Title, &n.Content, &n.Summary, &n.URL, &n.ImageURL,
+		&n.Author, &n.Source, &n.Category, &n.SourceCategory, &tagsJSON, &n.PublishedAt,
+		&n.CreatedAt, &n.UpdatedAt, &n.Hash,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, newsModels.ErrNewsNotFound
+		}
+		return nil, fmt.Errorf("failed to get news by URL: %w", err)
+	}
+
+	if len(tagsJSON) > 0 {
+		if err := json.Unmarshal(tagsJSON, &n.Tags); err != nil {
+			r.logger.Warn().Err(err).Str("id", n.ID).Msg("Failed to unmarshal tags")
+			n.Tags = []string{}
+		}
+	}
+
+	return &n, nil
+}
+
+// GetNewsByIDs returns the articles with the given IDs in the order they
+// were requested. IDs that are malformed or match no article are left out.
+func (r *NewsRepository) GetNewsByIDs(ctx context.Context, ids []string) ([]models.News, error) {
+	r.logger.Debug().Int("count", len(ids)).Msg("Getting news by IDs")
+
+	news := []models.News{}
+
+	seen := make(map[string]bool, len(ids))
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		valid = append(valid, id)
+	}
+	if len(valid) == 0 {
+		return news, nil
+	}
+
+	query := `
+		SELECT n.id, n.title, n.content, n.summary, n.url, n.image_url, n.author, n.source,
+			   n.category, COALESCE(n.source_category, ''), n.tags, n.published_at, n.created_at, n.updated_at
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS requested(id, position)
+		JOIN news n ON n.id = requested.id
+		ORDER BY requested.position
+	`
+
+	rows, err := r.db.Query(ctx, query, valid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get news by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n models.News
+		var tagsJSON []byte
+
+		err := rows.Scan(
+			&n.ID, &n.Title, &n.Content, &n.Summary, &n.URL, &n.ImageURL,
+			&n.Author, &n.Source, &n.Category, &n.SourceCategory, &tagsJSON, &n.PublishedAt,
+			&n.CreatedAt, &n.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan news row: %w", err)
+		}
+
+		if len(tagsJSON) > 0 {
+			if err := json.Unmarshal(tagsJSON, &n.Tags); err != nil {
+				r.logger.Warn().Err(err).Str("id", n.ID).Msg("Failed to unmarshal tags")
+				n.Tags = []string{}
+			}
+		}
+
+		news = append(news, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating news rows: %w", err)
+	}
+
+	return news, nil
+}
+
+// GetIDsPublishedSince returns the IDs of the articles published at or
+// after since, newest first
+func (r *NewsRepository) GetIDsPublishedSince(ctx context.Context, since time.Time) ([]string, error) {
+	r.logger.Debug().Time("since", since).Msg("Getting IDs of recent news")
+
+	rows, err := r.db.Query(ctx, `SELECT id FROM news WHERE published_at >= $1 ORDER BY published_at DESC`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent news IDs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan news ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating news IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
 func (r *NewsRepository) CreateNews(ctx context.Context, news *models.News) error {
 	r.logger.Debug().Str("title", news.Title).Msg("Creating news")
 
@@ -316,18 +548,24 @@ func (r *NewsRepository) CreateNews(ctx context.Context, news *models.News) erro
 
 	query := `
 		INSERT INTO news (title, content, summary, url, image_url, author, source, 
-						 category, tags, published_at, content_hash)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+						 category, source_category, tags, published_at, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12)
 		RETURNING id, created_at, updated_at
 	`
 
 	err = r.db.QueryRow(ctx, query,
 		news.Title, news.Content, news.Summary, news.URL, news.ImageURL,
-		news.Author, news.Source, news.Category, tagsJSON, news.PublishedAt,
+		news.Author, news.Source, news.Category, news.SourceCategory, tagsJSON, news.PublishedAt,
 		news.Hash,
 	).Scan(&news.ID, &news.CreatedAt, &news.UpdatedAt)
 
 	if err != nil {
+		// Concurrent workers can insert the same article; whoever loses the
+		// race on the url or content_hash constraint has a duplicate
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("%w (%s)", newsModels.ErrDuplicateNews, pgErr.ConstraintName)
+		}
 		return fmt.Errorf("failed to create news: %w", err)
 	}
 
@@ -343,22 +581,29 @@ func (r *NewsRepository) UpdateNews(ctx context.Context, news *models.News) erro
 		return fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
+	// The content hash only changes when the caller computed a new one,
+	// e.g. for an edited article re-published by its feed
 	query := `
 		UPDATE news SET 
 			title = $2, content = $3, summary = $4, url = $5, image_url = $6,
-			author = $7, category = $8, tags = $9, updated_at = NOW()
+			author = $7, category = $8, tags = $9,
+			content_hash = COALESCE(NULLIF($10, ''), content_hash), updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
 
 	err = r.db.QueryRow(ctx, query,
 		news.ID, news.Title, news.Content, news.Summary, news.URL,
-		news.ImageURL, news.Author, news.Category, tagsJSON,
+		news.ImageURL, news.Author, news.Category, tagsJSON, news.Hash,
 	).Scan(&news.UpdatedAt)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return fmt.Errorf("news not found")
+			return newsModels.ErrNewsNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("%w (%s)", newsModels.ErrDuplicateNews, pgErr.ConstraintName)
 		}
 		return fmt.Errorf("failed to update news: %w", err)
 	}
@@ -366,9 +611,75 @@ func (r *NewsRepository) UpdateNews(ctx context.Context, news *models.News) erro
 	return nil
 }
 
+// PatchNews updates only the given fields of an article, as normalized by
+// news.NormalizePatch, and bumps updated_at. Field names double as column
+// names and are never taken from fields unchecked.
+func (r *NewsRepository) PatchNews(ctx context.Context, id string, fields map[string]interface{}) error {
+	r.logger.Debug().Str("id", id).Int("fields", len(fields)).Msg("Patching news")
+
+	if _, err := uuid.Parse(id); err != nil {
+		return newsModels.ErrNewsNotFound
+	}
+
+	fields, err := newsModels.NormalizePatch(fields)
+	if err != nil {
+		return err
+	}
+
+	// Sorted for a stable statement
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sets := make([]string, 0, len(names)+1)
+	args := []interface{}{id}
+	for _, name := range names {
+		value := fields[name]
+		switch name {
+		case "tags":
+			tagsJSON, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to marshal tags: %w", err)
+			}
+			value = tagsJSON
+		case "source_category":
+			// Stored as NULL when the feed provided none
+			if value == "" {
+				value = nil
+			}
+		}
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", name, len(args)))
+	}
+	sets = append(sets, "updated_at = NOW()")
+
+	query := fmt.Sprintf(`UPDATE news SET %s WHERE id = $1`, strings.Join(sets, ", "))
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("%w (%s)", newsModels.ErrDuplicateNews, pgErr.ConstraintName)
+		}
+		return fmt.Errorf("failed to patch news: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return newsModels.ErrNewsNotFound
+	}
+
+	return nil
+}
+
 func (r *NewsRepository) DeleteNews(ctx context.Context, id string) error {
 	r.logger.Debug().Str("id", id).Msg("Deleting news")
 
+	if _, err := uuid.Parse(id); err != nil {
+		return newsModels.ErrNewsNotFound
+	}
+
 	query := `DELETE FROM news WHERE id = $1`
 
 	result, err := r.db.Exec(ctx, query, id)
@@ -377,7 +688,7 @@ func (r *NewsRepository) DeleteNews(ctx context.Context, id string) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("news not found")
+		return newsModels.ErrNewsNotFound
 	}
 
 	return nil
@@ -397,6 +708,36 @@ func (r *NewsRepository) CheckDuplicate(ctx context.Context, hash string) (bool,
 	return exists, nil
 }
 
+// GetExistingURLs returns the subset of the given URLs already stored
+func (r *NewsRepository) GetExistingURLs(ctx context.Context, urls []string) ([]string, error) {
+	r.logger.Debug().Int("count", len(urls)).Msg("Checking existing URLs")
+
+	existing := []string{}
+	if len(urls) == 0 {
+		return existing, nil
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT url FROM news WHERE url = ANY($1)`, urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing urls: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("failed to scan url: %w", err)
+		}
+		existing = append(existing, url)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate urls: %w", err)
+	}
+
+	return existing, nil
+}
+
 func (r *NewsRepository) GetCategories(ctx context.Context) ([]models.Category, error) {
 	r.logger.Debug().Msg("Getting categories")
 
@@ -425,127 +766,526 @@ func (r *NewsRepository) GetCategories(ctx context.Context) ([]models.Category,
 	return categories, nil
 }
 
-func (r *NewsRepository) GetStats(ctx context.Context) (*models.Stats, error) {
-	r.logger.Debug().Msg("Getting stats")
+// GetTags returns the most used tags of articles published in the last
+// seven days, the window the news feeds cover, with their article counts.
+// Articles without tags are skipped.
+func (r *NewsRepository) GetTags(ctx context.Context, limit int) ([]models.TagCount, error) {
+	r.logger.Debug().Int("limit", limit).Msg("Getting tags")
 
-	stats := &models.Stats{}
+	query := `
+		SELECT tag, COUNT(*) AS count
+		FROM news, jsonb_array_elements_text(news.tags) AS tag
+		WHERE news.published_at >= NOW() - interval '7 days'
+			AND jsonb_typeof(news.tags) = 'array'
+			AND tag <> ''
+		GROUP BY tag
+		ORDER BY count DESC, tag
+		LIMIT $1
+	`
 
-	// Get total articles
-	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM news").Scan(&stats.TotalArticles)
+	rows, err := r.db.Query(ctx, query, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get total articles: %w", err)
+		return nil, fmt.Errorf("failed to query tags: %w", err)
 	}
+	defer rows.Close()
 
-	// Get articles today
-	err = r.db.QueryRow(ctx, `
-		SELECT COUNT(*) FROM news 
-		WHERE published_at >= CURRENT_DATE
-	`).Scan(&stats.ArticlesToday)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get articles today: %w", err)
+	tags := []models.TagCount{}
+	for rows.Next() {
+		var t models.TagCount
+		if err := rows.Scan(&t.Tag, &t.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %w", err)
+		}
+		tags = append(tags, t)
 	}
 
-	// Get articles this week
-	err = r.db.QueryRow(ctx, `
-		SELECT COUNT(*) FROM news 
-		WHERE published_at >= DATE_TRUNC('week', CURRENT_DATE)
-	`).Scan(&stats.ArticlesThisWeek)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get articles this week: %w", err)
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating tag rows: %w", rows.Err())
 	}
 
-	// Get articles this month
-	err = r.db.QueryRow(ctx, `
-		SELECT COUNT(*) FROM news 
-		WHERE published_at >= DATE_TRUNC('month', CURRENT_DATE)
-	`).Scan(&stats.ArticlesThisMonth)
+	return tags, nil
+}
+
+func (r *NewsRepository) CreateCategory(ctx context.Context, category *models.Category) error {
+	r.logger.Debug().Str("name", category.Name).Msg("Creating category")
+
+	query := `
+		INSERT INTO categories (name, description, color, icon)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		category.Name, category.Description, category.Color, category.Icon,
+	).Scan(&category.ID)
+
 	if err != nil {
-		return nil, fmt.Errorf("failed to get articles this month: %w", err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return newsModels.ErrCategoryExists
+		}
+		return fmt.Errorf("failed to create category: %w", err)
 	}
 
-	// Get top categories
-	rows, err := r.db.Query(ctx, `
-		SELECT category, COUNT(*) as count 
-		FROM news 
-		GROUP BY category 
-		ORDER BY count DESC 
-		LIMIT 10
-	`)
+	return nil
+}
+
+// UpdateCategory updates a category. When the name changes, articles filed
+// under the old name are moved to the new one in the same transaction and
+// returned so callers can re-index them.
+func (r *NewsRepository) UpdateCategory(ctx context.Context, category *models.Category) ([]models.News, error) {
+	r.logger.Debug().Str("id", category.ID).Msg("Updating category")
+
+	if _, err := uuid.Parse(category.ID); err != nil {
+		return nil, newsModels.ErrCategoryNotFound
+	}
+
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get top categories: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback(ctx)
 
-	for rows.Next() {
-		var categoryStats models.CategoryStats
-		err := rows.Scan(&categoryStats.Category, &categoryStats.Count)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan category stats: %w", err)
+	var oldName string
+	err = tx.QueryRow(ctx, `SELECT name FROM categories WHERE id = $1 FOR UPDATE`, category.ID).Scan(&oldName)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, newsModels.ErrCategoryNotFound
 		}
-		stats.TopCategories = append(stats.TopCategories, categoryStats)
+		return nil, fmt.Errorf("failed to get category: %w", err)
 	}
 
-	// Get top sources
-	rows, err = r.db.Query(ctx, `
-		SELECT source, COUNT(*) as count 
-		FROM news 
-		GROUP BY source 
-		ORDER BY count DESC 
-		LIMIT 10
-	`)
+	_, err = tx.Exec(ctx, `
+		UPDATE categories SET name = $2, description = $3, color = $4, icon = $5
+		WHERE id = $1
+	`, category.ID, category.Name, category.Description, category.Color, category.Icon)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get top sources: %w", err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, newsModels.ErrCategoryExists
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var sourceStats models.SourceStats
-		err := rows.Scan(&sourceStats.Source, &sourceStats.Count)
+	var moved []models.News
+	if oldName != category.Name {
+		moved, err = r.moveCategory(ctx, tx, oldName, category.Name)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan source stats: %w", err)
+			return nil, fmt.Errorf("failed to rename category on news: %w", err)
 		}
-		stats.TopSources = append(stats.TopSources, sourceStats)
 	}
 
-	return stats, nil
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit category update: %w", err)
+	}
+
+	return moved, nil
 }
 
-func (r *NewsRepository) GetSources(ctx context.Context) ([]models.Source, error) {
-	r.logger.Debug().Msg("Getting sources")
+// DeleteCategory removes a category. Categories still assigned to articles
+// are rejected with ErrCategoryInUse unless reassignTo names another
+// category, in which case those articles are moved there first and
+// returned so callers can re-index them.
+func (r *NewsRepository) DeleteCategory(ctx context.Context, id, reassignTo string) ([]models.News, error) {
+	r.logger.Debug().Str("id", id).Str("reassign_to", reassignTo).Msg("Deleting category")
 
-	query := `
-		SELECT id, name, type, url, schedule, rate_limit, headers, enabled, 
-			   last_fetched, created_at, updated_at
-		FROM sources ORDER BY name
-	`
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, newsModels.ErrCategoryNotFound
+	}
 
-	rows, err := r.db.Query(ctx, query)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query sources: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
+
+	var name string
+	err = tx.QueryRow(ctx, `SELECT name FROM categories WHERE id = $1 FOR UPDATE`, id).Scan(&name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, newsModels.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	if reassignTo == name {
+		return nil, newsModels.ErrReassignToSelf
+	}
+
+	var inUse int64
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM news WHERE category = $1`, name).Scan(&inUse); err != nil {
+		return nil, fmt.Errorf("failed to count category usage: %w", err)
+	}
+
+	var reassigned []models.News
+	if inUse > 0 {
+		if reassignTo == "" {
+			return nil, newsModels.ErrCategoryInUse
+		}
+
+		var exists bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM categories WHERE name = $1)`, reassignTo).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check reassignment category: %w", err)
+		}
+		if !exists {
+			return nil, newsModels.ErrCategoryNotFound
+		}
+
+		reassigned, err = r.moveCategory(ctx, tx, name, reassignTo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reassign news: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM categories WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit category delete: %w", err)
+	}
+
+	return reassigned, nil
+}
+
+// moveCategory files the articles of category from under to and returns
+// them as updated
+func (r *NewsRepository) moveCategory(ctx context.Context, tx pgx.Tx, from, to string) ([]models.News, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE news SET category = $2, updated_at = NOW()
+		WHERE category = $1
+		RETURNING `+updatedNewsColumns, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return r.scanUpdatedNews(rows)
+}
+
+// MergeTags replaces every occurrence of the from tags with to across all
+// articles in a single UPDATE, dropping duplicates while keeping the
+// original tag order. It returns the updated articles so callers can
+// re-index them.
+func (r *NewsRepository) MergeTags(ctx context.Context, from []string, to string) ([]models.News, error) {
+	r.logger.Debug().Strs("from", from).Str("to", to).Msg("Merging tags")
+
+	query := `
+		UPDATE news SET
+			tags = (
+				SELECT COALESCE(jsonb_agg(tag ORDER BY pos), '[]'::jsonb)
+				FROM (
+					SELECT CASE WHEN elem = ANY($1) THEN $2 ELSE elem END AS tag, MIN(ord) AS pos
+					FROM jsonb_array_elements_text(news.tags) WITH ORDINALITY AS e(elem, ord)
+					GROUP BY 1
+				) merged
+			),
+			updated_at = NOW()
+		WHERE tags ?| $1
+		RETURNING ` + updatedNewsColumns
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	return r.scanUpdatedNews(rows)
+}
+
+// updatedNewsColumns is the RETURNING list of bulk updates whose articles
+// are read back with scanUpdatedNews
+const updatedNewsColumns = `id, title, content, summary, url, image_url, author, source,
+	category, COALESCE(source_category, ''), tags, published_at, created_at, updated_at`
+
+// scanUpdatedNews reads the articles returned by a bulk update and closes rows
+func (r *NewsRepository) scanUpdatedNews(rows pgx.Rows) ([]models.News, error) {
 	defer rows.Close()
 
-	var sources []models.Source
+	var updated []models.News
 	for rows.Next() {
-		var s models.Source
-		var headersJSON []byte
+		var n models.News
+		var tagsJSON []byte
 
 		err := rows.Scan(
-			&s.ID, &s.Name, &s.Type, &s.URL, &s.Schedule, &s.RateLimit,
-			&headersJSON, &s.Enabled, &s.LastFetched, &s.CreatedAt, &s.UpdatedAt,
+			&n.ID, &n.Title, &n.Content, &n.Summary, &n.URL, &n.ImageURL,
+			&n.Author, &n.Source, &n.Category, &n.SourceCategory, &tagsJSON, &n.PublishedAt,
+			&n.CreatedAt, &n.UpdatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan source row: %w", err)
+			return nil, fmt.Errorf("failed to scan updated news row: %w", err)
 		}
 
-		// Unmarshal headers
-		if len(headersJSON) > 0 {
-			if err := json.Unmarshal(headersJSON, &s.Headers); err != nil {
-				r.logger.Warn().Err(err).Str("id", s.ID).Msg("Failed to unmarshal headers")
-				s.Headers = make(map[string]string)
+		if len(tagsJSON) > 0 {
+			if err := json.Unmarshal(tagsJSON, &n.Tags); err != nil {
+				r.logger.Warn().Err(err).Str("id", n.ID).Msg("Failed to unmarshal tags")
+				n.Tags = []string{}
 			}
 		}
 
+		updated = append(updated, n)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating updated news rows: %w", rows.Err())
+	}
+
+	return updated, nil
+}
+
+func (r *NewsRepository) GetStats(ctx context.Context) (*models.Stats, error) {
+	return r.GetStatsWithFilter(ctx, models.StatsFilter{})
+}
+
+// GetStatsWithFilter computes the statistics over the articles matching the
+// filter. The today/this week/this month counts are further narrowed to
+// their period.
+func (r *NewsRepository) GetStatsWithFilter(ctx context.Context, filter models.StatsFilter) (*models.Stats, error) {
+	r.logger.Debug().
+		Time("from", filter.From).
+		Time("to", filter.To).
+		Str("category", filter.Category).
+		Str("source", filter.Source).
+		Msg("Getting stats")
+
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("published_at < $%d", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	stats := &models.Stats{}
+
+	// Get total articles
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM news "+where, args...).Scan(&stats.TotalArticles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total articles: %w", err)
+	}
+
+	// Get articles today
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM news 
+		`+where+` AND published_at >= CURRENT_DATE
+	`, args...).Scan(&stats.ArticlesToday)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles today: %w", err)
+	}
+
+	// Get articles this week
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM news 
+		`+where+` AND published_at >= DATE_TRUNC('week', CURRENT_DATE)
+	`, args...).Scan(&stats.ArticlesThisWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles this week: %w", err)
+	}
+
+	// Get articles this month
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM news 
+		`+where+` AND published_at >= DATE_TRUNC('month', CURRENT_DATE)
+	`, args...).Scan(&stats.ArticlesThisMonth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles this month: %w", err)
+	}
+
+	// Get top categories
+	rows, err := r.db.Query(ctx, `
+		SELECT category, COUNT(*) as count 
+		FROM news 
+		`+where+`
+		GROUP BY category 
+		ORDER BY count DESC 
+		LIMIT 10
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top categories: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var categoryStats models.CategoryStats
+		err := rows.Scan(&categoryStats.Category, &categoryStats.Count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category stats: %w", err)
+		}
+		stats.TopCategories = append(stats.TopCategories, categoryStats)
+	}
+
+	// Get top sources
+	rows, err = r.db.Query(ctx, `
+		SELECT source, COUNT(*) as count 
+		FROM news 
+		`+where+`
+		GROUP BY source 
+		ORDER BY count DESC 
+		LIMIT 10
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top sources: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sourceStats models.SourceStats
+		err := rows.Scan(&sourceStats.Source, &sourceStats.Count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source stats: %w", err)
+		}
+		stats.TopSources = append(stats.TopSources, sourceStats)
+	}
+
+	return stats, nil
+}
+
+// GetDailyCounts returns the number of articles published per day for every
+// day from from up to, but excluding, to. Days without articles are included
+// with a zero count.
+func (r *NewsRepository) GetDailyCounts(ctx context.Context, from, to time.Time) ([]models.DailyCount, error) {
+	r.logger.Debug().Time("from", from).Time("to", to).Msg("Getting daily counts")
+
+	query := `
+		SELECT to_char(day, 'YYYY-MM-DD'), COUNT(n.id)
+		FROM generate_series(
+			date_trunc('day', $1::timestamptz),
+			date_trunc('day', $2::timestamptz - interval '1 microsecond'),
+			interval '1 day'
+		) AS day
+		LEFT JOIN news n
+			ON n.published_at >= day AND n.published_at < day + interval '1 day'
+			AND n.published_at >= $1 AND n.published_at < $2
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []models.DailyCount{}
+	for rows.Next() {
+		var count models.DailyCount
+		if err := rows.Scan(&count.Date, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan daily count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate daily counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetCategorySentiment averages the content analysis sentiment of the
+// articles published since the given time per category. Every category,
+// whether from the categories table or the articles, is listed; those
+// without analyzed articles have a zero average and count.
+func (r *NewsRepository) GetCategorySentiment(ctx context.Context, since time.Time) ([]models.CategorySentiment, error) {
+	r.logger.Debug().Time("since", since).Msg("Getting category sentiment")
+
+	query := `
+		SELECT c.name, COALESCE(AVG(ca.sentiment_score), 0)::float8, COUNT(ca.sentiment_score)
+		FROM (
+			SELECT name FROM categories
+			UNION
+			SELECT category FROM news WHERE published_at >= $1 AND category <> ''
+		) AS c(name)
+		LEFT JOIN news n ON n.category = c.name AND n.published_at >= $1
+		LEFT JOIN content_analysis ca ON ca.article_id = n.id
+		GROUP BY c.name
+		ORDER BY c.name
+	`
+
+	rows, err := r.db.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category sentiment: %w", err)
+	}
+	defer rows.Close()
+
+	sentiments := []models.CategorySentiment{}
+	for rows.Next() {
+		var sentiment models.CategorySentiment
+		if err := rows.Scan(&sentiment.Category, &sentiment.AverageSentiment, &sentiment.Articles); err != nil {
+			return nil, fmt.Errorf("failed to scan category sentiment: %w", err)
+		}
+		sentiments = append(sentiments, sentiment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate category sentiment: %w", err)
+	}
+
+	return sentiments, nil
+}
+
+// sourceColumns are the sources columns read by scanSource
+const sourceColumns = `
+	id, name, type, url, schedule, rate_limit, headers, enabled,
+	last_fetched, last_success, COALESCE(last_error, ''), COALESCE(last_error_category, ''),
+	consecutive_failures, created_at, updated_at`
+
+// scanSource scans a row selected with sourceColumns
+func (r *NewsRepository) scanSource(row pgx.Row) (models.Source, error) {
+	var s models.Source
+	var headersJSON []byte
+	var lastFetched, lastSuccess *time.Time
+
+	err := row.Scan(
+		&s.ID, &s.Name, &s.Type, &s.URL, &s.Schedule, &s.RateLimit,
+		&headersJSON, &s.Enabled, &lastFetched, &lastSuccess, &s.LastError,
+		&s.LastErrorCategory, &s.ConsecutiveFailures, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return s, err
+	}
+	if lastFetched != nil {
+		s.LastFetched = *lastFetched
+	}
+	if lastSuccess != nil {
+		s.LastSuccess = *lastSuccess
+	}
+
+	// Unmarshal headers
+	if len(headersJSON) > 0 {
+		if err := json.Unmarshal(headersJSON, &s.Headers); err != nil {
+			r.logger.Warn().Err(err).Str("id", s.ID).Msg("Failed to unmarshal headers")
+			s.Headers = make(map[string]string)
+		}
+	}
+
+	return s, nil
+}
+
+func (r *NewsRepository) GetSources(ctx context.Context) ([]models.Source, error) {
+	r.logger.Debug().Msg("Getting sources")
+
+	rows, err := r.db.Query(ctx, `SELECT `+sourceColumns+` FROM sources ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []models.Source
+	for rows.Next() {
+		s, err := r.scanSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source row: %w", err)
+		}
 		sources = append(sources, s)
 	}
 
@@ -556,6 +1296,45 @@ func (r *NewsRepository) GetSources(ctx context.Context) ([]models.Source, error
 	return sources, nil
 }
 
+// GetSourceByID returns the source stored under id. Malformed IDs yield
+// ErrInvalidSourceID, unknown ones ErrSourceNotFound.
+func (r *NewsRepository) GetSourceByID(ctx context.Context, id string) (*models.Source, error) {
+	r.logger.Debug().Str("id", id).Msg("Getting source by ID")
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, sourceModels.ErrInvalidSourceID
+	}
+
+	s, err := r.scanSource(r.db.QueryRow(ctx, `SELECT `+sourceColumns+` FROM sources WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, sourceModels.ErrSourceNotFound
+		}
+		return nil, fmt.Errorf("failed to get source: %w", err)
+	}
+
+	return &s, nil
+}
+
+// GetSourceArticleStats counts the articles of the named source stored in
+// the last 24 hours and 7 days
+func (r *NewsRepository) GetSourceArticleStats(ctx context.Context, source string) (*models.SourceArticleStats, error) {
+	r.logger.Debug().Str("source", source).Msg("Getting source article stats")
+
+	var stats models.SourceArticleStats
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours'),
+			   COUNT(*)
+		FROM news
+		WHERE source = $1 AND created_at >= NOW() - INTERVAL '7 days'
+	`, source).Scan(&stats.Last24h, &stats.Last7d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source article stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
 func (r *NewsRepository) CreateSource(ctx context.Context, source *models.Source) error {
 	r.logger.Debug().Str("name", source.Name).Msg("Creating source")
 
@@ -615,6 +1394,48 @@ func (r *NewsRepository) UpdateSource(ctx context.Context, source *models.Source
 	return nil
 }
 
+// BulkUpdateSources applies partial updates to many sources in one
+// transaction. Unknown IDs are reported per update; any database error rolls
+// back every update.
+func (r *NewsRepository) BulkUpdateSources(ctx context.Context, updates []models.SourceBulkUpdate) ([]models.SourceBulkUpdateResult, error) {
+	r.logger.Debug().Int("count", len(updates)).Msg("Bulk updating sources")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE sources SET
+			schedule = COALESCE($2, schedule),
+			rate_limit = COALESCE($3, rate_limit),
+			enabled = COALESCE($4, enabled),
+			updated_at = NOW()
+		WHERE id = $1
+	`
+
+	results := make([]models.SourceBulkUpdateResult, 0, len(updates))
+	for _, update := range updates {
+		result, err := tx.Exec(ctx, query, update.ID, update.Schedule, update.RateLimit, update.Enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update source %s: %w", update.ID, err)
+		}
+
+		if result.RowsAffected() == 0 {
+			results = append(results, models.SourceBulkUpdateResult{ID: update.ID, Error: "source not found"})
+			continue
+		}
+		results = append(results, models.SourceBulkUpdateResult{ID: update.ID, Updated: true})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit bulk source update: %w", err)
+	}
+
+	return results, nil
+}
+
 func (r *NewsRepository) DeleteSource(ctx context.Context, id string) error {
 	r.logger.Debug().Str("id", id).Msg("Deleting source")
 
@@ -632,6 +1453,84 @@ func (r *NewsRepository) DeleteSource(ctx context.Context, id string) error {
 	return nil
 }
 
+// RecordSourceFetch stores the outcome of one fetch of a source and returns
+// the resulting failure streak. An empty fetchErr marks a success and resets
+// the streak; otherwise the error and its category are kept and the streak
+// grows. Sources defined only in the service config are added to the table
+// on their first fetch.
+func (r *NewsRepository) RecordSourceFetch(ctx context.Context, source *models.Source, fetchErr, category string) (int, error) {
+	r.logger.Debug().Str("name", source.Name).Bool("success", fetchErr == "").Msg("Recording source fetch")
+
+	query := `
+		INSERT INTO sources (name, type, url, schedule, rate_limit, enabled,
+			last_fetched, last_success, last_error, last_error_category, consecutive_failures)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(),
+			CASE WHEN $7::text = '' THEN NOW() END,
+			NULLIF($7::text, ''),
+			CASE WHEN $7::text = '' THEN NULL ELSE $8::text END,
+			CASE WHEN $7::text = '' THEN 0 ELSE 1 END)
+		ON CONFLICT (name) DO UPDATE SET
+			last_fetched = NOW(),
+			last_success = CASE WHEN $7::text = '' THEN NOW() ELSE sources.last_success END,
+			last_error = COALESCE(NULLIF($7::text, ''), sources.last_error),
+			last_error_category = CASE WHEN $7::text = '' THEN sources.last_error_category ELSE $8::text END,
+			consecutive_failures = CASE WHEN $7::text = '' THEN 0 ELSE sources.consecutive_failures + 1 END,
+			updated_at = NOW()
+		RETURNING consecutive_failures
+	`
+
+	var failures int
+	err := r.db.QueryRow(ctx, query,
+		source.Name, source.Type, source.URL, source.Schedule, source.RateLimit, source.Enabled, fetchErr, category,
+	).Scan(&failures)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record source fetch: %w", err)
+	}
+
+	return failures, nil
+}
+
+// DisableSourceByName turns off collection for the named source.
+func (r *NewsRepository) DisableSourceByName(ctx context.Context, name string) error {
+	r.logger.Debug().Str("name", name).Msg("Disabling source")
+
+	result, err := r.db.Exec(ctx, `UPDATE sources SET enabled = false, updated_at = NOW() WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to disable source: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("source not found")
+	}
+
+	return nil
+}
+
+// EnableSource turns collection back on for a source and clears its
+// failure streak. Malformed IDs yield ErrInvalidSourceID, unknown ones
+// ErrSourceNotFound.
+func (r *NewsRepository) EnableSource(ctx context.Context, id string) error {
+	r.logger.Debug().Str("id", id).Msg("Enabling source")
+
+	if _, err := uuid.Parse(id); err != nil {
+		return sourceModels.ErrInvalidSourceID
+	}
+
+	result, err := r.db.Exec(ctx, `
+		UPDATE sources SET enabled = true, consecutive_failures = 0, updated_at = NOW()
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to enable source: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return sourceModels.ErrSourceNotFound
+	}
+
+	return nil
+}
+
 // GetRecentArticles returns articles from the last specified duration
 func (nr *NewsRepository) GetRecentArticles(ctx context.Context, duration time.Duration) ([]models.News, error) {
 	since := time.Now().Add(-duration)
@@ -748,26 +1647,235 @@ func (nr *NewsRepository) GetArticlesByDateRange(ctx context.Context, start, end
 	return articles, nil
 }
 
-// CleanupOldArticles removes articles older than 2 days from the database
-func (r *NewsRepository) CleanupOldArticles(ctx context.Context) error {
-	r.logger.Info().Msg("Starting cleanup of articles older than 2 days")
-	
-	twoDaysAgo := time.Now().AddDate(0, 0, -2)
-	
-	query := `DELETE FROM news WHERE published_at < $1`
-	
-	result, err := r.db.Exec(ctx, query, twoDaysAgo)
+// GetKeywordMentions returns the keywords extracted by content analysis for
+// articles created since the given time
+func (nr *NewsRepository) GetKeywordMentions(ctx context.Context, since time.Time) ([]models.KeywordMention, error) {
+	query := `
+		SELECT LOWER(kw.keyword), n.id, n.created_at
+		FROM content_analysis ca
+		JOIN news n ON n.id = ca.article_id
+		CROSS JOIN LATERAL jsonb_array_elements_text(ca.keywords_extracted) AS kw(keyword)
+		WHERE n.created_at >= $1
+	`
+
+	rows, err := nr.db.Query(ctx, query, since)
+	if err != nil {
+		nr.logger.Error().Err(err).Msg("Failed to get keyword mentions")
+		return nil, fmt.Errorf("failed to get keyword mentions: %w", err)
+	}
+	defer rows.Close()
+
+	var mentions []models.KeywordMention
+	for rows.Next() {
+		var mention models.KeywordMention
+		if err := rows.Scan(&mention.Keyword, &mention.ArticleID, &mention.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan keyword mention row: %w", err)
+		}
+		mentions = append(mentions, mention)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating keyword mention rows: %w", rows.Err())
+	}
+
+	nr.logger.Debug().Int("count", len(mentions)).Time("since", since).Msg("Retrieved keyword mentions")
+	return mentions, nil
+}
+
+// CleanupOldArticles removes articles published longer ago than their
+// retention period. Sources listed in sourceRetentions use their own period;
+// every other article uses retention.
+func (r *NewsRepository) CleanupOldArticles(ctx context.Context, retention time.Duration, sourceRetentions map[string]time.Duration) error {
+	r.logger.Info().Dur("retention", retention).Int("source_overrides", len(sourceRetentions)).Msg("Starting cleanup of old articles")
+
+	now := time.Now()
+	var deletedCount int64
+
+	overridden := make([]string, 0, len(sourceRetentions))
+	for source, sourceRetention := range sourceRetentions {
+		overridden = append(overridden, source)
+
+		result, err := r.db.Exec(ctx, `DELETE FROM news WHERE source = $1 AND published_at < $2`, source, now.Add(-sourceRetention))
+		if err != nil {
+			r.logger.Error().Err(err).Str("source", source).Msg("Failed to cleanup old articles")
+			return fmt.Errorf("failed to cleanup old articles for source %s: %w", source, err)
+		}
+		deletedCount += result.RowsAffected()
+	}
+
+	cutoff := now.Add(-retention)
+	result, err := r.db.Exec(ctx, `DELETE FROM news WHERE published_at < $1 AND source <> ALL($2)`, cutoff, overridden)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("Failed to cleanup old articles")
 		return fmt.Errorf("failed to cleanup old articles: %w", err)
 	}
-	
-	deletedCount := result.RowsAffected()
-	r.logger.Info().Int64("deleted_count", deletedCount).Time("cutoff_date", twoDaysAgo).Msg("Cleanup completed")
-	
+	deletedCount += result.RowsAffected()
+
+	r.logger.Info().Int64("deleted_count", deletedCount).Time("cutoff_date", cutoff).Msg("Cleanup completed")
+
+	return nil
+}
+
+// GetBackfillPosition returns where an interrupted backfill of the source
+// stopped, or an empty string when there is nothing to resume
+func (r *NewsRepository) GetBackfillPosition(ctx context.Context, source string) (string, error) {
+	var position string
+	err := r.db.QueryRow(ctx, `SELECT position FROM source_backfills WHERE source = $1`, source).Scan(&position)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get backfill position: %w", err)
+	}
+	return position, nil
+}
+
+// SaveBackfillPosition records the next archive position to backfill
+func (r *NewsRepository) SaveBackfillPosition(ctx context.Context, source, position string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO source_backfills (source, position, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (source) DO UPDATE SET position = EXCLUDED.position, updated_at = NOW()
+	`, source, position)
+	if err != nil {
+		return fmt.Errorf("failed to save backfill position: %w", err)
+	}
 	return nil
 }
 
+// ClearBackfillPosition forgets the backfill progress of the source
+func (r *NewsRepository) ClearBackfillPosition(ctx context.Context, source string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM source_backfills WHERE source = $1`, source); err != nil {
+		return fmt.Errorf("failed to clear backfill position: %w", err)
+	}
+	return nil
+}
+
+// contentHashBackfillPageSize is the number of rows BackfillContentHashes
+// loads at a time
+const contentHashBackfillPageSize = 500
+
+// BackfillContentHashes sets content_hash on rows stored before it was
+// filled consistently. The processor hashes articles as parsed, before
+// cleaning them; ContentHash ignores what cleaning changes, so hashing the
+// stored row gives the hash its article had when it was ingested. Rows are
+// read a page at a time in id order. A row whose hash already belongs to
+// another article is a duplicate of it and is left without a hash.
+func (r *NewsRepository) BackfillContentHashes(ctx context.Context) (int, error) {
+	updated := 0
+	skipped := 0
+	lastID := uuid.Nil
+
+	for {
+		rows, err := r.db.Query(ctx, `
+			SELECT id, title, content, url FROM news
+			WHERE content_hash IS NULL AND id > $1
+			ORDER BY id
+			LIMIT $2
+		`, lastID, contentHashBackfillPageSize)
+		if err != nil {
+			return updated, fmt.Errorf("failed to query rows without content hash: %w", err)
+		}
+
+		var page []models.News
+		for rows.Next() {
+			var n models.News
+			if err := rows.Scan(&n.ID, &n.Title, &n.Content, &n.URL); err != nil {
+				rows.Close()
+				return updated, fmt.Errorf("failed to scan news row: %w", err)
+			}
+			page = append(page, n)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return updated, fmt.Errorf("error iterating news rows: %w", err)
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		for _, n := range page {
+			_, err := r.db.Exec(ctx,
+				`UPDATE news SET content_hash = $2 WHERE id = $1 AND content_hash IS NULL`,
+				n.ID, n.ContentHash())
+			if err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+					r.logger.Warn().Str("id", n.ID).Str("url", n.URL).Msg("Article duplicates an existing one, leaving content hash empty")
+					skipped++
+					continue
+				}
+				return updated, fmt.Errorf("failed to update content hash: %w", err)
+			}
+			updated++
+		}
+
+		lastID, err = uuid.Parse(page[len(page)-1].ID)
+		if err != nil {
+			return updated, fmt.Errorf("invalid news id %q: %w", page[len(page)-1].ID, err)
+		}
+
+		r.logger.Info().Int("updated", updated).Int("skipped", skipped).Msg("Backfilled content hashes")
+	}
+
+	return updated, nil
+}
+
+// AddIngestCounts adds counts to the source's statistics for the current
+// UTC day
+func (r *NewsRepository) AddIngestCounts(ctx context.Context, source string, counts models.SourceIngestCounts) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO source_ingest_stats (source, day, fetched, new, duplicate, failed)
+		VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, $2, $3, $4, $5)
+		ON CONFLICT (source, day) DO UPDATE SET
+			fetched = source_ingest_stats.fetched + EXCLUDED.fetched,
+			new = source_ingest_stats.new + EXCLUDED.new,
+			duplicate = source_ingest_stats.duplicate + EXCLUDED.duplicate,
+			failed = source_ingest_stats.failed + EXCLUDED.failed
+	`, source, counts.Fetched, counts.New, counts.Duplicate, counts.Failed)
+	if err != nil {
+		return fmt.Errorf("failed to add ingest counts: %w", err)
+	}
+	return nil
+}
+
+// GetIngestStats returns the daily ingestion statistics in [from, to)
+// ordered by source and day. An empty source returns every source. Days on
+// which a source saw no activity have no entry.
+func (r *NewsRepository) GetIngestStats(ctx context.Context, source string, from, to time.Time) ([]models.SourceIngestStat, error) {
+	r.logger.Debug().Str("source", source).Time("from", from).Time("to", to).Msg("Getting ingest stats")
+
+	query := `
+		SELECT source, to_char(day, 'YYYY-MM-DD'), fetched, new, duplicate, failed
+		FROM source_ingest_stats
+		WHERE day >= $1::date AND day < $2::date
+			AND ($3::text = '' OR source = $3)
+		ORDER BY source, day
+	`
+
+	rows, err := r.db.Query(ctx, query, from.UTC(), to.UTC(), source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingest stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []models.SourceIngestStat{}
+	for rows.Next() {
+		var stat models.SourceIngestStat
+		if err := rows.Scan(&stat.Source, &stat.Date, &stat.Fetched, &stat.New, &stat.Duplicate, &stat.Failed); err != nil {
+			return nil, fmt.Errorf("failed to scan ingest stat: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate ingest stats: %w", err)
+	}
+
+	return stats, nil
+}
+
 func (r *NewsRepository) Close() error {
 	r.db.Close()
 	return nil
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+func TestCreateNewsConcurrentDuplicates(t *testing.T) {
+	pool := newTestPool(t)
+
+	newsRepo, err := NewNewsRepositoryWithPool(pool, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("failed to create news repository: %v", err)
+	}
+
+	tests := map[string]func(hash, url string, worker int) *models.News{
+		"same content hash": func(hash, url string, worker int) *models.News {
+			return &models.News{Title: "Race", URL: fmt.Sprintf("%s/%d", url, worker), Hash: hash}
+		},
+		"same url": func(hash, url string, worker int) *models.News {
+			return &models.News{Title: "Race", URL: url, Hash: fmt.Sprintf("%s-%d", hash, worker)}
+		},
+	}
+
+	const workers = 8
+	for name, article := range tests {
+		t.Run(name, func(t *testing.T) {
+			hash := uuid.NewString()
+			url := "https://example.com/" + uuid.NewString()
+
+			// Release all workers at once so their inserts overlap
+			start := make(chan struct{})
+			errs := make([]error, workers)
+			ids := make([]string, workers)
+
+			var wg sync.WaitGroup
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					news := article(hash, url, i)
+					news.Source = "test"
+					news.Category = "technology"
+					news.PublishedAt = time.Now()
+
+					<-start
+					errs[i] = newsRepo.CreateNews(context.Background(), news)
+					ids[i] = news.ID
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+
+			stored := 0
+			for i, err := range errs {
+				switch {
+				case err == nil:
+					stored++
+					id := ids[i]
+					t.Cleanup(func() {
+						_ = newsRepo.DeleteNews(context.Background(), id)
+					})
+				case !errors.Is(err, newsModels.ErrDuplicateNews):
+					t.Errorf("worker %d: err = %v, want ErrDuplicateNews", i, err)
+				}
+			}
+			if stored != 1 {
+				t.Errorf("%d workers stored the article, want 1", stored)
+			}
+		})
+	}
+}
+
+func TestDeleteNewsRejectsMalformedID(t *testing.T) {
+	// The ID is checked before the database is queried
+	newsRepo := &NewsRepository{logger: zerolog.Nop()}
+
+	if err := newsRepo.DeleteNews(context.Background(), "not-a-uuid"); !errors.Is(err, newsModels.ErrNewsNotFound) {
+		t.Errorf("err = %v, want ErrNewsNotFound", err)
+	}
+}
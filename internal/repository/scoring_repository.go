@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
 
-	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
@@ -168,6 +172,14 @@ func (r *ScoringRepository) insertDefaultSourceCredibility(ctx context.Context)
 
 // Article Scores
 func (r *ScoringRepository) SaveArticleScore(ctx context.Context, score *models.ArticleScore) error {
+	clamped, err := score.Normalize()
+	if err != nil {
+		return fmt.Errorf("invalid article score for %s: %w", score.ArticleID, err)
+	}
+	if len(clamped) > 0 {
+		r.logger.Warn().Str("article_id", score.ArticleID).Strs("scores", clamped).Msg("Clamped out-of-range article scores")
+	}
+
 	query := `
 		INSERT INTO article_scores (article_id, engagement_score, credibility_score, content_score, social_score, final_score, last_updated)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
@@ -179,7 +191,7 @@ func (r *ScoringRepository) SaveArticleScore(ctx context.Context, score *models.
 			final_score = EXCLUDED.final_score,
 			last_updated = EXCLUDED.last_updated`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		score.ArticleID,
 		score.EngagementScore,
 		score.CredibilityScore,
@@ -217,6 +229,49 @@ func (r *ScoringRepository) GetArticleScore(ctx context.Context, articleID strin
 	return &score, nil
 }
 
+// GetArticleScores returns the stored scores for the given articles in a
+// single query, keyed by article ID. Articles without a score are omitted.
+func (r *ScoringRepository) GetArticleScores(ctx context.Context, articleIDs []string) (map[string]*models.ArticleScore, error) {
+	scores := make(map[string]*models.ArticleScore, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return scores, nil
+	}
+
+	query := `
+		SELECT id, article_id, engagement_score, credibility_score, content_score, social_score, final_score, last_updated, created_at
+		FROM article_scores WHERE article_id = ANY($1)`
+
+	rows, err := r.db.Query(ctx, query, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article scores: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var score models.ArticleScore
+		if err := rows.Scan(
+			&score.ID,
+			&score.ArticleID,
+			&score.EngagementScore,
+			&score.CredibilityScore,
+			&score.ContentScore,
+			&score.SocialScore,
+			&score.FinalScore,
+			&score.LastUpdated,
+			&score.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan article score: %w", err)
+		}
+		scores[score.ArticleID] = &score
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate article scores: %w", err)
+	}
+
+	return scores, nil
+}
+
 // Engagement Metrics
 func (r *ScoringRepository) UpdateEngagementMetrics(ctx context.Context, articleID, engagementType string, value int64) error {
 	// First, ensure the record exists
@@ -249,6 +304,32 @@ func (r *ScoringRepository) UpdateEngagementMetrics(ctx context.Context, article
 	return err
 }
 
+// IncrementViewCount atomically adds a view to an existing article and
+// returns the new total. It never creates metrics for unknown articles.
+func (r *ScoringRepository) IncrementViewCount(ctx context.Context, articleID string) (int64, error) {
+	if _, err := uuid.Parse(articleID); err != nil {
+		return 0, newsModels.ErrNewsNotFound
+	}
+
+	query := `
+		INSERT INTO engagement_metrics (article_id, view_count)
+		SELECT id, 1 FROM news WHERE id = $1
+		ON CONFLICT (article_id) DO UPDATE SET
+			view_count = engagement_metrics.view_count + 1,
+			last_updated = NOW()
+		RETURNING view_count`
+
+	var viewCount int64
+	if err := r.db.QueryRow(ctx, query, articleID).Scan(&viewCount); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, newsModels.ErrNewsNotFound
+		}
+		return 0, fmt.Errorf("failed to increment view count: %w", err)
+	}
+
+	return viewCount, nil
+}
+
 func (r *ScoringRepository) GetEngagementMetrics(ctx context.Context, articleID string) (*models.EngagementMetrics, error) {
 	query := `
 		SELECT id, article_id, view_count, click_count, share_count, average_read_time, bounce_rate, last_updated, created_at
@@ -300,6 +381,14 @@ func (r *ScoringRepository) GetSourceCredibility(ctx context.Context, sourceName
 }
 
 func (r *ScoringRepository) UpdateSourceCredibility(ctx context.Context, credibility *models.SourceCredibility) error {
+	clamped, err := credibility.Normalize()
+	if err != nil {
+		return fmt.Errorf("invalid credibility for %s: %w", credibility.SourceName, err)
+	}
+	if len(clamped) > 0 {
+		r.logger.Warn().Str("source", credibility.SourceName).Strs("scores", clamped).Msg("Clamped out-of-range credibility scores")
+	}
+
 	query := `
 		UPDATE source_credibility SET 
 			credibility_score = $2, 
@@ -309,7 +398,7 @@ func (r *ScoringRepository) UpdateSourceCredibility(ctx context.Context, credibi
 			updated_at = NOW()
 		WHERE source_name = $1`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		credibility.SourceName,
 		credibility.CredibilityScore,
 		credibility.ReliabilityScore,
@@ -322,6 +411,14 @@ func (r *ScoringRepository) UpdateSourceCredibility(ctx context.Context, credibi
 
 // Content Analysis
 func (r *ScoringRepository) SaveContentAnalysis(ctx context.Context, analysis *models.ContentAnalysis) error {
+	clamped, err := analysis.Normalize()
+	if err != nil {
+		return fmt.Errorf("invalid content analysis for %s: %w", analysis.ArticleID, err)
+	}
+	if len(clamped) > 0 {
+		r.logger.Warn().Str("article_id", analysis.ArticleID).Strs("scores", clamped).Msg("Clamped out-of-range content analysis scores")
+	}
+
 	keywordsJSON, _ := json.Marshal(analysis.KeywordsExtracted)
 	entitiesJSON, _ := json.Marshal(analysis.EntitiesExtracted)
 
@@ -340,7 +437,7 @@ func (r *ScoringRepository) SaveContentAnalysis(ctx context.Context, analysis *m
 			language_detected = EXCLUDED.language_detected,
 			processed_at = EXCLUDED.processed_at`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		analysis.ArticleID,
 		analysis.SentimentScore,
 		analysis.ImportanceScore,
@@ -489,3 +586,42 @@ func (r *ScoringRepository) GetTopScoredArticles(ctx context.Context, limit int,
 
 	return articleIDs, nil
 }
+
+// GetScoresUpdatedSince returns the final scores changed after since, keyed
+// by article ID, along with the latest update time seen so callers can sync
+// incrementally.
+func (r *ScoringRepository) GetScoresUpdatedSince(ctx context.Context, since time.Time) (map[string]float64, time.Time, error) {
+	query := `
+		SELECT article_id, final_score, last_updated FROM article_scores
+		WHERE last_updated > $1
+		ORDER BY last_updated`
+
+	rows, err := r.db.Query(ctx, query, since)
+	if err != nil {
+		return nil, since, fmt.Errorf("failed to get updated scores: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make(map[string]float64)
+	latest := since
+	for rows.Next() {
+		var (
+			articleID   string
+			finalScore  float64
+			lastUpdated time.Time
+		)
+		if err := rows.Scan(&articleID, &finalScore, &lastUpdated); err != nil {
+			return nil, since, fmt.Errorf("failed to scan updated score: %w", err)
+		}
+		scores[articleID] = finalScore
+		if lastUpdated.After(latest) {
+			latest = lastUpdated
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, since, fmt.Errorf("failed to iterate updated scores: %w", err)
+	}
+
+	return scores, latest, nil
+}
@@ -5,24 +5,55 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"news-aggregator/internal/config"
+	"news-aggregator/internal/db"
 	"news-aggregator/internal/models"
+	searchModels "news-aggregator/internal/models/search"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/rs/zerolog"
 )
 
+// Boost weights applied to preferred categories and sources when a search
+// is personalized.
+const (
+	preferredCategoryBoost = 2.0
+	preferredSourceBoost   = 1.5
+)
+
+// Fuzzy matching settings. Queries shorter than minFuzzyQueryLength are
+// matched exactly since a single edit would change most of the term.
+const (
+	minFuzzyQueryLength = 4
+	fuzzyPrefixLength   = 1
+	fuzzyMaxExpansions  = 50
+)
+
 type SearchRepository struct {
-	client *elasticsearch.Client
-	logger zerolog.Logger
-	index  string
+	client           *elasticsearch.Client
+	logger           zerolog.Logger
+	index            string
+	searchWindow     time.Duration
+	shards           int
+	replicas         int
+	synonyms         []string
+	popularityWeight float64
 }
 
 func NewSearchRepository(cfg *config.Config, logger zerolog.Logger) (*SearchRepository, error) {
+	if cfg.Elasticsearch.Shards < 1 {
+		return nil, fmt.Errorf("elasticsearch shards must be at least 1, got %d", cfg.Elasticsearch.Shards)
+	}
+	if cfg.Elasticsearch.Replicas < 0 {
+		return nil, fmt.Errorf("elasticsearch replicas must not be negative, got %d", cfg.Elasticsearch.Replicas)
+	}
+
 	// Create Elasticsearch client
 	esConfig := elasticsearch.Config{
 		Addresses: cfg.Elasticsearch.Addresses,
@@ -39,19 +70,158 @@ func NewSearchRepository(cfg *config.Config, logger zerolog.Logger) (*SearchRepo
 	}
 
 	repo := &SearchRepository{
-		client: client,
-		logger: logger.With().Str("component", "search_repository").Logger(),
-		index:  cfg.Elasticsearch.Index,
+		client:           client,
+		logger:           logger.With().Str("component", "search_repository").Logger(),
+		index:            cfg.Elasticsearch.Index,
+		shards:           cfg.Elasticsearch.Shards,
+		replicas:         cfg.Elasticsearch.Replicas,
+		synonyms:         cfg.Elasticsearch.Synonyms,
+		searchWindow:     cfg.Elasticsearch.SearchWindow,
+		popularityWeight: cfg.Elasticsearch.PopularityWeight,
 	}
 
-	// Initialize index
-	if err := repo.initIndex(context.Background()); err != nil {
-		return nil, fmt.Errorf("failed to initialize index: %w", err)
+	// Initialize index, retrying while the cluster starts up
+	err = db.RetryWithBackoff(context.Background(), repo.logger, "elasticsearch",
+		cfg.Elasticsearch.ConnectRetries, cfg.Elasticsearch.ConnectRetryInterval, func() error {
+			if err := repo.initIndex(context.Background()); err != nil {
+				return fmt.Errorf("failed to initialize index: %w", err)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
 	}
 
 	return repo, nil
 }
 
+// Analyzers of the title, content and summary fields. Both stem with
+// snowball so "running" matches "run"; the search analyzer also expands
+// the configured synonyms, which synonym_graph only supports at query time.
+const (
+	newsAnalyzer       = "news_analyzer"
+	newsSearchAnalyzer = "news_search_analyzer"
+	newsSynonymFilter  = "news_synonyms"
+)
+
+// defaultLanguage is assumed for articles indexed without a language; the
+// main text fields are analyzed for it
+const defaultLanguage = "en"
+
+// languageAnalyzers maps the other detected languages to the built-in
+// Elasticsearch analyzer of their text fields, e.g. content_hi
+var languageAnalyzers = map[string]string{
+	"hi": "hindi",
+	"es": "spanish",
+	"fr": "french",
+	"de": "german",
+	"pt": "portuguese",
+}
+
+// localizedTextFields are the text fields with a copy per languageAnalyzers
+// entry. Only the copy of the article's own language is filled, so other
+// languages' analyzers never index it.
+var localizedTextFields = []string{"title", "content", "summary"}
+
+// localizedField returns the name of field's copy for language
+func localizedField(field, language string) string {
+	return field + "_" + language
+}
+
+// addLanguageFields adds the localized text fields to the index properties
+func addLanguageFields(properties map[string]interface{}) map[string]interface{} {
+	for language, analyzer := range languageAnalyzers {
+		for _, field := range localizedTextFields {
+			properties[localizedField(field, language)] = map[string]interface{}{
+				"type":     "text",
+				"analyzer": analyzer,
+			}
+		}
+	}
+	return properties
+}
+
+// languageFields returns the text fields to search for language: its
+// localized fields, or the main fields for English and languages without
+// a dedicated analyzer
+func languageFields(fields []string, language string) []string {
+	if _, ok := languageAnalyzers[language]; !ok {
+		return fields
+	}
+
+	localized := make([]string, len(fields))
+	for i, field := range fields {
+		name, boost, _ := strings.Cut(field, "^")
+		localized[i] = localizedField(name, language)
+		if boost != "" {
+			localized[i] += "^" + boost
+		}
+	}
+	return localized
+}
+
+// buildLanguageFilter matches articles indexed under language. Articles
+// indexed before languages were recorded count as English.
+func buildLanguageFilter(language string) map[string]interface{} {
+	filter := map[string]interface{}{
+		"term": map[string]interface{}{
+			"language": language,
+		},
+	}
+	if language != defaultLanguage {
+		return filter
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []map[string]interface{}{
+				filter,
+				{
+					"bool": map[string]interface{}{
+						"must_not": map[string]interface{}{
+							"exists": map[string]interface{}{
+								"field": "language",
+							},
+						},
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// buildAnalysis builds the analysis settings defining newsAnalyzer and
+// newsSearchAnalyzer
+func (r *SearchRepository) buildAnalysis() map[string]interface{} {
+	searchFilters := []string{"lowercase"}
+	filters := map[string]interface{}{}
+	if len(r.synonyms) > 0 {
+		filters[newsSynonymFilter] = map[string]interface{}{
+			"type":     "synonym_graph",
+			"synonyms": r.synonyms,
+		}
+		searchFilters = append(searchFilters, newsSynonymFilter)
+	}
+	searchFilters = append(searchFilters, "stop", "snowball")
+
+	return map[string]interface{}{
+		"filter": filters,
+		"analyzer": map[string]interface{}{
+			newsAnalyzer: map[string]interface{}{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"lowercase", "stop", "snowball"},
+			},
+			newsSearchAnalyzer: map[string]interface{}{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    searchFilters,
+			},
+		},
+	}
+}
+
 func (r *SearchRepository) initIndex(ctx context.Context) error {
 	r.logger.Info().Str("index", r.index).Msg("Initializing Elasticsearch index")
 
@@ -66,7 +236,8 @@ func (r *SearchRepository) initIndex(ctx context.Context) error {
 	}
 	defer res.Body.Close()
 
-	// If index exists, return
+	// If index exists, return; shard, replica and analyzer settings only
+	// apply to new indices, changing them requires a reindex
 	if res.StatusCode == 200 {
 		r.logger.Info().Str("index", r.index).Msg("Index already exists")
 		return nil
@@ -75,10 +246,11 @@ func (r *SearchRepository) initIndex(ctx context.Context) error {
 	// Create index with mapping
 	mapping := map[string]interface{}{
 		"mappings": map[string]interface{}{
-			"properties": map[string]interface{}{
+			"properties": addLanguageFields(map[string]interface{}{
 				"title": map[string]interface{}{
-					"type":     "text",
-					"analyzer": "standard",
+					"type":            "text",
+					"analyzer":        newsAnalyzer,
+					"search_analyzer": newsSearchAnalyzer,
 					"fields": map[string]interface{}{
 						"keyword": map[string]interface{}{
 							"type": "keyword",
@@ -86,12 +258,17 @@ func (r *SearchRepository) initIndex(ctx context.Context) error {
 					},
 				},
 				"content": map[string]interface{}{
-					"type":     "text",
-					"analyzer": "standard",
+					"type":            "text",
+					"analyzer":        newsAnalyzer,
+					"search_analyzer": newsSearchAnalyzer,
 				},
 				"summary": map[string]interface{}{
-					"type":     "text",
-					"analyzer": "standard",
+					"type":            "text",
+					"analyzer":        newsAnalyzer,
+					"search_analyzer": newsSearchAnalyzer,
+				},
+				"language": map[string]interface{}{
+					"type": "keyword",
 				},
 				"author": map[string]interface{}{
 					"type": "keyword",
@@ -119,24 +296,15 @@ func (r *SearchRepository) initIndex(ctx context.Context) error {
 				"created_at": map[string]interface{}{
 					"type": "date",
 				},
-			},
+				"popularity": map[string]interface{}{
+					"type": "float",
+				},
+			}),
 		},
 		"settings": map[string]interface{}{
-			"number_of_shards":   1,
-			"number_of_replicas": 0,
-			"analysis": map[string]interface{}{
-				"analyzer": map[string]interface{}{
-					"news_analyzer": map[string]interface{}{
-						"type":      "custom",
-						"tokenizer": "standard",
-						"filter": []string{
-							"lowercase",
-							"stop",
-							"snowball",
-						},
-					},
-				},
-			},
+			"number_of_shards":   r.shards,
+			"number_of_replicas": r.replicas,
+			"analysis":           r.buildAnalysis(),
 		},
 	}
 
@@ -160,14 +328,133 @@ func (r *SearchRepository) initIndex(ctx context.Context) error {
 		return fmt.Errorf("failed to create index: %s", res.String())
 	}
 
-	r.logger.Info().Str("index", r.index).Msg("Index created successfully")
+	r.logger.Info().Str("index", r.index).Int("shards", r.shards).Int("replicas", r.replicas).Msg("Index created successfully")
 	return nil
 }
 
 func (r *SearchRepository) IndexNews(ctx context.Context, news *models.News) error {
 	r.logger.Debug().Str("id", news.ID).Str("title", news.Title).Msg("Indexing news")
 
-	// Prepare document for indexing
+	docJSON, err := json.Marshal(newsDocument(news))
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      r.index,
+		DocumentID: news.ID,
+		Body:       bytes.NewReader(docJSON),
+		Refresh:    "true",
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return fmt.Errorf("failed to index document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to index document: %s", res.String())
+	}
+
+	return nil
+}
+
+// BulkIndex indexes many articles with a single _bulk request and refreshes
+// the index once at the end. Documents rejected by Elasticsearch are logged
+// and counted; they do not fail the rest of the batch.
+func (r *SearchRepository) BulkIndex(ctx context.Context, items []models.News) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	r.logger.Debug().Int("count", len(items)).Msg("Bulk indexing news")
+
+	var body bytes.Buffer
+	for i := range items {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{
+				"_index": r.index,
+				"_id":    items[i].ID,
+			},
+		}
+
+		actionJSON, err := json.Marshal(action)
+		if err != nil {
+			return fmt.Errorf("failed to marshal bulk action: %w", err)
+		}
+
+		docJSON, err := json.Marshal(newsDocument(&items[i]))
+		if err != nil {
+			return fmt.Errorf("failed to marshal document: %w", err)
+		}
+
+		body.Write(actionJSON)
+		body.WriteByte('\n')
+		body.Write(docJSON)
+		body.WriteByte('\n')
+	}
+
+	req := esapi.BulkRequest{
+		Body:    &body,
+		Refresh: "true",
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return fmt.Errorf("failed to execute bulk request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to bulk index documents: %s", res.String())
+	}
+
+	var bulkResult struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&bulkResult); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+
+	failed := 0
+	if bulkResult.Errors {
+		for _, item := range bulkResult.Items {
+			for _, result := range item {
+				if result.Status < 300 {
+					continue
+				}
+				failed++
+				r.logger.Warn().
+					Str("id", result.ID).
+					Int("status", result.Status).
+					Str("error_type", result.Error.Type).
+					Str("reason", result.Error.Reason).
+					Msg("Failed to index document in bulk request")
+			}
+		}
+	}
+
+	r.logger.Info().
+		Int("indexed", len(items)-failed).
+		Int("failed", failed).
+		Msg("Bulk indexing completed")
+
+	return nil
+}
+
+// newsDocument builds the search document stored for an article. The text
+// is also copied into the localized fields of the article's language.
+func newsDocument(news *models.News) map[string]interface{} {
+	language := documentLanguage(news)
 	doc := map[string]interface{}{
 		"title":        news.Title,
 		"content":      news.Content,
@@ -180,30 +467,104 @@ func (r *SearchRepository) IndexNews(ctx context.Context, news *models.News) err
 		"image_url":    news.ImageURL,
 		"published_at": news.PublishedAt,
 		"created_at":   news.CreatedAt,
+		"language":     language,
 	}
 
-	docJSON, err := json.Marshal(doc)
-	if err != nil {
-		return fmt.Errorf("failed to marshal document: %w", err)
+	if _, ok := languageAnalyzers[language]; ok {
+		for _, field := range localizedTextFields {
+			doc[localizedField(field, language)] = doc[field]
+		}
 	}
+	return doc
+}
 
-	req := esapi.IndexRequest{
-		Index:      r.index,
-		DocumentID: news.ID,
-		Body:       bytes.NewReader(docJSON),
-		Refresh:    "true",
+// documentLanguage returns the language an article is indexed under
+func documentLanguage(news *models.News) string {
+	if news.Language == "" {
+		return defaultLanguage
+	}
+	return strings.ToLower(news.Language)
+}
+
+// UpdatePopularity sets the popularity field of already indexed articles
+// with a single _bulk request. Articles missing from the index are skipped.
+func (r *SearchRepository) UpdatePopularity(ctx context.Context, scores map[string]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	for id, score := range scores {
+		action := map[string]interface{}{
+			"update": map[string]interface{}{
+				"_index": r.index,
+				"_id":    id,
+			},
+		}
+		doc := map[string]interface{}{
+			"doc": map[string]interface{}{
+				"popularity": score,
+			},
+		}
+
+		actionJSON, err := json.Marshal(action)
+		if err != nil {
+			return fmt.Errorf("failed to marshal bulk action: %w", err)
+		}
+		docJSON, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal popularity update: %w", err)
+		}
+
+		body.Write(actionJSON)
+		body.WriteByte('\n')
+		body.Write(docJSON)
+		body.WriteByte('\n')
+	}
+
+	req := esapi.BulkRequest{
+		Body: &body,
 	}
 
 	res, err := req.Do(ctx, r.client)
 	if err != nil {
-		return fmt.Errorf("failed to index document: %w", err)
+		return fmt.Errorf("failed to execute bulk popularity update: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("failed to index document: %s", res.String())
+		return fmt.Errorf("failed to update popularity: %s", res.String())
+	}
+
+	var bulkResult struct {
+		Items []map[string]struct {
+			Status int `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&bulkResult); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+
+	updated, missing, failed := 0, 0, 0
+	for _, item := range bulkResult.Items {
+		for _, result := range item {
+			switch {
+			case result.Status < 300:
+				updated++
+			case result.Status == 404:
+				missing++
+			default:
+				failed++
+			}
+		}
 	}
 
+	r.logger.Debug().
+		Int("updated", updated).
+		Int("missing", missing).
+		Int("failed", failed).
+		Msg("Popularity update completed")
+
 	return nil
 }
 
@@ -236,47 +597,220 @@ func (r *SearchRepository) DeleteFromIndex(ctx context.Context, newsID string) e
 	return nil
 }
 
-func (r *SearchRepository) Search(ctx context.Context, query string, page, limit int) ([]models.News, int64, error) {
-	r.logger.Debug().Str("query", query).Int("page", page).Int("limit", limit).Msg("Performing search")
+// MissingFromIndex returns the IDs that have no document in the index
+func (r *SearchRepository) MissingFromIndex(ctx context.Context, ids []string) ([]string, error) {
+	missing := []string{}
+	if len(ids) == 0 {
+		return missing, nil
+	}
 
-	from := (page - 1) * limit
+	body, err := json.Marshal(map[string]interface{}{"ids": ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mget request: %w", err)
+	}
 
-	// Build search query with 7-day filter
-	sevenDaysAgo := time.Now().AddDate(0, 0, -7)
-	
-	searchQuery := map[string]interface{}{
-		"query": map[string]interface{}{
+	req := esapi.MgetRequest{
+		Index:  r.index,
+		Body:   bytes.NewReader(body),
+		Source: []string{"false"},
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute mget: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("mget error: %s", res.String())
+	}
+
+	var result struct {
+		Docs []struct {
+			ID    string `json:"_id"`
+			Found bool   `json:"found"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode mget result: %w", err)
+	}
+
+	for _, doc := range result.Docs {
+		if !doc.Found {
+			missing = append(missing, doc.ID)
+		}
+	}
+
+	return missing, nil
+}
+
+// DeleteOlderThan removes all documents published before the cutoff and
+// returns how many were deleted. Version conflicts from concurrent indexing
+// are skipped rather than aborting the request.
+func (r *SearchRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error) {
+	r.logger.Debug().Time("cutoff", cutoff).Msg("Deleting old documents from index")
+
+	query := map[string]interface{}{
+		"range": map[string]interface{}{
+			"published_at": map[string]interface{}{
+				"lt": cutoff,
+			},
+		},
+	}
+
+	deleted, err := r.deleteByQuery(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	r.logger.Info().Int("deleted_count", deleted).Time("cutoff_date", cutoff).Msg("Index cleanup completed")
+	return deleted, nil
+}
+
+// DeleteExpired removes documents past their retention period, using the
+// per-source override where one is configured, and returns how many were
+// deleted.
+func (r *SearchRepository) DeleteExpired(ctx context.Context, retention time.Duration, sourceRetentions map[string]time.Duration) (int, error) {
+	r.logger.Debug().Dur("retention", retention).Int("source_overrides", len(sourceRetentions)).Msg("Deleting expired documents from index")
+
+	now := time.Now()
+	overridden := make([]string, 0, len(sourceRetentions))
+	should := make([]map[string]interface{}, 0, len(sourceRetentions)+1)
+	for source, sourceRetention := range sourceRetentions {
+		overridden = append(overridden, source)
+		should = append(should, map[string]interface{}{
 			"bool": map[string]interface{}{
-				"must": map[string]interface{}{
-					"multi_match": map[string]interface{}{
-						"query":  query,
-						"fields": []string{"title^3", "content^2", "summary^2", "author", "category", "tags"},
-						"type":   "best_fields",
-					},
-				},
-				"filter": map[string]interface{}{
-					"range": map[string]interface{}{
-						"published_at": map[string]interface{}{
-							"gte": sevenDaysAgo,
-						},
-					},
+				"filter": []map[string]interface{}{
+					{"term": map[string]interface{}{"source": source}},
+					{"range": map[string]interface{}{"published_at": map[string]interface{}{"lt": now.Add(-sourceRetention)}}},
 				},
 			},
+		})
+	}
+
+	cutoff := now.Add(-retention)
+	defaultClause := map[string]interface{}{
+		"filter": []map[string]interface{}{
+			{"range": map[string]interface{}{"published_at": map[string]interface{}{"lt": cutoff}}},
 		},
-		"highlight": map[string]interface{}{
-			"fields": map[string]interface{}{
-				"title":   map[string]interface{}{},
-				"content": map[string]interface{}{},
-				"summary": map[string]interface{}{},
+	}
+	if len(overridden) > 0 {
+		defaultClause["must_not"] = []map[string]interface{}{
+			{"terms": map[string]interface{}{"source": overridden}},
+		}
+	}
+	should = append(should, map[string]interface{}{"bool": defaultClause})
+
+	query := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+
+	deleted, err := r.deleteByQuery(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	r.logger.Info().Int("deleted_count", deleted).Time("cutoff_date", cutoff).Msg("Index cleanup completed")
+	return deleted, nil
+}
+
+// deleteByQuery deletes the documents matching query, skipping version
+// conflicts from concurrent indexing, and returns how many were deleted.
+func (r *SearchRepository) deleteByQuery(ctx context.Context, query map[string]interface{}) (int, error) {
+	queryJSON, err := json.Marshal(map[string]interface{}{"query": query})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal delete query: %w", err)
+	}
+
+	refresh := true
+	req := esapi.DeleteByQueryRequest{
+		Index:     []string{r.index},
+		Body:      bytes.NewReader(queryJSON),
+		Conflicts: "proceed",
+		Refresh:   &refresh,
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute delete by query: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("failed to delete old documents: %s", res.String())
+	}
+
+	var result struct {
+		Deleted int `json:"deleted"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode delete by query result: %w", err)
+	}
+
+	return result.Deleted, nil
+}
+
+func (r *SearchRepository) Search(ctx context.Context, query string, page, limit int) ([]models.News, int64, error) {
+	return r.SearchInRange(ctx, query, time.Time{}, time.Time{}, false, page, limit)
+}
+
+// SearchInRange performs a basic search restricted to the given published_at
+// range. When no range is given the configured search window applies, and
+// when the window is disabled all articles are searched. Exact disables
+// typo-tolerant matching.
+func (r *SearchRepository) SearchInRange(ctx context.Context, query string, dateFrom, dateTo time.Time, exact bool, page, limit int) ([]models.News, int64, error) {
+	r.logger.Debug().Str("query", query).Bool("exact", exact).Int("page", page).Int("limit", limit).Msg("Performing search")
+
+	from := (page - 1) * limit
+
+	boolQuery := map[string]interface{}{
+		"must": r.buildTextQuery(query, []string{"title^3", "content^2", "summary^2", "author", "category", "tags"}, exact),
+	}
+
+	if dateRange := r.searchDateRange(dateFrom, dateTo); dateRange != nil {
+		boolQuery["filter"] = map[string]interface{}{
+			"range": map[string]interface{}{
+				"published_at": dateRange,
+			},
+		}
+	}
+
+	finalQuery := map[string]interface{}{
+		"bool": boolQuery,
+	}
+
+	sort := []map[string]interface{}{
+		{
+			"published_at": map[string]interface{}{
+				"order": "desc",
 			},
 		},
-		"sort": []map[string]interface{}{
+	}
+
+	if r.popularityWeight > 0 {
+		finalQuery = r.buildPopularityQuery(finalQuery)
+		sort = append([]map[string]interface{}{
 			{
-				"published_at": map[string]interface{}{
+				"_score": map[string]interface{}{
 					"order": "desc",
 				},
 			},
+		}, sort...)
+	}
+
+	searchQuery := map[string]interface{}{
+		"query": finalQuery,
+		"highlight": map[string]interface{}{
+			"fields": map[string]interface{}{
+				"title":   map[string]interface{}{},
+				"content": map[string]interface{}{},
+				"summary": map[string]interface{}{},
+			},
 		},
+		"sort": sort,
 		"from": from,
 		"size": limit,
 	}
@@ -309,23 +843,107 @@ func (r *SearchRepository) Search(ctx context.Context, query string, page, limit
 	return r.parseSearchResult(searchResult)
 }
 
+// MoreLikeThis returns up to limit indexed articles whose title and content
+// resemble those of the article with the given ID, most similar first. The
+// seed article itself is never returned. An article that is not in the
+// index has no similar articles rather than being an error.
+func (r *SearchRepository) MoreLikeThis(ctx context.Context, id string, limit int) ([]models.News, error) {
+	r.logger.Debug().Str("id", id).Int("limit", limit).Msg("Finding similar articles")
+
+	exists, err := esapi.ExistsRequest{Index: r.index, DocumentID: id}.Do(ctx, r.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check seed document: %w", err)
+	}
+	exists.Body.Close()
+
+	if exists.StatusCode == http.StatusNotFound {
+		return []models.News{}, nil
+	}
+	if exists.IsError() {
+		return nil, fmt.Errorf("failed to check seed document: %s", exists.String())
+	}
+
+	searchQuery := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"more_like_this": map[string]interface{}{
+						"fields": []string{"title", "content"},
+						"like": []map[string]interface{}{
+							{"_index": r.index, "_id": id},
+						},
+						"min_term_freq":   1,
+						"min_doc_freq":    2,
+						"max_query_terms": 25,
+					},
+				},
+				"must_not": map[string]interface{}{
+					"ids": map[string]interface{}{
+						"values": []string{id},
+					},
+				},
+			},
+		},
+		"size": limit,
+	}
+
+	queryJSON, err := json.Marshal(searchQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal more like this query: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.index},
+		Body:  bytes.NewReader(queryJSON),
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute more like this query: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("more like this error: %s", res.String())
+	}
+
+	var searchResult map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&searchResult); err != nil {
+		return nil, fmt.Errorf("failed to decode more like this result: %w", err)
+	}
+
+	news, _, err := r.parseSearchResult(searchResult)
+	return news, err
+}
+
 func (r *SearchRepository) AdvancedSearch(ctx context.Context, searchQuery models.SearchQuery) (*models.SearchResult, error) {
-	r.logger.Debug().Interface("query", searchQuery).Msg("Performing advanced search")
+	return r.advancedSearch(ctx, searchQuery, false)
+}
+
+// SearchWithFacets runs an advanced search and also returns the category,
+// source, author, tag and publication date counts of the matching articles
+func (r *SearchRepository) SearchWithFacets(ctx context.Context, searchQuery models.SearchQuery) (*models.SearchResult, error) {
+	return r.advancedSearch(ctx, searchQuery, true)
+}
+
+func (r *SearchRepository) advancedSearch(ctx context.Context, searchQuery models.SearchQuery, withFacets bool) (*models.SearchResult, error) {
+	r.logger.Debug().Interface("query", searchQuery).Bool("facets", withFacets).Msg("Performing advanced search")
 
 	from := (searchQuery.Page - 1) * searchQuery.Limit
 
 	// Build advanced search query
 	mustQueries := []map[string]interface{}{}
 
-	// Text query
+	// Text query, against the language's analyzed fields when one is given
+	language := strings.ToLower(strings.TrimSpace(searchQuery.Language))
 	if searchQuery.Query != "" {
-		mustQueries = append(mustQueries, map[string]interface{}{
-			"multi_match": map[string]interface{}{
-				"query":  searchQuery.Query,
-				"fields": []string{"title^3", "content^2", "summary^2"},
-				"type":   "best_fields",
-			},
-		})
+		fields := languageFields([]string{"title^3", "content^2", "summary^2"}, language)
+		mustQueries = append(mustQueries, r.buildTextQuery(searchQuery.Query, fields, searchQuery.Exact))
+	}
+
+	// Language filter
+	if language != "" {
+		mustQueries = append(mustQueries, buildLanguageFilter(language))
 	}
 
 	// Category filter
@@ -346,6 +964,24 @@ func (r *SearchRepository) AdvancedSearch(ctx context.Context, searchQuery model
 		})
 	}
 
+	// Tag filter
+	if len(searchQuery.Tags) > 0 {
+		mustQueries = append(mustQueries, map[string]interface{}{
+			"terms": map[string]interface{}{
+				"tags": searchQuery.Tags,
+			},
+		})
+	}
+
+	// Author filter
+	if len(searchQuery.Authors) > 0 {
+		mustQueries = append(mustQueries, map[string]interface{}{
+			"terms": map[string]interface{}{
+				"author": searchQuery.Authors,
+			},
+		})
+	}
+
 	// Date range filter
 	if !searchQuery.DateFrom.IsZero() || !searchQuery.DateTo.IsZero() {
 		dateRange := map[string]interface{}{}
@@ -379,26 +1015,55 @@ func (r *SearchRepository) AdvancedSearch(ctx context.Context, searchQuery model
 		}
 	}
 
-	esQuery := map[string]interface{}{
-		"query": finalQuery,
-		"highlight": map[string]interface{}{
-			"fields": map[string]interface{}{
-				"title":   map[string]interface{}{},
-				"content": map[string]interface{}{},
-				"summary": map[string]interface{}{},
+	sort := []map[string]interface{}{
+		{
+			"published_at": map[string]interface{}{
+				"order": "desc",
 			},
 		},
-		"sort": []map[string]interface{}{
+	}
+
+	// Personalization only re-ranks the matched documents: the boosts are
+	// applied through function_score so the filters above stay untouched.
+	if searchQuery.IsPersonalized() {
+		finalQuery = r.buildPersonalizedQuery(finalQuery, searchQuery.Preferences)
+	}
+
+	// Popularity blending likewise only affects the relevance score
+	if r.popularityWeight > 0 {
+		finalQuery = r.buildPopularityQuery(finalQuery)
+	}
+
+	if searchQuery.IsPersonalized() || r.popularityWeight > 0 {
+		sort = append([]map[string]interface{}{
 			{
-				"published_at": map[string]interface{}{
+				"_score": map[string]interface{}{
 					"order": "desc",
 				},
 			},
+		}, sort...)
+	}
+
+	highlightFields := map[string]interface{}{}
+	for _, field := range languageFields([]string{"title", "content", "summary"}, language) {
+		highlightFields[field] = map[string]interface{}{}
+	}
+
+	esQuery := map[string]interface{}{
+		"query": finalQuery,
+		"highlight": map[string]interface{}{
+			"fields": highlightFields,
 		},
+		"sort": sort,
 		"from": from,
 		"size": searchQuery.Limit,
 	}
 
+	now := time.Now().UTC().Truncate(time.Second)
+	if withFacets {
+		esQuery["aggs"] = r.buildFacetAggregations(now)
+	}
+
 	queryJSON, err := json.Marshal(esQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal advanced search query: %w", err)
@@ -429,10 +1094,236 @@ func (r *SearchRepository) AdvancedSearch(ctx context.Context, searchQuery model
 		return nil, err
 	}
 
-	return &models.SearchResult{
+	result := &models.SearchResult{
 		News:  news,
 		Total: total,
-	}, nil
+		Page:  searchQuery.Page,
+		Limit: searchQuery.Limit,
+		Query: searchQuery.Query,
+	}
+	if took, ok := searchResult["took"].(float64); ok {
+		result.Took = time.Duration(took) * time.Millisecond
+	}
+	if withFacets {
+		result.Facets = parseFacets(searchResult["aggregations"], now)
+	}
+
+	return result, nil
+}
+
+// facetSize is the number of values returned per term facet
+const facetSize = 10
+
+// facetDateRanges are the publication date buckets of the date facet,
+// as label and age
+var facetDateRanges = []struct {
+	label string
+	age   time.Duration
+}{
+	{"Last 24 hours", 24 * time.Hour},
+	{"Last 7 days", 7 * 24 * time.Hour},
+	{"Last 30 days", 30 * 24 * time.Hour},
+}
+
+// buildFacetAggregations builds the aggregations behind searchModels.Facets. Date
+// ranges are computed from now so their bounds match what parseFacets reports.
+func (r *SearchRepository) buildFacetAggregations(now time.Time) map[string]interface{} {
+	terms := func(field string) map[string]interface{} {
+		return map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": field,
+				"size":  facetSize,
+			},
+		}
+	}
+
+	ranges := make([]map[string]interface{}, 0, len(facetDateRanges))
+	for _, dr := range facetDateRanges {
+		ranges = append(ranges, map[string]interface{}{
+			"key":  dr.label,
+			"from": now.Add(-dr.age).Format(time.RFC3339),
+		})
+	}
+
+	return map[string]interface{}{
+		"categories": terms("category"),
+		"sources":    terms("source"),
+		"authors":    terms("author"),
+		"tags":       terms("tags"),
+		"date_ranges": map[string]interface{}{
+			"date_range": map[string]interface{}{
+				"field":  "published_at",
+				"ranges": ranges,
+			},
+		},
+	}
+}
+
+// parseFacets converts the aggregations of a SearchWithFacets response.
+// Missing or malformed aggregations yield empty facets rather than an error.
+func parseFacets(raw interface{}, now time.Time) *searchModels.Facets {
+	facets := &searchModels.Facets{
+		Categories: []searchModels.FacetItem{},
+		Sources:    []searchModels.FacetItem{},
+		Authors:    []searchModels.FacetItem{},
+		Tags:       []searchModels.FacetItem{},
+		DateRanges: []searchModels.DateRange{},
+	}
+
+	aggs, ok := raw.(map[string]interface{})
+	if !ok {
+		return facets
+	}
+
+	buckets := func(name string) []interface{} {
+		agg, ok := aggs[name].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		b, _ := agg["buckets"].([]interface{})
+		return b
+	}
+
+	terms := func(name string) []searchModels.FacetItem {
+		items := []searchModels.FacetItem{}
+		for _, b := range buckets(name) {
+			bucket, ok := b.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value, ok := bucket["key"].(string)
+			if !ok || value == "" {
+				continue
+			}
+			count, _ := bucket["doc_count"].(float64)
+			items = append(items, searchModels.FacetItem{Value: value, Count: int64(count)})
+		}
+		return items
+	}
+
+	facets.Categories = terms("categories")
+	facets.Sources = terms("sources")
+	facets.Authors = terms("authors")
+	facets.Tags = terms("tags")
+
+	counts := make(map[string]int64)
+	for _, b := range buckets("date_ranges") {
+		if bucket, ok := b.(map[string]interface{}); ok {
+			key, _ := bucket["key"].(string)
+			count, _ := bucket["doc_count"].(float64)
+			counts[key] = int64(count)
+		}
+	}
+	for _, dr := range facetDateRanges {
+		facets.DateRanges = append(facets.DateRanges, searchModels.DateRange{
+			Label: dr.label,
+			From:  now.Add(-dr.age),
+			To:    now,
+			Count: counts[dr.label],
+		})
+	}
+
+	return facets
+}
+
+// buildTextQuery builds the multi_match clause for a free-text query. Fuzzy
+// matching is enabled unless exact is set or the query is too short for edit
+// distance to be meaningful; the first character must always match so typos
+// don't pull in unrelated terms.
+func (r *SearchRepository) buildTextQuery(query string, fields []string, exact bool) map[string]interface{} {
+	multiMatch := map[string]interface{}{
+		"query":  query,
+		"fields": fields,
+		"type":   "best_fields",
+	}
+
+	if !exact && utf8.RuneCountInString(strings.TrimSpace(query)) >= minFuzzyQueryLength {
+		multiMatch["fuzziness"] = "AUTO"
+		multiMatch["prefix_length"] = fuzzyPrefixLength
+		multiMatch["max_expansions"] = fuzzyMaxExpansions
+	}
+
+	return map[string]interface{}{
+		"multi_match": multiMatch,
+	}
+}
+
+// searchDateRange returns the published_at range for a basic search, or nil
+// when the search should not be restricted by date.
+func (r *SearchRepository) searchDateRange(dateFrom, dateTo time.Time) map[string]interface{} {
+	if !dateFrom.IsZero() || !dateTo.IsZero() {
+		dateRange := map[string]interface{}{}
+		if !dateFrom.IsZero() {
+			dateRange["gte"] = dateFrom
+		}
+		if !dateTo.IsZero() {
+			dateRange["lte"] = dateTo
+		}
+		return dateRange
+	}
+
+	if r.searchWindow <= 0 {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"gte": time.Now().Add(-r.searchWindow),
+	}
+}
+
+// buildPersonalizedQuery wraps a query in a function_score that boosts
+// documents from the user's preferred categories and sources.
+func (r *SearchRepository) buildPersonalizedQuery(query map[string]interface{}, prefs *models.Preferences) map[string]interface{} {
+	functions := []map[string]interface{}{}
+
+	if len(prefs.Categories) > 0 {
+		functions = append(functions, map[string]interface{}{
+			"filter": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"category": prefs.Categories,
+				},
+			},
+			"weight": preferredCategoryBoost,
+		})
+	}
+
+	if len(prefs.Sources) > 0 {
+		functions = append(functions, map[string]interface{}{
+			"filter": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"source": prefs.Sources,
+				},
+			},
+			"weight": preferredSourceBoost,
+		})
+	}
+
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query":      query,
+			"functions":  functions,
+			"score_mode": "sum",
+			"boost_mode": "multiply",
+		},
+	}
+}
+
+// buildPopularityQuery wraps a query in a function_score that adds the
+// article's synced popularity, scaled by the configured weight, to its
+// relevance. Articles without a synced score get no boost.
+func (r *SearchRepository) buildPopularityQuery(query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query": query,
+			"field_value_factor": map[string]interface{}{
+				"field":    "popularity",
+				"factor":   r.popularityWeight,
+				"modifier": "log1p",
+				"missing":  0,
+			},
+			"boost_mode": "sum",
+		},
+	}
 }
 
 func (r *SearchRepository) GetSuggestions(ctx context.Context, query string, limit int) ([]string, error) {
@@ -511,20 +1402,29 @@ func (r *SearchRepository) parseSearchResult(result map[string]interface{}) ([]m
 		return nil, 0, fmt.Errorf("invalid hits format")
 	}
 
+	// Malformed hits are skipped so one bad document cannot fail the search
 	var news []models.News
-	for _, doc := range documents {
+	for i, doc := range documents {
 		docMap, ok := doc.(map[string]interface{})
 		if !ok {
+			r.logger.Warn().Int("hit", i).Msg("Skipping search hit that is not an object")
+			continue
+		}
+
+		id, ok := docMap["_id"].(string)
+		if !ok || id == "" {
+			r.logger.Warn().Int("hit", i).Msg("Skipping search hit without an _id")
 			continue
 		}
 
 		source, ok := docMap["_source"].(map[string]interface{})
 		if !ok {
+			r.logger.Warn().Int("hit", i).Str("id", id).Msg("Skipping search hit without a _source")
 			continue
 		}
 
 		var n models.News
-		n.ID = docMap["_id"].(string)
+		n.ID = id
 
 		if title, ok := source["title"].(string); ok {
 			n.Title = title
@@ -550,6 +1450,9 @@ func (r *SearchRepository) parseSearchResult(result map[string]interface{}) ([]m
 		if imageURL, ok := source["image_url"].(string); ok {
 			n.ImageURL = imageURL
 		}
+		if language, ok := source["language"].(string); ok {
+			n.Language = language
+		}
 
 		// Parse tags
 		if tags, ok := source["tags"].([]interface{}); ok {
@@ -560,8 +1463,50 @@ func (r *SearchRepository) parseSearchResult(result map[string]interface{}) ([]m
 			}
 		}
 
+		n.Highlights = parseHighlights(docMap["highlight"])
+
 		news = append(news, n)
 	}
 
 	return news, int64(totalValue), nil
 }
+
+// unlocalizedField returns the main field of a localized field, and any
+// other field unchanged
+func unlocalizedField(field string) string {
+	if i := strings.LastIndex(field, "_"); i > 0 {
+		if _, ok := languageAnalyzers[field[i+1:]]; ok {
+			return field[:i]
+		}
+	}
+	return field
+}
+
+// parseHighlights extracts the highlight fragments of a search hit, keyed
+// by field with localized fields folded into their main field (content_hi
+// becomes content). It returns nil when the hit has no highlights.
+func parseHighlights(raw interface{}) map[string][]string {
+	fields, ok := raw.(map[string]interface{})
+	if !ok || len(fields) == 0 {
+		return nil
+	}
+
+	highlights := make(map[string][]string, len(fields))
+	for field, value := range fields {
+		fragments, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		field = unlocalizedField(field)
+		for _, fragment := range fragments {
+			if fragmentStr, ok := fragment.(string); ok {
+				highlights[field] = append(highlights[field], fragmentStr)
+			}
+		}
+	}
+
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/models"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+func TestParseSearchResultSkipsMalformedHits(t *testing.T) {
+	body := `{
+		"hits": {
+			"total": {"value": 5},
+			"hits": [
+				{"_id": "a", "_source": {"title": "First", "category": "technology"}},
+				"not an object",
+				{"_source": {"title": "No ID"}},
+				{"_id": 42, "_source": {"title": "Numeric ID"}},
+				{"_id": "b"},
+				{"_id": "c", "_source": "not an object"},
+				{"_id": "d", "_source": {"title": "Last", "tags": ["go", 1]}}
+			]
+		}
+	}`
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+
+	repo := &SearchRepository{logger: zerolog.Nop()}
+	news, total, err := repo.parseSearchResult(result)
+	if err != nil {
+		t.Fatalf("parseSearchResult returned error: %v", err)
+	}
+
+	if total != 5 {
+		t.Errorf("total = %d, want 5", total)
+	}
+	if len(news) != 2 {
+		t.Fatalf("got %d articles, want 2", len(news))
+	}
+	if news[0].ID != "a" || news[0].Title != "First" || news[0].Category != "technology" {
+		t.Errorf("first article = %+v", news[0])
+	}
+	if news[1].ID != "d" || news[1].Title != "Last" {
+		t.Errorf("second article = %+v", news[1])
+	}
+}
+
+func TestParseSearchResultRejectsMalformedEnvelope(t *testing.T) {
+	tests := map[string]string{
+		"no hits":        `{}`,
+		"no total":       `{"hits": {"hits": []}}`,
+		"string total":   `{"hits": {"total": {"value": "5"}, "hits": []}}`,
+		"hits not array": `{"hits": {"total": {"value": 0}, "hits": {}}}`,
+	}
+
+	repo := &SearchRepository{logger: zerolog.Nop()}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			var result map[string]interface{}
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("invalid fixture: %v", err)
+			}
+
+			if _, _, err := repo.parseSearchResult(result); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestNewsDocumentFillsOnlyItsLanguageFields(t *testing.T) {
+	doc := newsDocument(&models.News{Title: "Elecciones", Content: "Texto", Summary: "Resumen", Language: "ES"})
+
+	for _, field := range localizedTextFields {
+		if doc[localizedField(field, "es")] != doc[field] {
+			t.Errorf("%s = %v, want %v", localizedField(field, "es"), doc[localizedField(field, "es")], doc[field])
+		}
+		for language := range languageAnalyzers {
+			if _, ok := doc[localizedField(field, language)]; ok && language != "es" {
+				t.Errorf("document has %s", localizedField(field, language))
+			}
+		}
+	}
+
+	english := newsDocument(&models.News{Title: "Elections"})
+	for language := range languageAnalyzers {
+		if _, ok := english[localizedField("title", language)]; ok {
+			t.Errorf("English document has %s", localizedField("title", language))
+		}
+	}
+}
+
+func TestParseHighlightsFoldsLocalizedFields(t *testing.T) {
+	highlights := parseHighlights(map[string]interface{}{
+		"content_hi": []interface{}{"<em>a</em>"},
+		"content":    []interface{}{"<em>b</em>"},
+		"image_url":  []interface{}{"<em>c</em>"},
+	})
+
+	if got := highlights["image_url"]; !reflect.DeepEqual(got, []string{"<em>c</em>"}) {
+		t.Errorf("image_url highlights = %v, want it unchanged", got)
+	}
+	if got := highlights["content"]; len(got) != 2 {
+		t.Errorf("content highlights = %v, want both fragments", got)
+	}
+}
+
+// newTestSearchRepository creates a search repository on a fresh index of
+// the cluster in TEST_ELASTICSEARCH_URL, e.g. http://localhost:9200, and
+// skips the test when it is not set. The index is deleted when the test ends.
+func newTestSearchRepository(t *testing.T, synonyms []string) *SearchRepository {
+	t.Helper()
+
+	address := os.Getenv("TEST_ELASTICSEARCH_URL")
+	if address == "" {
+		t.Skip("TEST_ELASTICSEARCH_URL not set")
+	}
+
+	cfg := &config.Config{}
+	cfg.Elasticsearch.Addresses = []string{address}
+	cfg.Elasticsearch.Index = "news-test-" + uuid.NewString()
+	cfg.Elasticsearch.Shards = 1
+	cfg.Elasticsearch.Synonyms = synonyms
+
+	repo, err := NewSearchRepository(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("failed to create search repository: %v", err)
+	}
+	t.Cleanup(func() {
+		req := esapi.IndicesDeleteRequest{Index: []string{repo.index}}
+		if res, err := req.Do(context.Background(), repo.client); err == nil {
+			res.Body.Close()
+		}
+	})
+
+	return repo
+}
+
+// analyze returns the tokens the index's analyzer produces for text
+func analyze(t *testing.T, repo *SearchRepository, analyzer, text string) []string {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"analyzer": analyzer, "text": text})
+	if err != nil {
+		t.Fatalf("failed to encode analyze request: %v", err)
+	}
+
+	req := esapi.IndicesAnalyzeRequest{Index: repo.index, Body: bytes.NewReader(body)}
+	res, err := req.Do(context.Background(), repo.client)
+	if err != nil {
+		t.Fatalf("analyze request failed: %v", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("analyze request failed: %s", res.String())
+	}
+
+	var result struct {
+		Tokens []struct {
+			Token string `json:"token"`
+		} `json:"tokens"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatalf("invalid analyze response: %v", err)
+	}
+
+	tokens := make([]string, len(result.Tokens))
+	for i, token := range result.Tokens {
+		tokens[i] = token.Token
+	}
+	return tokens
+}
+
+func TestBuildAnalysisStemsIndexAndSearchText(t *testing.T) {
+	for _, synonyms := range [][]string{nil, {"usa, united states"}} {
+		repo := &SearchRepository{synonyms: synonyms}
+		analyzers := repo.buildAnalysis()["analyzer"].(map[string]interface{})
+
+		for _, name := range []string{newsAnalyzer, newsSearchAnalyzer} {
+			filters := analyzers[name].(map[string]interface{})["filter"].([]string)
+			if filters[len(filters)-1] != "snowball" {
+				t.Errorf("synonyms %v: %s filters = %v, want snowball last", synonyms, name, filters)
+			}
+		}
+	}
+}
+
+func TestStemmingMatchesWordForms(t *testing.T) {
+	repo := newTestSearchRepository(t, []string{"usa, united states"})
+
+	// "running" is indexed as the stem a search for "run" produces
+	indexed := analyze(t, repo, newsAnalyzer, "Running")
+	searched := analyze(t, repo, newsSearchAnalyzer, "run")
+	if !reflect.DeepEqual(indexed, searched) {
+		t.Errorf("indexed tokens %v, search tokens %v, want them equal", indexed, searched)
+	}
+
+	for _, form := range []string{"runs", "RUN"} {
+		if got := analyze(t, repo, newsSearchAnalyzer, form); !reflect.DeepEqual(got, indexed) {
+			t.Errorf("search tokens for %q = %v, want %v", form, got, indexed)
+		}
+	}
+}
+
+func TestMissingFromIndex(t *testing.T) {
+	repo := newTestSearchRepository(t, nil)
+
+	indexed := &models.News{ID: uuid.NewString(), Title: "Indexed", URL: "https://example.com/indexed", PublishedAt: time.Now()}
+	if err := repo.IndexNews(context.Background(), indexed); err != nil {
+		t.Fatalf("IndexNews: %v", err)
+	}
+
+	unindexed := uuid.NewString()
+	missing, err := repo.MissingFromIndex(context.Background(), []string{indexed.ID, unindexed})
+	if err != nil {
+		t.Fatalf("MissingFromIndex: %v", err)
+	}
+	if !reflect.DeepEqual(missing, []string{unindexed}) {
+		t.Errorf("missing = %v, want [%s]", missing, unindexed)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"news-aggregator/internal/config"
+	"news-aggregator/internal/db"
 	"news-aggregator/internal/models"
 
 	"github.com/jackc/pgx/v5"
@@ -18,30 +19,22 @@ type UserRepository struct {
 	logger zerolog.Logger
 }
 
+// NewUserRepository creates a user repository with its own connection pool.
+// Prefer NewUserRepositoryWithPool when other repositories share the database.
 func NewUserRepository(cfg *config.Config, logger zerolog.Logger) (*UserRepository, error) {
-	// Build connection string
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Database,
-		cfg.Database.SSLMode,
-	)
-
-	// Create connection pool
-	db, err := pgxpool.New(context.Background(), connStr)
+	pool, err := db.NewPool(cfg, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create database pool: %w", err)
+		return nil, err
 	}
 
-	// Test connection
-	if err := db.Ping(context.Background()); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
+	return NewUserRepositoryWithPool(pool, logger)
+}
 
+// NewUserRepositoryWithPool creates a user repository on an existing pool
+// and initializes its schema.
+func NewUserRepositoryWithPool(pool *pgxpool.Pool, logger zerolog.Logger) (*UserRepository, error) {
 	repo := &UserRepository{
-		db:     db,
+		db:     pool,
 		logger: logger.With().Str("component", "user_repository").Logger(),
 	}
 
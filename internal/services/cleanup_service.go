@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"news-aggregator/internal/config"
@@ -12,26 +13,31 @@ import (
 )
 
 type CleanupService struct {
-	config      *config.Config
-	logger      zerolog.Logger
-	newsService *NewsService
-	logRotator  *loggerPkg.LogRotator
+	config        *config.Config
+	logger        zerolog.Logger
+	newsService   *NewsService
+	searchService *SearchService
+	logRotator    *loggerPkg.LogRotator
 	ticker      *time.Ticker
 	done        chan bool
+	wg          sync.WaitGroup
 }
 
-func NewCleanupService(cfg *config.Config, logger zerolog.Logger, newsService *NewsService) *CleanupService {
+// NewCleanupService creates the cleanup service. searchService may be nil, in
+// which case the search index is not cleaned up.
+func NewCleanupService(cfg *config.Config, logger zerolog.Logger, newsService *NewsService, searchService *SearchService) *CleanupService {
 	// Get log files to rotate
 	logFiles := loggerPkg.GetLogFiles()
 	logRotator := loggerPkg.NewLogRotator(logger, logFiles)
 
 	return &CleanupService{
-		config:      cfg,
-		logger:      logger.With().Str("service", "cleanup").Logger(),
-		newsService: newsService,
-		logRotator:  logRotator,
-		ticker:      time.NewTicker(6 * time.Hour), // Run every 6 hours
-		done:        make(chan bool),
+		config:        cfg,
+		logger:        logger.With().Str("service", "cleanup").Logger(),
+		newsService:   newsService,
+		searchService: searchService,
+		logRotator:    logRotator,
+		ticker:        time.NewTicker(6 * time.Hour), // Run every 6 hours
+		done:          make(chan bool),
 	}
 }
 
@@ -45,7 +51,9 @@ func (cs *CleanupService) Start(ctx context.Context) error {
 	cs.performCleanup(ctx)
 
 	// Start periodic cleanup
+	cs.wg.Add(1)
 	go func() {
+		defer cs.wg.Done()
 		for {
 			select {
 			case <-cs.ticker.C:
@@ -72,19 +80,24 @@ func (cs *CleanupService) Stop() {
 	// Stop ticker
 	cs.ticker.Stop()
 	
-	// Signal done
-	cs.done <- true
+	// Signal done and wait for an in-progress cleanup to finish. The loop may
+	// already have exited on context cancellation, so close rather than send.
+	close(cs.done)
+	cs.wg.Wait()
 }
 
 func (cs *CleanupService) performCleanup(ctx context.Context) {
 	cs.logger.Info().Msg("Starting periodic cleanup")
 	
-	// Cleanup old database articles (older than 2 days)
+	// Cleanup database articles past their retention period
 	if err := cs.newsService.CleanupOldArticles(ctx); err != nil {
 		cs.logger.Error().Err(err).Msg("Failed to cleanup old articles from database")
 	} else {
 		cs.logger.Info().Msg("Database cleanup completed successfully")
 	}
+
+	// Keep the search index in sync with the database
+	cs.cleanupSearchIndex(ctx)
 	
 	cs.logger.Info().Msg("Periodic cleanup completed")
 }
@@ -97,6 +110,9 @@ func (cs *CleanupService) ManualCleanup(ctx context.Context) error {
 	if err := cs.newsService.CleanupOldArticles(ctx); err != nil {
 		return fmt.Errorf("failed to cleanup database: %w", err)
 	}
+
+	// Search index cleanup is best effort, the next run catches up
+	cs.cleanupSearchIndex(ctx)
 	
 	// Force log rotation check
 	cs.logRotator.Stop()
@@ -105,3 +121,20 @@ func (cs *CleanupService) ManualCleanup(ctx context.Context) error {
 	cs.logger.Info().Msg("Manual cleanup completed")
 	return nil
 }
+
+// cleanupSearchIndex removes articles past the retention period from the
+// search index. Failures are logged only so an unavailable Elasticsearch
+// never blocks the database cleanup.
+func (cs *CleanupService) cleanupSearchIndex(ctx context.Context) {
+	if cs.searchService == nil {
+		return
+	}
+
+	deleted, err := cs.searchService.DeleteExpired(ctx)
+	if err != nil {
+		cs.logger.Warn().Err(err).Msg("Failed to cleanup old articles from search index, will retry on next run")
+		return
+	}
+
+	cs.logger.Info().Int("deleted_count", deleted).Msg("Search index cleanup completed successfully")
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"news-aggregator/internal/config"
+	"news-aggregator/internal/models"
+
+	"github.com/rs/zerolog"
+)
+
+// maxLLMContentLength caps the article text sent to the LLM endpoint
+const maxLLMContentLength = 8000
+
+// LLMNLPClient performs content analysis through an OpenAI-compatible
+// chat completions endpoint. Every call falls back to SimpleNLPClient on
+// error or timeout so scoring never stalls on the external service.
+type LLMNLPClient struct {
+	logger     zerolog.Logger
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+	model      string
+	timeout    time.Duration
+	fallback   *SimpleNLPClient
+}
+
+// NewLLMNLPClient creates a new LLM-backed NLP client
+func NewLLMNLPClient(cfg config.NLPConfig, logger zerolog.Logger) *LLMNLPClient {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &LLMNLPClient{
+		logger: logger.With().Str("component", "llm_nlp_client").Logger(),
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		endpoint: strings.TrimRight(cfg.Endpoint, "/"),
+		apiKey:   cfg.APIKey,
+		model:    cfg.Model,
+		timeout:  timeout,
+		fallback: NewSimpleNLPClient(logger, cfg.StopWords),
+	}
+}
+
+// NewNLPClient returns the NLP client selected by cfg.NLP.Provider
+func NewNLPClient(cfg *config.Config, logger zerolog.Logger) NLPClient {
+	switch strings.ToLower(cfg.NLP.Provider) {
+	case "llm":
+		return NewLLMNLPClient(cfg.NLP, logger)
+	case "", "simple":
+		return NewSimpleNLPClient(logger, cfg.NLP.StopWords)
+	default:
+		logger.Warn().Str("provider", cfg.NLP.Provider).Msg("Unknown NLP provider, using simple client")
+		return NewSimpleNLPClient(logger, cfg.NLP.StopWords)
+	}
+}
+
+// llmAnalysis is the JSON document the model is asked to produce
+type llmAnalysis struct {
+	Summary    string            `json:"summary"`
+	Sentiment  float64           `json:"sentiment"`
+	Importance float64           `json:"importance"`
+	Keywords   []string          `json:"keywords"`
+	Entities   map[string]string `json:"entities"`
+	Topic      string            `json:"topic"`
+	Language   string            `json:"language"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+const analysisPrompt = `You analyze news articles. Respond with a single JSON object with these fields:
+"summary" (string, at most 2 sentences),
+"sentiment" (number from -1.0 to 1.0),
+"importance" (number from 0.0 to 1.0, how newsworthy the article is),
+"keywords" (array of at most 10 lowercase keywords),
+"entities" (object mapping entity text to PERSON, ORGANIZATION, LOCATION, DATE, MONEY or PERCENTAGE),
+"topic" (one of technology, business, politics, health, sports, science, entertainment, world, general),
+"language" (ISO 639-1 code).`
+
+// AnalyzeContent performs content analysis using the LLM endpoint
+func (c *LLMNLPClient) AnalyzeContent(ctx context.Context, title, content string) (*models.ContentAnalysis, error) {
+	result, err := c.analyze(ctx, title, content)
+	if err != nil {
+		c.logger.Warn().Err(err).Str("title", title).Msg("LLM analysis failed, falling back to simple client")
+		return c.fallback.AnalyzeContent(ctx, title, content)
+	}
+
+	analysis := &models.ContentAnalysis{
+		SentimentScore:      clamp(result.Sentiment, -1.0, 1.0),
+		ImportanceScore:     clamp(result.Importance, 0.0, 1.0),
+		ReadabilityScore:    c.fallback.calculateReadability(content),
+		KeywordsExtracted:   result.Keywords,
+		EntitiesExtracted:   result.Entities,
+		TopicClassification: result.Topic,
+		LanguageDetected:    result.Language,
+		ProcessedAt:         time.Now(),
+	}
+
+	if analysis.EntitiesExtracted == nil {
+		analysis.EntitiesExtracted = make(map[string]string)
+	}
+	if analysis.TopicClassification == "" {
+		analysis.TopicClassification = c.fallback.classifyTopic(title + " " + content)
+	}
+	if analysis.LanguageDetected == "" {
+		analysis.LanguageDetected = c.fallback.detectLanguage(content)
+	}
+
+	return analysis, nil
+}
+
+// Summarize returns a short summary of the article, falling back to the
+// leading sentences of the content when the LLM is unavailable
+func (c *LLMNLPClient) Summarize(ctx context.Context, title, content string) (string, error) {
+	result, err := c.analyze(ctx, title, content)
+	if err != nil || result.Summary == "" {
+		c.logger.Warn().Err(err).Str("title", title).Msg("LLM summarization failed, using leading sentences")
+		return leadingSentences(content, 2), nil
+	}
+	return result.Summary, nil
+}
+
+// ExtractKeywords extracts important keywords from text
+func (c *LLMNLPClient) ExtractKeywords(ctx context.Context, text string) ([]string, error) {
+	result, err := c.analyze(ctx, "", text)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("LLM keyword extraction failed, falling back to simple client")
+		return c.fallback.ExtractKeywords(ctx, text)
+	}
+	return result.Keywords, nil
+}
+
+// ClassifyTopic classifies the topic of the text
+func (c *LLMNLPClient) ClassifyTopic(ctx context.Context, text string) (string, error) {
+	result, err := c.analyze(ctx, "", text)
+	if err != nil || result.Topic == "" {
+		c.logger.Warn().Err(err).Msg("LLM topic classification failed, falling back to simple client")
+		return c.fallback.ClassifyTopic(ctx, text)
+	}
+	return result.Topic, nil
+}
+
+// CalculateImportance calculates the importance score of the content
+func (c *LLMNLPClient) CalculateImportance(ctx context.Context, title, content string) (float64, error) {
+	result, err := c.analyze(ctx, title, content)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("LLM importance scoring failed, falling back to simple client")
+		return c.fallback.CalculateImportance(ctx, title, content)
+	}
+	return clamp(result.Importance, 0.0, 1.0), nil
+}
+
+// analyze sends a single chat completion request and decodes the JSON answer
+func (c *LLMNLPClient) analyze(ctx context.Context, title, content string) (*llmAnalysis, error) {
+	if c.endpoint == "" {
+		return nil, fmt.Errorf("llm endpoint not configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	content = truncateUTF8(content, maxLLMContentLength)
+
+	reqBody := chatCompletionRequest{
+		Model: c.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: analysisPrompt},
+			{Role: "user", Content: fmt.Sprintf("Title: %s\n\n%s", title, content)},
+		},
+		Temperature:    0,
+		ResponseFormat: map[string]string{"type": "json_object"},
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call llm endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read llm response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("llm endpoint returned status %d", resp.StatusCode)
+	}
+
+	var completion chatCompletionResponse
+	if err := json.Unmarshal(body, &completion); err != nil {
+		return nil, fmt.Errorf("failed to parse llm response: %w", err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("llm response contained no choices")
+	}
+
+	var result llmAnalysis
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse llm analysis: %w", err)
+	}
+
+	return &result, nil
+}
+
+// clamp restricts a value to the given range
+func clamp(value, min, max float64) float64 {
+	if math.IsNaN(value) {
+		return min
+	}
+	return math.Max(min, math.Min(max, value))
+}
+
+// leadingSentences returns the first n sentences of the text
+func leadingSentences(text string, n int) string {
+	sentences := strings.SplitAfter(text, ". ")
+	if len(sentences) > n {
+		sentences = sentences[:n]
+	}
+	return strings.TrimSpace(strings.Join(sentences, ""))
+}
+
+// truncateUTF8 shortens s to at most maxBytes bytes without splitting a
+// multi-byte rune
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateUTF8KeepsRunesWhole(t *testing.T) {
+	// "é" is two bytes, so a 5-byte cut would land inside the third rune
+	text := strings.Repeat("é", 4)
+
+	got := truncateUTF8(text, 5)
+	if got != "éé" || !utf8.ValidString(got) {
+		t.Errorf("truncateUTF8 = %q, want %q", got, "éé")
+	}
+	if got := truncateUTF8(text, len(text)); got != text {
+		t.Errorf("truncateUTF8 at full length = %q, want it unchanged", got)
+	}
+	if got := truncateUTF8("abcdef", 3); got != "abc" {
+		t.Errorf("truncateUTF8 ASCII = %q, want %q", got, "abc")
+	}
+}
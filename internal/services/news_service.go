@@ -2,13 +2,21 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"news-aggregator/internal/config"
+	"news-aggregator/internal/datasources/core"
+	"news-aggregator/internal/datasources/utils"
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
+	sourceModels "news-aggregator/internal/models/source"
 	"news-aggregator/internal/repository"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
@@ -31,6 +39,21 @@ func NewNewsService(cfg *config.Config, logger zerolog.Logger) (*NewsService, er
 	}, nil
 }
 
+// NewNewsServiceWithPool creates a news service whose repository uses a
+// shared connection pool.
+func NewNewsServiceWithPool(cfg *config.Config, pool *pgxpool.Pool, logger zerolog.Logger) (*NewsService, error) {
+	repo, err := repository.NewNewsRepositoryWithPool(pool, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create news repository: %w", err)
+	}
+
+	return &NewsService{
+		config:     cfg,
+		logger:     logger,
+		repository: repo,
+	}, nil
+}
+
 func (s *NewsService) GetNews(ctx context.Context, filter models.NewsFilter) ([]models.News, int, error) {
 	s.logger.Debug().
 		Int("page", filter.Page).
@@ -45,6 +68,7 @@ func (s *NewsService) GetNews(ctx context.Context, filter models.NewsFilter) ([]
 		return nil, 0, fmt.Errorf("failed to get news: %w", err)
 	}
 
+	setExpiry(s.config, news)
 	return news, total, nil
 }
 
@@ -57,6 +81,69 @@ func (s *NewsService) GetNewsByID(ctx context.Context, id string) (*models.News,
 		return nil, fmt.Errorf("failed to get news by ID: %w", err)
 	}
 
+	news.SetExpiry(s.config.RetentionFor(news.Source))
+	return news, nil
+}
+
+// GetPopularNews returns the articles of the configured popular window
+// ranked by engagement (see config.PopularConfig).
+func (s *NewsService) GetPopularNews(ctx context.Context, page, limit int) ([]models.News, int, error) {
+	s.logger.Debug().Int("page", page).Int("limit", limit).Msg("Getting popular news")
+
+	since := time.Now().Add(-s.config.Popular.Window)
+	news, total, err := s.repository.GetPopularNews(ctx, since, s.config.Popular, page, limit)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get popular news")
+		return nil, 0, fmt.Errorf("failed to get popular news: %w", err)
+	}
+
+	setExpiry(s.config, news)
+	return news, total, nil
+}
+
+// GetNewsByURL returns the article stored under url; errors wrap
+// ErrNewsNotFound when there is none.
+func (s *NewsService) GetNewsByURL(ctx context.Context, url string) (*models.News, error) {
+	s.logger.Debug().Str("url", url).Msg("Getting news by URL")
+
+	news, err := s.repository.GetNewsByURL(ctx, url)
+	if err != nil {
+		if !errors.Is(err, newsModels.ErrNewsNotFound) {
+			s.logger.Error().Err(err).Str("url", url).Msg("Failed to get news by URL")
+		}
+		return nil, fmt.Errorf("failed to get news by URL: %w", err)
+	}
+
+	news.SetExpiry(s.config.RetentionFor(news.Source))
+	return news, nil
+}
+
+// GetIDsPublishedSince returns the IDs of the articles published at or
+// after since
+func (s *NewsService) GetIDsPublishedSince(ctx context.Context, since time.Time) ([]string, error) {
+	s.logger.Debug().Time("since", since).Msg("Getting IDs of recent news")
+
+	ids, err := s.repository.GetIDsPublishedSince(ctx, since)
+	if err != nil {
+		s.logger.Error().Err(err).Time("since", since).Msg("Failed to get recent news IDs")
+		return nil, fmt.Errorf("failed to get recent news IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetNewsByIDs returns the articles with the given IDs in request order,
+// leaving out IDs that match no article.
+func (s *NewsService) GetNewsByIDs(ctx context.Context, ids []string) ([]models.News, error) {
+	s.logger.Debug().Int("count", len(ids)).Msg("Getting news by IDs")
+
+	news, err := s.repository.GetNewsByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error().Err(err).Int("count", len(ids)).Msg("Failed to get news by IDs")
+		return nil, fmt.Errorf("failed to get news by IDs: %w", err)
+	}
+
+	setExpiry(s.config, news)
 	return news, nil
 }
 
@@ -64,20 +151,13 @@ func (s *NewsService) CreateNews(ctx context.Context, news *models.News) error {
 	s.logger.Debug().Str("title", news.Title).Str("source", news.Source).Msg("Creating news")
 
 	if err := s.repository.CreateNews(ctx, news); err != nil {
-		// Check if this is a duplicate URL error
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") && 
-		   strings.Contains(err.Error(), "news_url_key") {
-			s.logger.Debug().Str("title", news.Title).Str("url", news.URL).Msg("Duplicate article URL detected, skipping")
-			return nil // Don't treat duplicates as errors
+		// Duplicates are expected when workers race on the same article;
+		// callers can recognize them with errors.Is(err, ErrDuplicateNews)
+		if errors.Is(err, newsModels.ErrDuplicateNews) {
+			s.logger.Debug().Err(err).Str("title", news.Title).Str("url", news.URL).Msg("Duplicate article detected, skipping")
+			return err
 		}
-		
-		// Check if this is a duplicate content hash error
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") && 
-		   strings.Contains(err.Error(), "content_hash") {
-			s.logger.Debug().Str("title", news.Title).Str("hash", news.Hash).Msg("Duplicate article content detected, skipping")
-			return nil // Don't treat duplicates as errors
-		}
-		
+
 		s.logger.Error().Err(err).Str("title", news.Title).Msg("Failed to create news")
 		return fmt.Errorf("failed to create news: %w", err)
 	}
@@ -97,6 +177,20 @@ func (s *NewsService) UpdateNews(ctx context.Context, news *models.News) error {
 	return nil
 }
 
+// PatchNews updates only the given fields of an article. Errors wrap a
+// *PatchError for fields that cannot be updated and ErrNewsNotFound for
+// unknown articles.
+func (s *NewsService) PatchNews(ctx context.Context, id string, fields map[string]interface{}) error {
+	s.logger.Debug().Str("id", id).Int("fields", len(fields)).Msg("Patching news")
+
+	if err := s.repository.PatchNews(ctx, id, fields); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to patch news")
+		return fmt.Errorf("failed to patch news: %w", err)
+	}
+
+	return nil
+}
+
 func (s *NewsService) DeleteNews(ctx context.Context, id string) error {
 	s.logger.Debug().Str("id", id).Msg("Deleting news")
 
@@ -120,10 +214,97 @@ func (s *NewsService) GetCategories(ctx context.Context) ([]models.Category, err
 	return categories, nil
 }
 
+// GetTags returns the most used tags of recent articles with their counts
+func (s *NewsService) GetTags(ctx context.Context, limit int) ([]models.TagCount, error) {
+	s.logger.Debug().Int("limit", limit).Msg("Getting tags")
+
+	tags, err := s.repository.GetTags(ctx, limit)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get tags")
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (s *NewsService) AddCategory(ctx context.Context, req *models.CategoryRequest) (*models.Category, error) {
+	s.logger.Debug().Str("name", req.Name).Msg("Adding category")
+
+	category := &models.Category{
+		Name:        strings.ToLower(strings.TrimSpace(req.Name)),
+		Description: req.Description,
+		Color:       req.Color,
+		Icon:        req.Icon,
+	}
+
+	if err := s.repository.CreateCategory(ctx, category); err != nil {
+		s.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to add category")
+		return nil, fmt.Errorf("failed to add category: %w", err)
+	}
+
+	return category, nil
+}
+
+// UpdateCategory updates a category and returns it together with the
+// articles moved to its new name, if it was renamed.
+func (s *NewsService) UpdateCategory(ctx context.Context, id string, req *models.CategoryRequest) (*models.Category, []models.News, error) {
+	s.logger.Debug().Str("id", id).Str("name", req.Name).Msg("Updating category")
+
+	category := &models.Category{
+		ID:          id,
+		Name:        strings.ToLower(strings.TrimSpace(req.Name)),
+		Description: req.Description,
+		Color:       req.Color,
+		Icon:        req.Icon,
+	}
+
+	moved, err := s.repository.UpdateCategory(ctx, category)
+	if err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to update category")
+		return nil, nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return category, moved, nil
+}
+
+// DeleteCategory deletes a category, moving its articles to reassignTo when
+// given. It returns the articles that were reassigned.
+func (s *NewsService) DeleteCategory(ctx context.Context, id, reassignTo string) ([]models.News, error) {
+	s.logger.Debug().Str("id", id).Str("reassign_to", reassignTo).Msg("Deleting category")
+
+	reassigned, err := s.repository.DeleteCategory(ctx, id, strings.ToLower(strings.TrimSpace(reassignTo)))
+	if err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to delete category")
+		return nil, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return reassigned, nil
+}
+
+// MergeTags rewrites the from tags to to on every article carrying them and
+// returns the articles that changed.
+func (s *NewsService) MergeTags(ctx context.Context, req *models.TagMergeRequest) ([]models.News, error) {
+	s.logger.Debug().Strs("from", req.From).Str("to", req.To).Msg("Merging tags")
+
+	updated, err := s.repository.MergeTags(ctx, req.From, req.To)
+	if err != nil {
+		s.logger.Error().Err(err).Str("to", req.To).Msg("Failed to merge tags")
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	s.logger.Info().Strs("from", req.From).Str("to", req.To).Int("articles", len(updated)).Msg("Tags merged")
+	return updated, nil
+}
+
 func (s *NewsService) GetStats(ctx context.Context) (*models.Stats, error) {
+	return s.GetStatsWithFilter(ctx, models.StatsFilter{})
+}
+
+// GetStatsWithFilter returns statistics over the articles matching the filter
+func (s *NewsService) GetStatsWithFilter(ctx context.Context, filter models.StatsFilter) (*models.Stats, error) {
 	s.logger.Debug().Msg("Getting stats")
 
-	stats, err := s.repository.GetStats(ctx)
+	stats, err := s.repository.GetStatsWithFilter(ctx, filter)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to get stats")
 		return nil, fmt.Errorf("failed to get stats: %w", err)
@@ -132,9 +313,44 @@ func (s *NewsService) GetStats(ctx context.Context) (*models.Stats, error) {
 	return stats, nil
 }
 
+// GetDailyCounts returns per-day article counts in [from, to), including
+// days without articles
+func (s *NewsService) GetDailyCounts(ctx context.Context, from, to time.Time) ([]models.DailyCount, error) {
+	s.logger.Debug().Time("from", from).Time("to", to).Msg("Getting daily counts")
+
+	counts, err := s.repository.GetDailyCounts(ctx, from, to)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get daily counts")
+		return nil, fmt.Errorf("failed to get daily counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetSentimentTrends returns the average sentiment per category of the
+// articles published within window; a zero window uses the configured one
+func (s *NewsService) GetSentimentTrends(ctx context.Context, window time.Duration) ([]models.CategorySentiment, error) {
+	if window <= 0 {
+		window = s.config.Sentiment.Window
+	}
+	s.logger.Debug().Dur("window", window).Msg("Getting sentiment trends")
+
+	sentiments, err := s.repository.GetCategorySentiment(ctx, time.Now().Add(-window))
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get sentiment trends")
+		return nil, fmt.Errorf("failed to get sentiment trends: %w", err)
+	}
+
+	return sentiments, nil
+}
+
 func (s *NewsService) AddSource(ctx context.Context, req *models.SourceRequest) (*models.Source, error) {
 	s.logger.Debug().Str("name", req.Name).Str("url", req.URL).Msg("Adding source")
 
+	if err := validateScheduleRateLimit(req.Type, req.Schedule, req.RateLimit); err != nil {
+		return nil, err
+	}
+
 	source := &models.Source{
 		Name:      req.Name,
 		Type:      req.Type,
@@ -156,6 +372,10 @@ func (s *NewsService) AddSource(ctx context.Context, req *models.SourceRequest)
 func (s *NewsService) UpdateSource(ctx context.Context, id string, req *models.SourceRequest) error {
 	s.logger.Debug().Str("id", id).Str("name", req.Name).Msg("Updating source")
 
+	if err := validateScheduleRateLimit(req.Type, req.Schedule, req.RateLimit); err != nil {
+		return err
+	}
+
 	source := &models.Source{
 		ID:        id,
 		Name:      req.Name,
@@ -175,6 +395,79 @@ func (s *NewsService) UpdateSource(ctx context.Context, id string, req *models.S
 	return nil
 }
 
+// BulkUpdateSources applies partial schedule, rate limit and enabled
+// updates to many sources at once and reports the outcome per source.
+func (s *NewsService) BulkUpdateSources(ctx context.Context, updates []models.SourceBulkUpdate) ([]models.SourceBulkUpdateResult, error) {
+	s.logger.Debug().Int("count", len(updates)).Msg("Bulk updating sources")
+
+	// Check each update against the source's current values so that changing
+	// only the schedule or only the rate limit cannot leave them inconsistent
+	sources, err := s.repository.GetSources(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get sources for bulk update")
+		return nil, fmt.Errorf("failed to bulk update sources: %w", err)
+	}
+	byID := make(map[string]models.Source, len(sources))
+	for _, source := range sources {
+		byID[source.ID] = source
+	}
+
+	rejected := make(map[int]error)
+	valid := make([]models.SourceBulkUpdate, 0, len(updates))
+	for i, update := range updates {
+		current, ok := byID[update.ID]
+		if !ok {
+			valid = append(valid, update)
+			continue
+		}
+		schedule, rateLimit := current.Schedule, current.RateLimit
+		if update.Schedule != nil {
+			schedule = *update.Schedule
+		}
+		if update.RateLimit != nil {
+			rateLimit = *update.RateLimit
+		}
+		if err := validateScheduleRateLimit(current.Type, schedule, rateLimit); err != nil {
+			rejected[i] = err
+			continue
+		}
+		valid = append(valid, update)
+	}
+
+	applied, err := s.repository.BulkUpdateSources(ctx, valid)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to bulk update sources")
+		return nil, fmt.Errorf("failed to bulk update sources: %w", err)
+	}
+
+	// Merge the rejected updates back in request order
+	results := make([]models.SourceBulkUpdateResult, 0, len(updates))
+	for i, update := range updates {
+		if err, ok := rejected[i]; ok {
+			results = append(results, models.SourceBulkUpdateResult{ID: update.ID, Error: err.Error()})
+			continue
+		}
+		results = append(results, applied[0])
+		applied = applied[1:]
+	}
+
+	return results, nil
+}
+
+// validateScheduleRateLimit checks that a source's rate limit fits its type
+// and schedule. Schedules that do not parse are left to the request
+// validation.
+func validateScheduleRateLimit(sourceType, schedule string, rateLimit int) error {
+	interval, err := time.ParseDuration(schedule)
+	if err != nil {
+		return nil
+	}
+	if err := utils.ValidateScheduleRateLimit(core.SourceType(sourceType), interval, float64(rateLimit)); err != nil {
+		return fmt.Errorf("%w: %v", sourceModels.ErrInconsistentRateLimit, err)
+	}
+	return nil
+}
+
 func (s *NewsService) DeleteSource(ctx context.Context, id string) error {
 	s.logger.Debug().Str("id", id).Msg("Deleting source")
 
@@ -198,6 +491,118 @@ func (s *NewsService) GetSources(ctx context.Context) ([]models.Source, error) {
 	return sources, nil
 }
 
+// GetSourceByID returns the source stored under id; errors wrap
+// ErrInvalidSourceID and ErrSourceNotFound
+func (s *NewsService) GetSourceByID(ctx context.Context, id string) (*models.Source, error) {
+	s.logger.Debug().Str("id", id).Msg("Getting source by ID")
+
+	source, err := s.repository.GetSourceByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, sourceModels.ErrSourceNotFound) && !errors.Is(err, sourceModels.ErrInvalidSourceID) {
+			s.logger.Error().Err(err).Str("id", id).Msg("Failed to get source")
+		}
+		return nil, fmt.Errorf("failed to get source: %w", err)
+	}
+
+	return source, nil
+}
+
+// GetSourceArticleStats counts the recently stored articles of the named source
+func (s *NewsService) GetSourceArticleStats(ctx context.Context, source string) (*models.SourceArticleStats, error) {
+	s.logger.Debug().Str("source", source).Msg("Getting source article stats")
+
+	stats, err := s.repository.GetSourceArticleStats(ctx, source)
+	if err != nil {
+		s.logger.Error().Err(err).Str("source", source).Msg("Failed to get source article stats")
+		return nil, fmt.Errorf("failed to get source article stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// RecordSourceFetch stores the outcome of a fetch from source and returns
+// its failure streak; a nil fetchErr records a success. Errors are stored
+// with their category (see core.ClassifyError).
+func (s *NewsService) RecordSourceFetch(ctx context.Context, source *models.Source, fetchErr error) (int, error) {
+	message := ""
+	if fetchErr != nil {
+		message = fetchErr.Error()
+	}
+
+	category := string(core.ClassifyError(fetchErr))
+	failures, err := s.repository.RecordSourceFetch(ctx, source, message, category)
+	if err != nil {
+		s.logger.Error().Err(err).Str("source", source.Name).Msg("Failed to record source fetch")
+		return 0, fmt.Errorf("failed to record source fetch: %w", err)
+	}
+
+	return failures, nil
+}
+
+// DisableSource turns off collection for the named source.
+func (s *NewsService) DisableSource(ctx context.Context, name string) error {
+	s.logger.Debug().Str("name", name).Msg("Disabling source")
+
+	if err := s.repository.DisableSourceByName(ctx, name); err != nil {
+		s.logger.Error().Err(err).Str("name", name).Msg("Failed to disable source")
+		return fmt.Errorf("failed to disable source: %w", err)
+	}
+
+	return nil
+}
+
+// EnableSource re-enables a source and resets its failure streak; errors
+// wrap ErrInvalidSourceID and ErrSourceNotFound
+func (s *NewsService) EnableSource(ctx context.Context, id string) error {
+	s.logger.Debug().Str("id", id).Msg("Enabling source")
+
+	if err := s.repository.EnableSource(ctx, id); err != nil {
+		if !errors.Is(err, sourceModels.ErrSourceNotFound) && !errors.Is(err, sourceModels.ErrInvalidSourceID) {
+			s.logger.Error().Err(err).Str("id", id).Msg("Failed to enable source")
+		}
+		return fmt.Errorf("failed to enable source: %w", err)
+	}
+
+	return nil
+}
+
+// GetSourceHealth reports how many sources are fetching successfully and
+// lists those with recent failures, worst first.
+func (s *NewsService) GetSourceHealth(ctx context.Context) (*models.SourceHealthSummary, error) {
+	s.logger.Debug().Msg("Getting source health")
+
+	sources, err := s.repository.GetSources(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get sources for health summary")
+		return nil, fmt.Errorf("failed to get sources: %w", err)
+	}
+
+	summary := &models.SourceHealthSummary{
+		Total:             len(sources),
+		Failing:           []models.Source{},
+		FailingByCategory: map[string]int{},
+	}
+	for _, source := range sources {
+		if source.ConsecutiveFailures > 0 {
+			summary.Failing = append(summary.Failing, source.WithoutSecrets())
+
+			category := source.LastErrorCategory
+			if category == "" {
+				// Failures recorded before errors were classified
+				category = string(core.ErrorCategoryUnknown)
+			}
+			summary.FailingByCategory[category]++
+		} else {
+			summary.Healthy++
+		}
+	}
+	sort.SliceStable(summary.Failing, func(i, j int) bool {
+		return summary.Failing[i].ConsecutiveFailures > summary.Failing[j].ConsecutiveFailures
+	})
+
+	return summary, nil
+}
+
 func (s *NewsService) CheckDuplicate(ctx context.Context, hash string) (bool, error) {
 	s.logger.Debug().Str("hash", hash).Msg("Checking for duplicate")
 
@@ -210,11 +615,26 @@ func (s *NewsService) CheckDuplicate(ctx context.Context, hash string) (bool, er
 	return exists, nil
 }
 
-// CleanupOldArticles removes articles older than 2 days
+// GetExistingURLs returns the subset of the given URLs that are already
+// stored, so clients can skip submitting articles the aggregator has
+func (s *NewsService) GetExistingURLs(ctx context.Context, urls []string) ([]string, error) {
+	s.logger.Debug().Int("count", len(urls)).Msg("Checking existing URLs")
+
+	existing, err := s.repository.GetExistingURLs(ctx, urls)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to check existing URLs")
+		return nil, fmt.Errorf("failed to check existing urls: %w", err)
+	}
+
+	return existing, nil
+}
+
+// CleanupOldArticles removes articles past their configured retention period
 func (s *NewsService) CleanupOldArticles(ctx context.Context) error {
-	s.logger.Info().Msg("Cleaning up old articles (older than 2 days)")
-	
-	if err := s.repository.CleanupOldArticles(ctx); err != nil {
+	retention := s.config.ArticleRetention()
+	s.logger.Info().Dur("retention", retention).Msg("Cleaning up old articles")
+
+	if err := s.repository.CleanupOldArticles(ctx, retention, s.config.SourceRetentions()); err != nil {
 		s.logger.Error().Err(err).Msg("Failed to cleanup old articles")
 		return fmt.Errorf("failed to cleanup old articles: %w", err)
 	}
@@ -222,6 +642,66 @@ func (s *NewsService) CleanupOldArticles(ctx context.Context) error {
 	return nil
 }
 
+// setExpiry fills ExpiresAt on each article from the retention period that
+// applies to its source.
+func setExpiry(cfg *config.Config, items []models.News) {
+	for i := range items {
+		items[i].SetExpiry(cfg.RetentionFor(items[i].Source))
+	}
+}
+
+// GetBackfillPosition returns where an interrupted backfill of the source
+// stopped, or an empty string when there is nothing to resume
+func (s *NewsService) GetBackfillPosition(ctx context.Context, source string) (string, error) {
+	position, err := s.repository.GetBackfillPosition(ctx, source)
+	if err != nil {
+		s.logger.Error().Err(err).Str("source", source).Msg("Failed to get backfill position")
+		return "", fmt.Errorf("failed to get backfill position: %w", err)
+	}
+	return position, nil
+}
+
+// SaveBackfillPosition records the next archive position to backfill
+func (s *NewsService) SaveBackfillPosition(ctx context.Context, source, position string) error {
+	if err := s.repository.SaveBackfillPosition(ctx, source, position); err != nil {
+		s.logger.Error().Err(err).Str("source", source).Msg("Failed to save backfill position")
+		return fmt.Errorf("failed to save backfill position: %w", err)
+	}
+	return nil
+}
+
+// ClearBackfillPosition forgets the backfill progress of the source
+func (s *NewsService) ClearBackfillPosition(ctx context.Context, source string) error {
+	if err := s.repository.ClearBackfillPosition(ctx, source); err != nil {
+		s.logger.Error().Err(err).Str("source", source).Msg("Failed to clear backfill position")
+		return fmt.Errorf("failed to clear backfill position: %w", err)
+	}
+	return nil
+}
+
+// AddIngestCounts adds counts to today's ingestion statistics of the source
+func (s *NewsService) AddIngestCounts(ctx context.Context, source string, counts models.SourceIngestCounts) error {
+	if err := s.repository.AddIngestCounts(ctx, source, counts); err != nil {
+		s.logger.Error().Err(err).Str("source", source).Msg("Failed to add ingest counts")
+		return fmt.Errorf("failed to add ingest counts: %w", err)
+	}
+	return nil
+}
+
+// GetIngestStats returns the daily ingestion statistics of one source, or
+// of all sources when source is empty, in [from, to)
+func (s *NewsService) GetIngestStats(ctx context.Context, source string, from, to time.Time) ([]models.SourceIngestStat, error) {
+	s.logger.Debug().Str("source", source).Time("from", from).Time("to", to).Msg("Getting ingest stats")
+
+	stats, err := s.repository.GetIngestStats(ctx, source, from, to)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get ingest stats")
+		return nil, fmt.Errorf("failed to get ingest stats: %w", err)
+	}
+
+	return stats, nil
+}
+
 // GetRepository returns the news repository for use by other services
 func (s *NewsService) GetRepository() *repository.NewsRepository {
 	return s.repository
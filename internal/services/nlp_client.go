@@ -7,6 +7,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"news-aggregator/internal/models"
 
@@ -15,13 +17,20 @@ import (
 
 // SimpleNLPClient provides basic NLP functionality without external dependencies
 type SimpleNLPClient struct {
-	logger zerolog.Logger
+	logger    zerolog.Logger
+	stopWords map[string]map[string]bool
 }
 
-// NewSimpleNLPClient creates a new simple NLP client
-func NewSimpleNLPClient(logger zerolog.Logger) *SimpleNLPClient {
+// keywordPattern matches words in any script; marks are included so that
+// Devanagari vowel signs do not split words
+var keywordPattern = regexp.MustCompile(`[\p{L}\p{M}]+`)
+
+// NewSimpleNLPClient creates a new simple NLP client. extraStopWords adds
+// words, keyed by language code, that are never extracted as keywords.
+func NewSimpleNLPClient(logger zerolog.Logger, extraStopWords map[string][]string) *SimpleNLPClient {
 	return &SimpleNLPClient{
-		logger: logger.With().Str("component", "nlp_client").Logger(),
+		logger:    logger.With().Str("component", "nlp_client").Logger(),
+		stopWords: buildStopWords(extraStopWords),
 	}
 }
 
@@ -243,27 +252,19 @@ func (c *SimpleNLPClient) countSyllables(word string) int {
 func (c *SimpleNLPClient) extractKeywords(text string) []string {
 	text = strings.ToLower(text)
 
-	// Remove common stop words
-	stopWords := map[string]bool{
-		"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
-		"in": true, "on": true, "at": true, "to": true, "for": true, "of": true,
-		"with": true, "by": true, "from": true, "up": true, "about": true, "into": true,
-		"through": true, "during": true, "before": true, "after": true, "above": true,
-		"below": true, "between": true, "among": true, "is": true, "are": true, "was": true,
-		"were": true, "be": true, "been": true, "being": true, "have": true, "has": true,
-		"had": true, "do": true, "does": true, "did": true, "will": true, "would": true,
-		"could": true, "should": true, "may": true, "might": true, "must": true,
-		"this": true, "that": true, "these": true, "those": true, "i": true, "me": true,
-		"my": true, "myself": true, "we": true, "our": true, "ours": true, "ourselves": true,
-		"you": true, "your": true, "yours": true, "yourself": true, "yourselves": true,
-		"he": true, "him": true, "his": true, "himself": true, "she": true, "her": true,
-		"hers": true, "herself": true, "it": true, "its": true, "itself": true,
-		"they": true, "them": true, "their": true, "theirs": true, "themselves": true,
-	}
-
-	// Extract words
-	wordRegex := regexp.MustCompile(`\b[a-zA-Z]{3,}\b`)
-	words := wordRegex.FindAllString(text, -1)
+	// Drop the common words of the text's language
+	stopWords, ok := c.stopWords[detectTextLanguage(text)]
+	if !ok {
+		stopWords = c.stopWords["en"]
+	}
+
+	// Extract words of three or more letters in any script
+	var words []string
+	for _, word := range keywordPattern.FindAllString(text, -1) {
+		if utf8.RuneCountInString(word) >= 3 {
+			words = append(words, word)
+		}
+	}
 
 	// Count word frequency
 	wordCount := make(map[string]int)
@@ -369,53 +370,94 @@ func (c *SimpleNLPClient) classifyTopic(text string) string {
 	return bestTopic
 }
 
-// detectLanguage performs basic language detection
-func (c *SimpleNLPClient) detectLanguage(text string) string {
-	// Very basic language detection based on common words
-	text = strings.ToLower(text)
-
-	englishWords := []string{"the", "and", "of", "to", "a", "in", "is", "it", "you", "that", "he", "was", "for", "on", "are", "as", "with", "his", "they", "i"}
-	spanishWords := []string{"el", "la", "de", "que", "y", "a", "en", "un", "es", "se", "no", "te", "lo", "le", "da", "su", "por", "son", "con", "para"}
-	frenchWords := []string{"le", "de", "et", "à", "un", "il", "être", "et", "en", "avoir", "que", "pour", "dans", "ce", "son", "une", "sur", "avec", "ne", "se"}
+// languageProfile describes how to recognise a language. Languages with a
+// distinctive script are matched by character range; the rest are scored by
+// stopword hits. Add a profile here to support a new language.
+type languageProfile struct {
+	code      string
+	script    *unicode.RangeTable
+	stopWords map[string]bool
+}
 
-	englishCount := 0
-	spanishCount := 0
-	frenchCount := 0
+// minScriptRatio is the share of letters that must be in a profile's script
+// for the script to decide the language
+const minScriptRatio = 0.3
+
+var languageProfiles = []languageProfile{
+	{code: "hi", script: unicode.Devanagari, stopWords: wordSet("का", "की", "के", "है", "में", "और", "को", "से", "पर", "यह", "था", "हैं", "भी", "एक", "लिए")},
+	{code: "en", stopWords: wordSet("the", "and", "of", "to", "a", "in", "is", "it", "you", "that", "he", "was", "for", "on", "are", "as", "with", "his", "they", "i")},
+	{code: "es", stopWords: wordSet("el", "la", "de", "que", "y", "en", "un", "es", "se", "no", "lo", "le", "su", "por", "son", "con", "para", "los", "las", "una")},
+	{code: "fr", stopWords: wordSet("le", "de", "et", "à", "un", "il", "être", "en", "que", "pour", "dans", "ce", "une", "sur", "avec", "ne", "se", "les", "des", "est")},
+	{code: "de", stopWords: wordSet("der", "die", "und", "das", "ist", "nicht", "ein", "eine", "zu", "den", "von", "mit", "sich", "des", "auf", "für", "im", "dem", "auch", "wird")},
+	{code: "pt", stopWords: wordSet("o", "de", "que", "e", "do", "da", "em", "um", "para", "é", "com", "não", "uma", "os", "no", "na", "por", "mais", "as", "dos")},
+}
 
-	words := strings.Fields(text)
+// wordSet builds a lookup set from a list of words
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
 	for _, word := range words {
-		word = strings.Trim(word, ".,!?;:")
+		set[word] = true
+	}
+	return set
+}
 
-		for _, engWord := range englishWords {
-			if word == engWord {
-				englishCount++
-				break
+// detectLanguage performs basic language detection using script ranges
+// first and stopword frequency second
+func (c *SimpleNLPClient) detectLanguage(text string) string {
+	return detectTextLanguage(text)
+}
+
+// detectTextLanguage returns the ISO 639-1 code of the languageProfiles
+// entry that best matches text, defaulting to English
+func detectTextLanguage(text string) string {
+	text = strings.ToLower(text)
+
+	// Script detection: a distinctive script is a strong signal on its own
+	letters := 0
+	scriptCounts := make(map[string]int)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, profile := range languageProfiles {
+			if profile.script != nil && unicode.Is(profile.script, r) {
+				scriptCounts[profile.code]++
 			}
 		}
+	}
 
-		for _, spaWord := range spanishWords {
-			if word == spaWord {
-				spanishCount++
-				break
+	if letters > 0 {
+		for _, profile := range languageProfiles {
+			if float64(scriptCounts[profile.code])/float64(letters) >= minScriptRatio {
+				return profile.code
 			}
 		}
+	}
 
-		for _, freWord := range frenchWords {
-			if word == freWord {
-				frenchCount++
-				break
+	// Stopword scoring for Latin-script languages
+	scores := make(map[string]int)
+	for _, word := range strings.Fields(text) {
+		word = strings.Trim(word, ".,!?;:\"'()«»„“”")
+		for _, profile := range languageProfiles {
+			if profile.stopWords[word] {
+				scores[profile.code]++
 			}
 		}
 	}
 
-	// Return language with highest count
-	if englishCount >= spanishCount && englishCount >= frenchCount {
-		return "en"
-	} else if spanishCount >= frenchCount {
-		return "es"
-	} else if frenchCount > 0 {
-		return "fr"
+	// Return language with highest count; profile order breaks ties
+	best := ""
+	bestScore := 0
+	for _, profile := range languageProfiles {
+		if scores[profile.code] > bestScore {
+			best = profile.code
+			bestScore = scores[profile.code]
+		}
 	}
 
-	return "en" // Default to English
+	if best == "" {
+		return "en" // Default to English
+	}
+	return best
 }
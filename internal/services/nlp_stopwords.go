@@ -0,0 +1,80 @@
+package services
+
+import "strings"
+
+// keywordStopWords lists, per ISO 639-1 code, the common words that are
+// never keywords. They are longer than the stopwords of languageProfiles,
+// which only need to be frequent enough to tell languages apart. Languages
+// without a list use English; NLPConfig.StopWords adds words per language.
+var keywordStopWords = map[string][]string{
+	"en": {
+		"the", "a", "an", "and", "or", "but", "in", "on", "at", "to", "for", "of",
+		"with", "by", "from", "up", "about", "into", "through", "during", "before", "after",
+		"above", "below", "between", "among", "is", "are", "was", "were", "be", "been",
+		"being", "have", "has", "had", "do", "does", "did", "will", "would", "could",
+		"should", "may", "might", "must", "this", "that", "these", "those", "i", "me",
+		"my", "myself", "we", "our", "ours", "ourselves", "you", "your", "yours", "yourself",
+		"yourselves", "he", "him", "his", "himself", "she", "her", "hers", "herself", "it",
+		"its", "itself", "they", "them", "their", "theirs", "themselves",
+	},
+	"hi": {
+		"का", "की", "के", "है", "में", "और", "को", "से", "पर", "यह", "था", "थी", "थे", "हैं",
+		"भी", "एक", "लिए", "कि", "जो", "कर", "ने", "तो", "ही", "या", "हो", "गया", "गई", "किया",
+		"करने", "करते", "रहा", "रही", "रहे", "साथ", "बाद", "अपने", "अपनी", "उनके", "उनकी", "इस",
+		"उस", "वह", "वे", "ये", "कहा", "होता", "होती", "होने", "सकता", "सकती", "जब", "तक",
+		"कुछ", "सभी", "बहुत", "लेकिन", "अब", "यहां", "वहां", "द्वारा", "दिया", "हुए", "हुआ", "हुई",
+	},
+	"es": {
+		"el", "la", "de", "que", "y", "en", "un", "es", "se", "no", "lo", "le", "su", "por",
+		"son", "con", "para", "los", "las", "una", "del", "al", "como", "más", "pero", "sus",
+		"este", "esta", "estos", "estas", "ese", "esa", "eso", "fue", "ha", "han", "hay",
+		"ser", "sido", "está", "están", "sobre", "entre", "cuando", "también", "sin", "muy",
+		"desde", "hasta", "todo", "todos", "porque", "donde", "quien", "otro", "otra", "según",
+		"tras", "durante", "ante", "nos", "les", "ya", "era", "será", "había",
+	},
+	"fr": {
+		"le", "de", "et", "à", "un", "il", "être", "en", "que", "pour", "dans", "ce", "une",
+		"sur", "avec", "ne", "se", "les", "des", "est", "la", "du", "au", "aux", "pas", "par",
+		"plus", "qui", "sont", "mais", "ou", "ont", "été", "cette", "ces", "son", "sa", "ses",
+		"leur", "leurs", "nous", "vous", "ils", "elle", "elles", "fait", "comme", "tout",
+		"tous", "aussi", "entre", "après", "avant", "depuis", "selon", "sans", "sous", "très",
+		"deux", "lors", "dont", "avait", "était",
+	},
+	"de": {
+		"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "zu", "den", "von", "mit",
+		"sich", "des", "auf", "für", "im", "dem", "auch", "wird", "in", "es", "an", "als",
+		"bei", "nach", "aus", "wie", "oder", "aber", "noch", "nur", "einer", "einem", "einen",
+		"eines", "sind", "war", "hat", "haben", "wurde", "wurden", "werden", "kann", "über",
+		"vor", "zum", "zur", "um", "durch", "sie", "er", "wir", "ihr", "ihre", "sein", "seine",
+		"diese", "dieser", "dieses", "schon", "mehr", "sehr", "bis", "gegen",
+	},
+	"pt": {
+		"o", "de", "que", "e", "do", "da", "em", "um", "para", "é", "com", "não", "uma", "os",
+		"no", "na", "por", "mais", "as", "dos", "das", "ao", "aos", "se", "como", "mas", "foi",
+		"ele", "ela", "eles", "elas", "seu", "sua", "seus", "suas", "isso", "este", "esta",
+		"esse", "essa", "são", "ser", "tem", "têm", "já", "também", "entre", "quando", "sobre",
+		"após", "até", "sem", "muito", "pelo", "pela", "pelos", "pelas", "nos", "nas", "há",
+		"segundo", "foram", "está", "estão",
+	},
+}
+
+// buildStopWords merges the built-in keyword stopwords with extra words
+// per language, lowercasing the extras so they match lowercased text
+func buildStopWords(extra map[string][]string) map[string]map[string]bool {
+	stopWords := make(map[string]map[string]bool, len(keywordStopWords)+len(extra))
+	for language, words := range keywordStopWords {
+		stopWords[language] = wordSet(words...)
+	}
+
+	for language, words := range extra {
+		language = strings.ToLower(language)
+		if stopWords[language] == nil {
+			stopWords[language] = make(map[string]bool, len(words))
+		}
+		for _, word := range words {
+			stopWords[language][strings.ToLower(strings.TrimSpace(word))] = true
+		}
+	}
+
+	return stopWords
+}
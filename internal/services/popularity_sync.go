@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"news-aggregator/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+// PopularitySync copies article final scores into the search index so search
+// ranking can reflect popularity. Each run only sends scores that changed
+// since the previous one.
+type PopularitySync struct {
+	scoringRepo *repository.ScoringRepository
+	searchRepo  *repository.SearchRepository
+	interval    time.Duration
+	logger      zerolog.Logger
+
+	mu       sync.Mutex
+	lastSync time.Time
+}
+
+// NewPopularitySync creates a sync running every interval (10 minutes when
+// unset). The gateway starts it when a popularity weight is configured.
+func NewPopularitySync(scoringRepo *repository.ScoringRepository, searchRepo *repository.SearchRepository, interval time.Duration, logger zerolog.Logger) *PopularitySync {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+
+	return &PopularitySync{
+		scoringRepo: scoringRepo,
+		searchRepo:  searchRepo,
+		interval:    interval,
+		logger:      logger.With().Str("service", "popularity_sync").Logger(),
+	}
+}
+
+// Start syncs scores immediately and then every interval until the context
+// is cancelled
+func (ps *PopularitySync) Start(ctx context.Context) {
+	ps.logger.Info().Dur("interval", ps.interval).Msg("Starting popularity sync")
+
+	go func() {
+		if err := ps.Sync(ctx); err != nil {
+			ps.logger.Error().Err(err).Msg("Initial popularity sync failed")
+		}
+
+		ticker := time.NewTicker(ps.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := ps.Sync(ctx); err != nil {
+					ps.logger.Error().Err(err).Msg("Failed to sync popularity")
+				}
+			case <-ctx.Done():
+				ps.logger.Info().Msg("Popularity sync stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Sync pushes scores updated since the last successful sync to the index.
+// The watermark only advances once the index update succeeded, so failed
+// runs are retried on the next tick.
+func (ps *PopularitySync) Sync(ctx context.Context) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	scores, latest, err := ps.scoringRepo.GetScoresUpdatedSince(ctx, ps.lastSync)
+	if err != nil {
+		return fmt.Errorf("failed to load changed scores: %w", err)
+	}
+
+	if len(scores) == 0 {
+		return nil
+	}
+
+	if err := ps.searchRepo.UpdatePopularity(ctx, scores); err != nil {
+		return fmt.Errorf("failed to update index popularity: %w", err)
+	}
+
+	ps.lastSync = latest
+	ps.logger.Debug().Int("articles", len(scores)).Time("watermark", latest).Msg("Popularity synced")
+	return nil
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"news-aggregator/internal/config"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	redditTokenURL = "https://www.reddit.com/api/v1/access_token"
+	redditAPIURL   = "https://oauth.reddit.com"
+
+	// redditTokenMargin renews the access token this long before it expires
+	redditTokenMargin = time.Minute
+
+	// defaultRedditCacheTTL is used when no cache TTL is configured
+	defaultRedditCacheTTL = 15 * time.Minute
+
+	// maxCachedRedditScores bounds the score cache; when it is full, expired
+	// entries are swept and, if that is not enough, arbitrary entries dropped
+	maxCachedRedditScores = 10000
+)
+
+// RedditClient looks up how much discussion a URL got on Reddit using
+// app-only OAuth, which has a far higher rate limit than the public API.
+// Scores are cached per URL.
+type RedditClient struct {
+	logger       zerolog.Logger
+	httpClient   *http.Client
+	clientID     string
+	clientSecret string
+	userAgent    string
+	cacheTTL     time.Duration
+
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedRedditScore
+}
+
+type cachedRedditScore struct {
+	score     int64
+	fetchedAt time.Time
+}
+
+// NewRedditClient creates a Reddit client, or returns nil when no client ID
+// is configured
+func NewRedditClient(cfg config.RedditConfig, logger zerolog.Logger) *RedditClient {
+	if cfg.ClientID == "" {
+		return nil
+	}
+
+	cacheTTL := cfg.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultRedditCacheTTL
+	}
+
+	return &RedditClient{
+		logger: logger.With().Str("component", "reddit_client").Logger(),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		userAgent:    cfg.UserAgent,
+		cacheTTL:     cacheTTL,
+		cache:        make(map[string]cachedRedditScore),
+	}
+}
+
+// Score returns the summed score and comment count of the Reddit
+// submissions linking to articleURL
+func (c *RedditClient) Score(ctx context.Context, articleURL string) (int64, error) {
+	if score, ok := c.cached(articleURL); ok {
+		return score, nil
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/info?limit=100&url=%s", redditAPIURL, url.QueryEscape(articleURL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("reddit request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		// Revoked or expired early; get a new token on the next lookup
+		c.resetToken()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("reddit returned status %d", resp.StatusCode)
+	}
+
+	var listing RedditResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return 0, fmt.Errorf("failed to decode reddit response: %w", err)
+	}
+
+	score := int64(0)
+	for _, child := range listing.Data.Children {
+		score += int64(child.Data.Score) + int64(child.Data.NumComments)
+	}
+
+	c.store(articleURL, score)
+
+	c.logger.Debug().
+		Str("url", articleURL).
+		Int("submissions", len(listing.Data.Children)).
+		Int64("score", score).
+		Msg("Reddit score fetched")
+
+	return score, nil
+}
+
+// cached returns a score fetched less than cacheTTL ago, evicting expired
+// entries as it finds them
+func (c *RedditClient) cached(articleURL string) (int64, bool) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	entry, ok := c.cache[articleURL]
+	if !ok {
+		return 0, false
+	}
+	if time.Since(entry.fetchedAt) >= c.cacheTTL {
+		delete(c.cache, articleURL)
+		return 0, false
+	}
+	return entry.score, true
+}
+
+// store caches the score of articleURL, making room first when the cache
+// is full
+func (c *RedditClient) store(articleURL string, score int64) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if _, ok := c.cache[articleURL]; !ok && len(c.cache) >= maxCachedRedditScores {
+		c.evictLocked()
+	}
+	c.cache[articleURL] = cachedRedditScore{score: score, fetchedAt: time.Now()}
+}
+
+// evictLocked removes expired entries and, if the cache is still full,
+// drops entries until a tenth of it is free. c.cacheMu must be held.
+func (c *RedditClient) evictLocked() {
+	for url, entry := range c.cache {
+		if time.Since(entry.fetchedAt) >= c.cacheTTL {
+			delete(c.cache, url)
+		}
+	}
+
+	for url := range c.cache {
+		if len(c.cache) < maxCachedRedditScores*9/10 {
+			break
+		}
+		delete(c.cache, url)
+	}
+}
+
+// accessToken returns the current app-only token, requesting a new one
+// shortly before it expires
+func (c *RedditClient) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, redditTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.SetBasicAuth(c.clientID, c.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("reddit token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reddit token request returned status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode reddit token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("reddit token request failed: %s", token.Error)
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - redditTokenMargin)
+	return c.token, nil
+}
+
+func (c *RedditClient) resetToken() {
+	c.tokenMu.Lock()
+	c.token = ""
+	c.tokenMu.Unlock()
+}
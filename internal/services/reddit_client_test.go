@@ -0,0 +1,27 @@
+package services
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/config"
+
+	"github.com/rs/zerolog"
+)
+
+func TestRedditScoreCacheIsBounded(t *testing.T) {
+	c := NewRedditClient(config.RedditConfig{ClientID: "test", CacheTTL: time.Hour}, zerolog.Nop())
+
+	for i := 0; i < 2*maxCachedRedditScores; i++ {
+		c.store("https://example.com/"+strconv.Itoa(i), int64(i))
+	}
+	if n := len(c.cache); n > maxCachedRedditScores {
+		t.Errorf("cache holds %d scores, want at most %d", n, maxCachedRedditScores)
+	}
+
+	latest := 2*maxCachedRedditScores - 1
+	if score, ok := c.cached("https://example.com/" + strconv.Itoa(latest)); !ok || score != int64(latest) {
+		t.Errorf("cached(latest) = %d, %v; want %d, true", score, ok, latest)
+	}
+}
@@ -2,15 +2,21 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"runtime/debug"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"news-aggregator/internal/models"
+	newsModels "news-aggregator/internal/models/news"
 	"news-aggregator/internal/repository"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
 )
 
@@ -22,6 +28,106 @@ type ScoringService struct {
 	config       models.TopStoriesConfig
 	nlpClient    NLPClient
 	socialClient SocialMetricsClient
+	analyses     *analysisCache
+
+	// refreshMu serializes score refreshes; refresh is the latest one
+	refreshMu sync.Mutex
+	refresh   *models.ScoreRefresh
+
+	// ctx bounds background refreshes; it is cancelled when the service
+	// owning this one stops
+	ctx context.Context
+}
+
+// contentAnalysisTTL is how long a content analysis is considered fresh
+const contentAnalysisTTL = 24 * time.Hour
+
+// defaultRecencyDecay is the recency decay rate used when the config sets
+// none for the article's category
+const defaultRecencyDecay = 0.1
+
+// defaultSocialRefreshInterval is used when the config sets no
+// SocialRefreshInterval
+const defaultSocialRefreshInterval = 6 * time.Hour
+
+// maxCachedAnalyses bounds the analysis cache; when it is full, expired
+// entries are swept and, if that is not enough, arbitrary entries dropped
+const maxCachedAnalyses = 10000
+
+// analysisCache keeps recent content analyses in process, keyed by article ID,
+// so bulk refreshes don't hit the database or NLP client for every article
+type analysisCache struct {
+	mu      sync.RWMutex
+	entries map[string]*cachedAnalysis
+}
+
+type cachedAnalysis struct {
+	analysis  *models.ContentAnalysis
+	persisted bool
+}
+
+func newAnalysisCache() *analysisCache {
+	return &analysisCache{
+		entries: make(map[string]*cachedAnalysis),
+	}
+}
+
+// get returns a copy of a fresh cached entry, evicting it if it has expired
+func (c *analysisCache) get(articleID string) (cachedAnalysis, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[articleID]
+	var cached cachedAnalysis
+	if ok {
+		cached = *entry
+	}
+	c.mu.RUnlock()
+
+	if !ok {
+		return cachedAnalysis{}, false
+	}
+
+	if time.Since(cached.analysis.ProcessedAt) >= contentAnalysisTTL {
+		c.mu.Lock()
+		delete(c.entries, articleID)
+		c.mu.Unlock()
+		return cachedAnalysis{}, false
+	}
+
+	return cached, true
+}
+
+func (c *analysisCache) set(articleID string, analysis *models.ContentAnalysis, persisted bool) {
+	c.mu.Lock()
+	if _, ok := c.entries[articleID]; !ok && len(c.entries) >= maxCachedAnalyses {
+		c.evictLocked()
+	}
+	c.entries[articleID] = &cachedAnalysis{analysis: analysis, persisted: persisted}
+	c.mu.Unlock()
+}
+
+// evictLocked removes expired entries and, if the cache is still full,
+// drops entries until a tenth of it is free. c.mu must be held.
+func (c *analysisCache) evictLocked() {
+	for id, entry := range c.entries {
+		if time.Since(entry.analysis.ProcessedAt) >= contentAnalysisTTL {
+			delete(c.entries, id)
+		}
+	}
+
+	for id := range c.entries {
+		if len(c.entries) < maxCachedAnalyses*9/10 {
+			break
+		}
+		delete(c.entries, id)
+	}
+}
+
+func (c *analysisCache) markPersisted(articleID string) {
+	c.mu.Lock()
+	if entry, ok := c.entries[articleID]; ok {
+		entry.persisted = true
+	}
+	c.mu.Unlock()
 }
 
 // NLPClient interface for content analysis
@@ -56,9 +162,19 @@ func NewScoringService(
 		config:       config,
 		nlpClient:    nlpClient,
 		socialClient: socialClient,
+		analyses:     newAnalysisCache(),
+		ctx:          context.Background(),
 	}
 }
 
+// Start ties background score refreshes to ctx, so they stop when it is
+// cancelled
+func (s *ScoringService) Start(ctx context.Context) {
+	s.refreshMu.Lock()
+	s.ctx = ctx
+	s.refreshMu.Unlock()
+}
+
 // CalculateTopStories returns top stories using enhanced algorithm
 func (s *ScoringService) CalculateTopStories(ctx context.Context, limit int) ([]models.News, error) {
 	s.logger.Info().Int("limit", limit).Msg("Calculating top stories with enhanced algorithm")
@@ -165,7 +281,7 @@ func (s *ScoringService) calculateSingleArticleScore(ctx context.Context, articl
 	}
 
 	// Calculate recency score
-	recencyScore := s.calculateRecencyScore(article.PublishedAt)
+	recencyScore := s.calculateRecencyScore(article.PublishedAt, article.Category)
 
 	// Calculate weighted final score
 	finalScore := s.calculateWeightedScore(
@@ -176,7 +292,7 @@ func (s *ScoringService) calculateSingleArticleScore(ctx context.Context, articl
 		recencyScore,
 	)
 
-	return &models.ArticleScore{
+	score := &models.ArticleScore{
 		ArticleID:        article.ID,
 		EngagementScore:  engagementScore,
 		CredibilityScore: credibilityScore,
@@ -184,7 +300,18 @@ func (s *ScoringService) calculateSingleArticleScore(ctx context.Context, articl
 		SocialScore:      socialScore,
 		FinalScore:       finalScore,
 		LastUpdated:      time.Now(),
-	}, nil
+	}
+
+	// Keep ranking on the same [0, 1] scale the scores are stored in
+	clamped, err := score.Normalize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to score article %s: %w", article.ID, err)
+	}
+	if len(clamped) > 0 {
+		s.logger.Warn().Str("article_id", article.ID).Strs("scores", clamped).Msg("Clamped out-of-range article scores")
+	}
+
+	return score, nil
 }
 
 // calculateEngagementScore calculates engagement-based score
@@ -224,32 +351,99 @@ func (s *ScoringService) calculateCredibilityScore(ctx context.Context, sourceNa
 
 // calculateContentScore analyzes content importance using NLP
 func (s *ScoringService) calculateContentScore(ctx context.Context, article models.News) (float64, error) {
+	// Check the in-process cache first
+	if entry, ok := s.analyses.get(article.ID); ok {
+		if !entry.persisted {
+			s.persistContentAnalysis(ctx, entry.analysis)
+		}
+		return entry.analysis.ImportanceScore, nil
+	}
+
 	// Check if analysis already exists
 	analysis, err := s.scoringRepo.GetContentAnalysis(ctx, article.ID)
-	if err == nil && time.Since(analysis.ProcessedAt) < 24*time.Hour {
+	if err == nil && time.Since(analysis.ProcessedAt) < contentAnalysisTTL {
+		s.analyses.set(article.ID, analysis, true)
 		return analysis.ImportanceScore, nil
 	}
 
 	// Perform new analysis
-	analysis, err = s.nlpClient.AnalyzeContent(ctx, article.Title, article.Content)
+	analysis, err = s.analyzeArticle(ctx, article)
 	if err != nil {
 		return s.calculateBasicContentScore(article), err
 	}
 
-	// Store analysis results
+	return analysis.ImportanceScore, nil
+}
+
+// analyzeArticle runs the NLP client over an article, caches the analysis
+// and stores it
+func (s *ScoringService) analyzeArticle(ctx context.Context, article models.News) (*models.ContentAnalysis, error) {
+	analysis, err := s.nlpClient.AnalyzeContent(ctx, article.Title, article.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	analysis.ArticleID = article.ID
-	if err := s.scoringRepo.SaveContentAnalysis(ctx, analysis); err != nil {
-		s.logger.Warn().Err(err).Msg("Failed to save content analysis")
+	if analysis.ProcessedAt.IsZero() {
+		analysis.ProcessedAt = time.Now()
 	}
 
-	return analysis.ImportanceScore, nil
+	// Store analysis results before caching so the first computation is
+	// always persisted; failed saves are retried on the next cache hit
+	s.analyses.set(article.ID, analysis, false)
+	s.persistContentAnalysis(ctx, analysis)
+
+	return analysis, nil
+}
+
+// GetContentAnalysis returns the stored NLP analysis of an article,
+// analyzing the article first when it has none. It returns
+// ErrNewsNotFound for unknown articles and malformed IDs.
+func (s *ScoringService) GetContentAnalysis(ctx context.Context, articleID string) (*models.ContentAnalysis, error) {
+	if _, err := uuid.Parse(articleID); err != nil {
+		return nil, newsModels.ErrNewsNotFound
+	}
+
+	if entry, ok := s.analyses.get(articleID); ok {
+		return entry.analysis, nil
+	}
+
+	stored, err := s.scoringRepo.GetContentAnalysis(ctx, articleID)
+	if err == nil {
+		return stored, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get content analysis: %w", err)
+	}
+
+	article, err := s.newsRepo.GetNewsByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := s.analyzeArticle(ctx, *article)
+	if err != nil {
+		s.logger.Error().Err(err).Str("article_id", articleID).Msg("Failed to analyze content")
+		return nil, fmt.Errorf("failed to analyze content: %w", err)
+	}
+
+	return analysis, nil
+}
+
+// persistContentAnalysis saves an analysis and marks its cache entry as persisted
+func (s *ScoringService) persistContentAnalysis(ctx context.Context, analysis *models.ContentAnalysis) {
+	if err := s.scoringRepo.SaveContentAnalysis(ctx, analysis); err != nil {
+		s.logger.Warn().Str("article_id", analysis.ArticleID).Err(err).Msg("Failed to save content analysis")
+		return
+	}
+	s.analyses.markPersisted(analysis.ArticleID)
 }
 
 // calculateSocialScore gets social media engagement score
 func (s *ScoringService) calculateSocialScore(ctx context.Context, url string) (float64, error) {
 	// Check if metrics already exist and are recent
 	metrics, err := s.scoringRepo.GetSocialMetrics(ctx, url)
-	if err == nil && time.Since(metrics.LastFetched) < 6*time.Hour {
+	if err == nil && time.Since(metrics.LastFetched) < s.socialRefreshInterval() {
 		return s.normalizeSocialScore(metrics), nil
 	}
 
@@ -267,8 +461,16 @@ func (s *ScoringService) calculateSocialScore(ctx context.Context, url string) (
 	return s.normalizeSocialScore(metrics), nil
 }
 
+// socialRefreshInterval returns how long stored social metrics stay fresh
+func (s *ScoringService) socialRefreshInterval() time.Duration {
+	if s.config.SocialRefreshInterval > 0 {
+		return s.config.SocialRefreshInterval
+	}
+	return defaultSocialRefreshInterval
+}
+
 // calculateRecencyScore calculates score based on article age
-func (s *ScoringService) calculateRecencyScore(publishedAt time.Time) float64 {
+func (s *ScoringService) calculateRecencyScore(publishedAt time.Time, category string) float64 {
 	age := time.Since(publishedAt)
 	maxAge := s.config.MaxAge
 
@@ -277,13 +479,25 @@ func (s *ScoringService) calculateRecencyScore(publishedAt time.Time) float64 {
 	}
 
 	// Exponential decay: newer articles get higher scores
-	decayRate := 0.1 // Adjust for faster/slower decay
+	decayRate := s.recencyDecay(category)
 	normalizedAge := age.Seconds() / maxAge.Seconds()
 	score := math.Exp(-decayRate * normalizedAge)
 
 	return score
 }
 
+// recencyDecay returns the decay rate configured for the category, falling
+// back to the general rate and then to defaultRecencyDecay
+func (s *ScoringService) recencyDecay(category string) float64 {
+	if decay, ok := s.config.CategoryRecencyDecay[s.normalizeCategory(category)]; ok && decay > 0 {
+		return decay
+	}
+	if s.config.RecencyDecay > 0 {
+		return s.config.RecencyDecay
+	}
+	return defaultRecencyDecay
+}
+
 // calculateWeightedScore combines all scores with configured weights
 func (s *ScoringService) calculateWeightedScore(engagement, credibility, content, social, recency float64) float64 {
 	weights := s.config.ScoringWeights
@@ -464,32 +678,256 @@ func (s *ScoringService) enforceMinimumCategoryDiversity(
 	return currentResult
 }
 
+// AttachScores pairs each article with its stored score using a single batch
+// lookup. Articles without a stored score get a zero score whose ArticleID
+// is empty.
+func (s *ScoringService) AttachScores(ctx context.Context, articles []models.News) ([]ScoredArticle, error) {
+	ids := make([]string, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+	}
+
+	scores, err := s.scoringRepo.GetArticleScores(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article scores: %w", err)
+	}
+
+	result := make([]ScoredArticle, 0, len(articles))
+	for _, article := range articles {
+		scored := ScoredArticle{Article: article}
+		if score, ok := scores[article.ID]; ok {
+			scored.Score = score.FinalScore
+			scored.Scores = *score
+		}
+		result = append(result, scored)
+	}
+
+	return result, nil
+}
+
 // TrackEngagement records user engagement with an article
 func (s *ScoringService) TrackEngagement(ctx context.Context, articleID string, engagementType string, value int64) error {
 	return s.scoringRepo.UpdateEngagementMetrics(ctx, articleID, engagementType, value)
 }
 
-// RefreshScores recalculates scores for all recent articles
+// RecordView counts a view for an article and returns its total view count
+func (s *ScoringService) RecordView(ctx context.Context, articleID string) (int64, error) {
+	return s.scoringRepo.IncrementViewCount(ctx, articleID)
+}
+
+// GetEngagementMetrics returns engagement metrics for an existing article.
+// Articles that exist but have no recorded engagement get zeroed metrics.
+func (s *ScoringService) GetEngagementMetrics(ctx context.Context, articleID string) (*models.EngagementMetrics, error) {
+	if _, err := s.newsRepo.GetNewsByID(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	metrics, err := s.scoringRepo.GetEngagementMetrics(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &models.EngagementMetrics{ArticleID: articleID}, nil
+		}
+		return nil, fmt.Errorf("failed to get engagement metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// GetSocialMetrics returns the social metrics of an existing article,
+// fetching them again when the stored ones are older than the social refresh
+// interval. When the fetch fails the stored metrics are returned as they
+// are, or zeroed metrics if there are none yet.
+func (s *ScoringService) GetSocialMetrics(ctx context.Context, articleID string) (*models.SocialMetrics, error) {
+	article, err := s.newsRepo.GetNewsByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.scoringRepo.GetSocialMetrics(ctx, article.URL)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get social metrics: %w", err)
+		}
+		stored = nil
+	}
+
+	if stored != nil && time.Since(stored.LastFetched) < s.socialRefreshInterval() {
+		return stored, nil
+	}
+
+	if s.socialClient == nil {
+		return socialMetricsOrZero(stored, article), nil
+	}
+
+	fetched, err := s.socialClient.GetSocialMetrics(ctx, article.URL)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("article_id", article.ID).Msg("Failed to refresh social metrics")
+		return socialMetricsOrZero(stored, article), nil
+	}
+
+	fetched.ArticleID = article.ID
+	if err := s.scoringRepo.SaveSocialMetrics(ctx, fetched); err != nil {
+		s.logger.Warn().Err(err).Str("article_id", article.ID).Msg("Failed to save social metrics")
+	}
+
+	return fetched, nil
+}
+
+// socialMetricsOrZero returns the stored metrics, or zeroed metrics for the
+// article when nothing is stored
+func socialMetricsOrZero(stored *models.SocialMetrics, article *models.News) *models.SocialMetrics {
+	if stored != nil {
+		return stored
+	}
+	return &models.SocialMetrics{
+		ArticleID:     article.ID,
+		URL:           article.URL,
+		SentimentData: map[string]float64{},
+	}
+}
+
+// RefreshScores recalculates scores for all recent articles. It returns
+// models.ErrScoreRefreshInProgress when another refresh is running.
 func (s *ScoringService) RefreshScores(ctx context.Context) error {
-	s.logger.Info().Msg("Starting score refresh for all articles")
+	refresh, err := s.beginRefresh()
+	if err != nil {
+		return err
+	}
+	return s.runRefresh(ctx, refresh)
+}
+
+// StartScoreRefresh starts a score refresh in the background and returns
+// it, so its progress can be followed with GetScoreRefresh. It returns
+// models.ErrScoreRefreshInProgress when another refresh is running.
+func (s *ScoringService) StartScoreRefresh() (*models.ScoreRefresh, error) {
+	refresh, err := s.beginRefresh()
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshMu.Lock()
+	ctx := s.ctx
+	s.refreshMu.Unlock()
+
+	// The refresh outlives the request that started it, but not the service
+	go func() {
+		defer s.recoverRefresh(refresh)
+		s.runRefresh(ctx, refresh)
+	}()
+
+	return s.GetScoreRefresh(refresh.ID)
+}
+
+// recoverRefresh marks a background refresh that panicked as failed, so it
+// doesn't stay running and block every later refresh
+func (s *ScoringService) recoverRefresh(refresh *models.ScoreRefresh) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	s.logger.Error().
+		Str("refresh_id", refresh.ID).
+		Interface("panic", r).
+		Bytes("stack", debug.Stack()).
+		Msg("Panic during score refresh")
+	s.finishRefresh(refresh, fmt.Errorf("score refresh panicked: %v", r))
+}
+
+// GetScoreRefresh returns a snapshot of the refresh with the given ID. Only
+// the latest refresh is kept.
+func (s *ScoringService) GetScoreRefresh(id string) (*models.ScoreRefresh, error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.refresh == nil || s.refresh.ID != id {
+		return nil, models.ErrScoreRefreshNotFound
+	}
+	snapshot := *s.refresh
+	return &snapshot, nil
+}
+
+// beginRefresh registers a new running refresh unless one is in progress
+func (s *ScoringService) beginRefresh() (*models.ScoreRefresh, error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.refresh != nil && s.refresh.Status == models.ScoreRefreshRunning {
+		return nil, models.ErrScoreRefreshInProgress
+	}
+
+	s.refresh = &models.ScoreRefresh{
+		ID:        uuid.New().String(),
+		Status:    models.ScoreRefreshRunning,
+		StartedAt: time.Now(),
+	}
+	return s.refresh, nil
+}
+
+// runRefresh rescores the recent articles, recording progress on refresh
+func (s *ScoringService) runRefresh(ctx context.Context, refresh *models.ScoreRefresh) error {
+	s.logger.Info().Str("refresh_id", refresh.ID).Msg("Starting score refresh for all articles")
 
 	articles, err := s.newsRepo.GetRecentArticles(ctx, s.config.MaxAge)
 	if err != nil {
-		return fmt.Errorf("failed to get recent articles: %w", err)
+		err = fmt.Errorf("failed to get recent articles: %w", err)
+		s.finishRefresh(refresh, err)
+		return err
 	}
 
+	s.refreshMu.Lock()
+	refresh.Total = len(articles)
+	s.refreshMu.Unlock()
+
 	for _, article := range articles {
+		// Stop rather than fail every remaining article once cancelled
+		if err := ctx.Err(); err != nil {
+			err = fmt.Errorf("score refresh cancelled: %w", err)
+			s.finishRefresh(refresh, err)
+			return err
+		}
+
+		failed := false
 		score, err := s.calculateSingleArticleScore(ctx, article)
 		if err != nil {
 			s.logger.Warn().Str("article_id", article.ID).Err(err).Msg("Failed to calculate score")
-			continue
+			failed = true
+		} else if err := s.scoringRepo.SaveArticleScore(ctx, score); err != nil {
+			s.logger.Warn().Str("article_id", article.ID).Err(err).Msg("Failed to save score")
+			failed = true
 		}
 
-		if err := s.scoringRepo.SaveArticleScore(ctx, score); err != nil {
-			s.logger.Warn().Str("article_id", article.ID).Err(err).Msg("Failed to save score")
+		s.refreshMu.Lock()
+		refresh.Processed++
+		if failed {
+			refresh.Failed++
 		}
+		s.refreshMu.Unlock()
 	}
 
-	s.logger.Info().Int("articles_processed", len(articles)).Msg("Score refresh completed")
+	s.finishRefresh(refresh, nil)
 	return nil
 }
+
+// finishRefresh marks refresh as completed, or failed when err is set
+func (s *ScoringService) finishRefresh(refresh *models.ScoreRefresh, err error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	now := time.Now()
+	refresh.FinishedAt = &now
+	refresh.Status = models.ScoreRefreshCompleted
+	if err != nil {
+		refresh.Status = models.ScoreRefreshFailed
+		refresh.Error = err.Error()
+		s.logger.Error().Err(err).Str("refresh_id", refresh.ID).Msg("Score refresh failed")
+		return
+	}
+
+	s.logger.Info().
+		Str("refresh_id", refresh.ID).
+		Int("articles_processed", refresh.Processed).
+		Int("articles_failed", refresh.Failed).
+		Dur("duration", now.Sub(refresh.StartedAt)).
+		Msg("Score refresh completed")
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"news-aggregator/internal/models"
+
+	"github.com/rs/zerolog"
+)
+
+func newRecencyTestService(config models.TopStoriesConfig) *ScoringService {
+	config.MaxAge = 24 * time.Hour
+	return NewScoringService(nil, nil, zerolog.Nop(), config, nil, nil)
+}
+
+func TestRecencyScorePrefersNewerArticles(t *testing.T) {
+	tests := map[string]models.TopStoriesConfig{
+		"default decay":  {},
+		"general decay":  {RecencyDecay: 0.3},
+		"category decay": {CategoryRecencyDecay: map[string]float64{"sports": 0.5}},
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newRecencyTestService(config)
+			now := time.Now()
+
+			fresh := s.calculateRecencyScore(now.Add(-time.Hour), "sports")
+			older := s.calculateRecencyScore(now.Add(-12*time.Hour), "sports")
+			if fresh <= older {
+				t.Errorf("1h-old score %v <= 12h-old score %v", fresh, older)
+			}
+			if fresh > 1 || older <= 0 {
+				t.Errorf("scores %v, %v outside (0, 1]", fresh, older)
+			}
+		})
+	}
+}
+
+func TestRecencyScoreCategoryDecay(t *testing.T) {
+	s := newRecencyTestService(models.TopStoriesConfig{
+		CategoryRecencyDecay: map[string]float64{"sports": 0.5},
+	})
+	publishedAt := time.Now().Add(-12 * time.Hour)
+
+	// Sports decays faster than categories using the default rate
+	sports := s.calculateRecencyScore(publishedAt, "sports")
+	politics := s.calculateRecencyScore(publishedAt, "politics")
+	if sports >= politics {
+		t.Errorf("sports score %v >= politics score %v", sports, politics)
+	}
+
+	if got := s.calculateRecencyScore(time.Now().Add(-25*time.Hour), "politics"); got != 0 {
+		t.Errorf("score past max age = %v, want 0", got)
+	}
+}
+
+func TestAnalysisCacheConcurrentAccess(t *testing.T) {
+	cache := newAnalysisCache()
+	cache.set("a", &models.ContentAnalysis{ArticleID: "a", ProcessedAt: time.Now()}, false)
+
+	// Run with -race: reading an entry must not race with marking it persisted
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if entry, ok := cache.get("a"); ok && !entry.persisted {
+					_ = entry.analysis.ArticleID
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cache.markPersisted("a")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if entry, ok := cache.get("a"); !ok || !entry.persisted {
+		t.Errorf("entry = %+v, %v; want a persisted entry", entry, ok)
+	}
+}
+
+func TestAnalysisCacheIsBounded(t *testing.T) {
+	cache := newAnalysisCache()
+	expired := time.Now().Add(-2 * contentAnalysisTTL)
+
+	// Expired entries are swept first
+	for i := 0; i < maxCachedAnalyses; i++ {
+		id := strconv.Itoa(i)
+		cache.set(id, &models.ContentAnalysis{ArticleID: id, ProcessedAt: expired}, true)
+	}
+	cache.set("fresh", &models.ContentAnalysis{ArticleID: "fresh", ProcessedAt: time.Now()}, true)
+	if n := len(cache.entries); n != 1 {
+		t.Errorf("cache holds %d entries after sweeping expired ones, want 1", n)
+	}
+
+	for i := 0; i < 2*maxCachedAnalyses; i++ {
+		id := strconv.Itoa(i)
+		cache.set(id, &models.ContentAnalysis{ArticleID: id, ProcessedAt: time.Now()}, true)
+	}
+	if n := len(cache.entries); n > maxCachedAnalyses {
+		t.Errorf("cache holds %d entries, want at most %d", n, maxCachedAnalyses)
+	}
+	if _, ok := cache.get(strconv.Itoa(2*maxCachedAnalyses - 1)); !ok {
+		t.Error("latest entry missing from the cache")
+	}
+}
+
+func TestStartScoreRefreshRecoversFromPanic(t *testing.T) {
+	// Without a news repository the refresh panics on its first query
+	s := newRecencyTestService(models.TopStoriesConfig{})
+
+	refresh, err := s.StartScoreRefresh()
+	if err != nil {
+		t.Fatalf("StartScoreRefresh: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for refresh.Status == models.ScoreRefreshRunning {
+		if time.Now().After(deadline) {
+			t.Fatal("refresh still running after it panicked")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if refresh, err = s.GetScoreRefresh(refresh.ID); err != nil {
+			t.Fatalf("GetScoreRefresh: %v", err)
+		}
+	}
+
+	if refresh.Status != models.ScoreRefreshFailed || refresh.FinishedAt == nil {
+		t.Errorf("refresh = %+v, want a finished failed refresh", refresh)
+	}
+	if _, err := s.beginRefresh(); err != nil {
+		t.Errorf("beginRefresh after a panicked refresh: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"news-aggregator/internal/config"
 	"news-aggregator/internal/models"
@@ -43,6 +44,30 @@ func (s *SearchService) Search(ctx context.Context, query string, page, limit in
 		return nil, 0, fmt.Errorf("search failed: %w", err)
 	}
 
+	setExpiry(s.config, results)
+	return results, total, nil
+}
+
+// SearchInRange performs a basic search over an explicit published date range,
+// overriding the configured search window. Zero times leave that bound open.
+// Exact disables typo-tolerant matching.
+func (s *SearchService) SearchInRange(ctx context.Context, query string, dateFrom, dateTo time.Time, exact bool, page, limit int) ([]models.News, int64, error) {
+	s.logger.Debug().
+		Str("query", query).
+		Bool("exact", exact).
+		Time("date_from", dateFrom).
+		Time("date_to", dateTo).
+		Int("page", page).
+		Int("limit", limit).
+		Msg("Performing search in range")
+
+	results, total, err := s.repository.SearchInRange(ctx, query, dateFrom, dateTo, exact, page, limit)
+	if err != nil {
+		s.logger.Error().Err(err).Str("query", query).Msg("Search failed")
+		return nil, 0, fmt.Errorf("search failed: %w", err)
+	}
+
+	setExpiry(s.config, results)
 	return results, total, nil
 }
 
@@ -51,6 +76,7 @@ func (s *SearchService) AdvancedSearch(ctx context.Context, searchQuery models.S
 		Str("query", searchQuery.Query).
 		Interface("categories", searchQuery.Categories).
 		Interface("sources", searchQuery.Sources).
+		Bool("personalized", searchQuery.IsPersonalized()).
 		Msg("Performing advanced search")
 
 	results, err := s.repository.AdvancedSearch(ctx, searchQuery)
@@ -59,12 +85,73 @@ func (s *SearchService) AdvancedSearch(ctx context.Context, searchQuery models.S
 		return nil, fmt.Errorf("advanced search failed: %w", err)
 	}
 
+	setExpiry(s.config, results.News)
 	return results, nil
 }
 
+// SearchAdvanced runs an advanced search and returns the matching articles
+// together with their total, highlights and facets, so clients can render
+// filters without further requests
+func (s *SearchService) SearchAdvanced(ctx context.Context, searchQuery models.SearchQuery) (*models.SearchResult, error) {
+	s.logger.Debug().
+		Str("query", searchQuery.Query).
+		Interface("categories", searchQuery.Categories).
+		Interface("sources", searchQuery.Sources).
+		Msg("Performing faceted search")
+
+	results, err := s.repository.SearchWithFacets(ctx, searchQuery)
+	if err != nil {
+		s.logger.Error().Err(err).Str("query", searchQuery.Query).Msg("Faceted search failed")
+		return nil, fmt.Errorf("advanced search failed: %w", err)
+	}
+
+	setExpiry(s.config, results.News)
+	return results, nil
+}
+
+// MoreLikeThis returns articles similar in title and content to the
+// article with the given ID. Articles missing from the index have none.
+func (s *SearchService) MoreLikeThis(ctx context.Context, id string, limit int) ([]models.News, error) {
+	s.logger.Debug().Str("id", id).Int("limit", limit).Msg("Finding similar articles")
+
+	results, err := s.repository.MoreLikeThis(ctx, id, limit)
+	if err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("More like this search failed")
+		return nil, fmt.Errorf("failed to find similar articles: %w", err)
+	}
+
+	setExpiry(s.config, results)
+	return results, nil
+}
+
+// PersonalizedSearch runs a faceted advanced search (see SearchAdvanced)
+// using the given user's preferences for boosting when the query asks for
+// personalization. Preferences only affect the ordering of results, not
+// which documents match or the facet counts; a nil prefs (anonymous user)
+// yields the neutral default ordering.
+func (s *SearchService) PersonalizedSearch(ctx context.Context, searchQuery models.SearchQuery, prefs *models.Preferences) (*models.SearchResult, error) {
+	searchQuery.Preferences = nil
+	if searchQuery.Personalize {
+		searchQuery.Preferences = prefs
+	}
+
+	return s.SearchAdvanced(ctx, searchQuery)
+}
+
+// setLanguage detects the language of articles that don't carry one, the
+// same way content analysis fills language_detected, so they are indexed
+// into the matching language sub-fields
+func setLanguage(news *models.News) {
+	if news.Language == "" {
+		news.Language = detectTextLanguage(news.Title + " " + news.Content)
+	}
+}
+
 func (s *SearchService) IndexNews(ctx context.Context, news *models.News) error {
 	s.logger.Debug().Str("id", news.ID).Str("title", news.Title).Msg("Indexing news")
 
+	setLanguage(news)
+
 	if err := s.repository.IndexNews(ctx, news); err != nil {
 		s.logger.Error().Err(err).Str("id", news.ID).Msg("Failed to index news")
 		return fmt.Errorf("failed to index news: %w", err)
@@ -73,9 +160,27 @@ func (s *SearchService) IndexNews(ctx context.Context, news *models.News) error
 	return nil
 }
 
+// BulkIndex indexes a batch of articles in a single request
+func (s *SearchService) BulkIndex(ctx context.Context, items []models.News) error {
+	s.logger.Debug().Int("count", len(items)).Msg("Bulk indexing news")
+
+	for i := range items {
+		setLanguage(&items[i])
+	}
+
+	if err := s.repository.BulkIndex(ctx, items); err != nil {
+		s.logger.Error().Err(err).Int("count", len(items)).Msg("Failed to bulk index news")
+		return fmt.Errorf("failed to bulk index news: %w", err)
+	}
+
+	return nil
+}
+
 func (s *SearchService) UpdateNewsIndex(ctx context.Context, news *models.News) error {
 	s.logger.Debug().Str("id", news.ID).Str("title", news.Title).Msg("Updating news index")
 
+	setLanguage(news)
+
 	if err := s.repository.UpdateNewsIndex(ctx, news); err != nil {
 		s.logger.Error().Err(err).Str("id", news.ID).Msg("Failed to update news index")
 		return fmt.Errorf("failed to update news index: %w", err)
@@ -84,6 +189,47 @@ func (s *SearchService) UpdateNewsIndex(ctx context.Context, news *models.News)
 	return nil
 }
 
+// MissingFromIndex returns the IDs of the articles that are not indexed
+func (s *SearchService) MissingFromIndex(ctx context.Context, ids []string) ([]string, error) {
+	s.logger.Debug().Int("count", len(ids)).Msg("Checking articles against the index")
+
+	missing, err := s.repository.MissingFromIndex(ctx, ids)
+	if err != nil {
+		s.logger.Error().Err(err).Int("count", len(ids)).Msg("Failed to check articles against the index")
+		return nil, fmt.Errorf("failed to check articles against the index: %w", err)
+	}
+
+	return missing, nil
+}
+
+// DeleteOlderThan removes articles published before the cutoff from the index
+func (s *SearchService) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error) {
+	s.logger.Debug().Time("cutoff", cutoff).Msg("Deleting old articles from index")
+
+	deleted, err := s.repository.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		s.logger.Error().Err(err).Time("cutoff", cutoff).Msg("Failed to delete old articles from index")
+		return 0, fmt.Errorf("failed to delete old articles from index: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteExpired removes articles past their configured retention period
+// from the index
+func (s *SearchService) DeleteExpired(ctx context.Context) (int, error) {
+	retention := s.config.ArticleRetention()
+	s.logger.Debug().Dur("retention", retention).Msg("Deleting expired articles from index")
+
+	deleted, err := s.repository.DeleteExpired(ctx, retention, s.config.SourceRetentions())
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to delete expired articles from index")
+		return 0, fmt.Errorf("failed to delete expired articles from index: %w", err)
+	}
+
+	return deleted, nil
+}
+
 func (s *SearchService) DeleteFromIndex(ctx context.Context, newsID string) error {
 	s.logger.Debug().Str("id", newsID).Msg("Deleting from index")
 
@@ -106,3 +252,8 @@ func (s *SearchService) GetSuggestions(ctx context.Context, query string, limit
 
 	return suggestions, nil
 }
+
+// GetRepository returns the search repository for use by other services
+func (s *SearchService) GetRepository() *repository.SearchRepository {
+	return s.repository
+}
@@ -9,15 +9,25 @@ import (
 	"net/url"
 	"time"
 
+	"news-aggregator/internal/config"
 	"news-aggregator/internal/models"
 
 	"github.com/rs/zerolog"
 )
 
+// defaultSocialConcurrency is used when no concurrency limit is configured
+const defaultSocialConcurrency = 4
+
 // SimpleSocialClient provides basic social media metrics collection
 type SimpleSocialClient struct {
 	logger     zerolog.Logger
 	httpClient *http.Client
+
+	// reddit is nil when no Reddit credentials are configured
+	reddit *RedditClient
+
+	// slots holds one token per outbound platform request in flight
+	slots chan struct{}
 }
 
 // NewSimpleSocialClient creates a new simple social media client
@@ -27,7 +37,36 @@ func NewSimpleSocialClient(logger zerolog.Logger) *SimpleSocialClient {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		slots: make(chan struct{}, defaultSocialConcurrency),
+	}
+}
+
+// NewSocialClient creates a social client that uses the credentials and
+// concurrency limit in cfg.SocialMedia where they are set
+func NewSocialClient(cfg *config.Config, logger zerolog.Logger) *SimpleSocialClient {
+	client := NewSimpleSocialClient(logger)
+	client.reddit = NewRedditClient(cfg.SocialMedia.Reddit, logger)
+	if cfg.SocialMedia.MaxConcurrent > 0 {
+		client.slots = make(chan struct{}, cfg.SocialMedia.MaxConcurrent)
+	}
+	return client
+}
+
+// acquire waits for a free request slot. The wait counts against ctx, so
+// a caller's deadline covers both queueing and the request itself; the
+// returned function frees the slot.
+func (c *SimpleSocialClient) acquire(ctx context.Context) (func(), error) {
+	select {
+	case c.slots <- struct{}{}:
+	default:
+		c.logger.Debug().Int("max_concurrent", cap(c.slots)).Msg("Waiting for a social request slot")
+		select {
+		case c.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for social request slot: %w", ctx.Err())
+		}
 	}
+	return func() { <-c.slots }, nil
 }
 
 // GetSocialMetrics retrieves comprehensive social media metrics for a URL
@@ -110,53 +149,26 @@ func (c *SimpleSocialClient) GetFacebookShares(ctx context.Context, articleURL s
 	return shares, nil
 }
 
-// GetRedditScore gets Reddit engagement score
+// GetRedditScore gets Reddit engagement score: the summed score and
+// comment count of submissions linking to the URL. Without Reddit
+// credentials the score is simulated.
 func (c *SimpleSocialClient) GetRedditScore(ctx context.Context, articleURL string) (int64, error) {
-	// Reddit API to search for submissions with this URL
-	// This is a simplified implementation
-
-	redditURL := fmt.Sprintf("https://www.reddit.com/api/info.json?url=%s", url.QueryEscape(articleURL))
-
-	req, err := http.NewRequestWithContext(ctx, "GET", redditURL, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set User-Agent to comply with Reddit API guidelines
-	req.Header.Set("User-Agent", "NewsAggregator/1.0 (by /u/newsaggregator)")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		// If Reddit API fails, return simulated score
+	if c.reddit == nil {
 		return c.simulateRedditScore(articleURL), nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return c.simulateRedditScore(articleURL), nil
+	// Cached scores need no request, so they don't wait for a slot
+	if score, ok := c.reddit.cached(articleURL); ok {
+		return score, nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	release, err := c.acquire(ctx)
 	if err != nil {
-		return c.simulateRedditScore(articleURL), nil
+		return 0, err
 	}
+	defer release()
 
-	var redditResponse RedditResponse
-	if err := json.Unmarshal(body, &redditResponse); err != nil {
-		return c.simulateRedditScore(articleURL), nil
-	}
-
-	totalScore := int64(0)
-	for _, child := range redditResponse.Data.Children {
-		totalScore += int64(child.Data.Score)
-	}
-
-	c.logger.Debug().
-		Str("url", articleURL).
-		Int64("score", totalScore).
-		Msg("Reddit score fetched")
-
-	return totalScore, nil
+	return c.reddit.Score(ctx, articleURL)
 }
 
 // Reddit API response structures
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"news-aggregator/internal/datasources/core"
+	"news-aggregator/internal/datasources/factory"
+	"news-aggregator/internal/datasources/utils"
+	"news-aggregator/internal/models"
+	sourceModels "news-aggregator/internal/models/source"
+)
+
+// sourceNameInvalidChars matches characters not allowed in source names
+var sourceNameInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// ImportSources creates an enabled RSS source for every feed that is not
+// already configured. Feeds are matched against existing sources by URL;
+// schedule and rate limit use the datasources defaults for RSS.
+func (s *NewsService) ImportSources(ctx context.Context, feeds []sourceModels.OPMLFeed) (*models.SourceImportResult, error) {
+	s.logger.Debug().Int("feeds", len(feeds)).Msg("Importing sources")
+
+	existing, err := s.repository.GetSources(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get sources for import")
+		return nil, fmt.Errorf("failed to get sources: %w", err)
+	}
+
+	urls := make(map[string]bool, len(existing))
+	names := make(map[string]bool, len(existing))
+	for _, source := range existing {
+		urls[source.URL] = true
+		names[source.Name] = true
+	}
+
+	result := &models.SourceImportResult{
+		Added:   []string{},
+		Skipped: []string{},
+		Failed:  []sourceModels.ImportFailure{},
+	}
+
+	for _, feed := range feeds {
+		if urls[feed.URL] {
+			result.Skipped = append(result.Skipped, feed.URL)
+			continue
+		}
+
+		if err := utils.ValidateURL(feed.URL); err != nil {
+			result.Failed = append(result.Failed, sourceModels.ImportFailure{URL: feed.URL, Error: err.Error()})
+			continue
+		}
+
+		name := uniqueSourceName(importedSourceName(feed), names)
+		if err := utils.ValidateSourceName(name); err != nil {
+			result.Failed = append(result.Failed, sourceModels.ImportFailure{URL: feed.URL, Error: err.Error()})
+			continue
+		}
+
+		req := &models.SourceRequest{
+			Name:      name,
+			Type:      string(core.SourceTypeRSS),
+			URL:       feed.URL,
+			Schedule:  factory.DefaultScheduleForType(core.SourceTypeRSS).String(),
+			RateLimit: int(factory.DefaultRateLimitForType(core.SourceTypeRSS)),
+			Headers:   map[string]string{},
+			Enabled:   true,
+		}
+		if err := req.Validate(); err != nil {
+			result.Failed = append(result.Failed, sourceModels.ImportFailure{URL: feed.URL, Error: err.Error()})
+			continue
+		}
+
+		if _, err := s.AddSource(ctx, req); err != nil {
+			result.Failed = append(result.Failed, sourceModels.ImportFailure{URL: feed.URL, Error: err.Error()})
+			continue
+		}
+
+		urls[feed.URL] = true
+		names[name] = true
+		result.Added = append(result.Added, feed.URL)
+	}
+
+	s.logger.Info().
+		Int("added", len(result.Added)).
+		Int("skipped", len(result.Skipped)).
+		Int("failed", len(result.Failed)).
+		Msg("Sources imported")
+
+	return result, nil
+}
+
+// ExportSources returns every configured source with credential-bearing
+// headers stripped.
+func (s *NewsService) ExportSources(ctx context.Context) ([]models.Source, error) {
+	s.logger.Debug().Msg("Exporting sources")
+
+	sources, err := s.repository.GetSources(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get sources for export")
+		return nil, fmt.Errorf("failed to get sources: %w", err)
+	}
+
+	exported := make([]models.Source, 0, len(sources))
+	for _, source := range sources {
+		exported = append(exported, source.WithoutSecrets())
+	}
+
+	return exported, nil
+}
+
+// importedSourceName derives a source name from the feed title, falling
+// back to the feed host, in the character set ValidateSourceName accepts.
+func importedSourceName(feed sourceModels.OPMLFeed) string {
+	base := feed.Title
+	if base == "" {
+		if parsed, err := url.Parse(feed.URL); err == nil {
+			base = parsed.Hostname()
+		}
+	}
+
+	name := sourceNameInvalidChars.ReplaceAllString(strings.ToLower(base), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > 90 {
+		name = strings.Trim(name[:90], "-")
+	}
+	if name == "" {
+		name = "feed"
+	}
+	return name
+}
+
+// uniqueSourceName appends a numeric suffix when name is already taken.
+func uniqueSourceName(name string, taken map[string]bool) string {
+	if !taken[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
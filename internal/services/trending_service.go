@@ -2,10 +2,16 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"news-aggregator/internal/config"
 	"news-aggregator/internal/models"
 	"news-aggregator/internal/repository"
 
@@ -16,41 +22,314 @@ type TrendingTopic struct {
 	Name           string    `json:"name"`
 	ArticleCount   int       `json:"article_count"`
 	TodayChange    int       `json:"today_change"`
-	TrendDirection string    `json:"trend_direction"` // "up", "down", "stable"
+	TrendDirection string    `json:"trend_direction"` // "rising", "steady", "falling"
+	PercentChange  float64   `json:"percent_change"`  // change against the previous window
+	Momentum       float64   `json:"momentum"`
 	Percentage     float64   `json:"percentage"`
+	Score          float64   `json:"score"`
 	Category       string    `json:"category,omitempty"`
 	LastUpdated    time.Time `json:"last_updated"`
 }
 
+// maxCachedTrendingTopics is the number of topics kept in the trending cache;
+// requests for fewer are served from its head
+const maxCachedTrendingTopics = 50
+
+// steadyChangeThreshold is the percentage change within which a topic is
+// considered steady rather than rising or falling
+const steadyChangeThreshold = 10.0
+
+// defaultTrendingBlocklist holds generic news vocabulary that is frequent in
+// headlines but never a meaningful topic on its own. It is extended by
+// config.TrendingConfig.Blocklist.
+var defaultTrendingBlocklist = []string{
+	"news", "update", "updates", "says", "said", "report", "reports", "latest",
+	"today", "yesterday", "week", "year", "breaking", "live", "watch", "video",
+	"photos", "according", "after", "over", "more", "first", "last", "people",
+	"world", "time", "just", "what", "why", "when", "here", "could", "would",
+}
+
 type TrendingService struct {
-	newsRepo *repository.NewsRepository
-	logger   zerolog.Logger
+	newsRepo  *repository.NewsRepository
+	config    config.TrendingConfig
+	blocklist map[string]bool
+	logger    zerolog.Logger
+
+	// Cached result of the last computation
+	mu        sync.RWMutex
+	cached    []TrendingTopic
+	updatedAt time.Time
+
+	// refreshMu lets one request recompute expired topics while the others
+	// wait for its result
+	refreshMu sync.Mutex
 }
 
-func NewTrendingService(newsRepo *repository.NewsRepository, logger zerolog.Logger) *TrendingService {
+func NewTrendingService(newsRepo *repository.NewsRepository, cfg config.TrendingConfig, logger zerolog.Logger) *TrendingService {
+	if cfg.Window <= 0 {
+		cfg.Window = 24 * time.Hour
+	}
+	if cfg.Decay < 0 {
+		cfg.Decay = 0
+	}
+	if cfg.MinMentions < 1 {
+		cfg.MinMentions = 1
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 5 * time.Minute
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 2 * cfg.RefreshInterval
+	}
+
+	blocklist := make(map[string]bool, len(defaultTrendingBlocklist)+len(cfg.Blocklist))
+	for _, word := range append(defaultTrendingBlocklist, cfg.Blocklist...) {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			blocklist[word] = true
+		}
+	}
+
 	return &TrendingService{
-		newsRepo: newsRepo,
-		logger:   logger.With().Str("service", "trending").Logger(),
+		newsRepo:  newsRepo,
+		config:    cfg,
+		blocklist: blocklist,
+		logger:    logger.With().Str("service", "trending").Logger(),
+	}
+}
+
+// Start computes the trending topics and keeps recomputing them every
+// refresh interval until the context is cancelled
+func (ts *TrendingService) Start(ctx context.Context) {
+	ts.logger.Info().Dur("refresh_interval", ts.config.RefreshInterval).Msg("Starting trending topics refresher")
+
+	go func() {
+		if err := ts.Refresh(ctx); err != nil {
+			ts.logger.Error().Err(err).Msg("Initial trending topics computation failed")
+		}
+
+		ticker := time.NewTicker(ts.config.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := ts.Refresh(ctx); err != nil {
+					ts.logger.Error().Err(err).Msg("Failed to refresh trending topics")
+				}
+			case <-ctx.Done():
+				ts.logger.Info().Msg("Trending topics refresher stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Refresh recomputes the trending topics and replaces the cached result.
+// The previous result is kept when the computation fails.
+func (ts *TrendingService) Refresh(ctx context.Context) error {
+	topics, err := ts.GetTrendingTopics(ctx, maxCachedTrendingTopics)
+	if err != nil {
+		return fmt.Errorf("failed to compute trending topics: %w", err)
+	}
+
+	ts.mu.Lock()
+	ts.cached = topics
+	ts.updatedAt = time.Now()
+	ts.mu.Unlock()
+
+	ts.logger.Debug().Int("topics_count", len(topics)).Msg("Trending topics cache refreshed")
+	return nil
+}
+
+// GetCachedTrendingTopics returns up to limit topics from the last
+// computation along with the time it ran. The topics are computed on demand
+// when nothing has been cached yet or the cache is older than the cache TTL,
+// which only happens when the background refresher is not keeping up. A
+// failed recompute of an expired cache serves the stale topics.
+func (ts *TrendingService) GetCachedTrendingTopics(ctx context.Context, limit int) ([]TrendingTopic, time.Time, error) {
+	topics, updatedAt := ts.snapshot()
+
+	if updatedAt.IsZero() || time.Since(updatedAt) >= ts.config.CacheTTL {
+		ts.refreshMu.Lock()
+		// Another request may have recomputed while this one waited
+		topics, updatedAt = ts.snapshot()
+		if updatedAt.IsZero() || time.Since(updatedAt) >= ts.config.CacheTTL {
+			if err := ts.Refresh(ctx); err != nil {
+				if updatedAt.IsZero() {
+					ts.refreshMu.Unlock()
+					return nil, time.Time{}, err
+				}
+				ts.logger.Warn().Err(err).Time("updated_at", updatedAt).Msg("Serving expired trending topics")
+			}
+			topics, updatedAt = ts.snapshot()
+		}
+		ts.refreshMu.Unlock()
 	}
+
+	if len(topics) > limit {
+		topics = topics[:limit]
+	}
+
+	return topics, updatedAt, nil
 }
 
-// GetTrendingTopics returns the top trending topics based on article tags and keywords
+// snapshot returns the cached topics and when they were computed
+func (ts *TrendingService) snapshot() ([]TrendingTopic, time.Time) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return ts.cached, ts.updatedAt
+}
+
+// LastUpdated returns when the cached trending topics were last computed
+func (ts *TrendingService) LastUpdated() time.Time {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return ts.updatedAt
+}
+
+// GetTrendingTopics returns the top trending topics. Keywords extracted by
+// content analysis are preferred; when none are available yet it falls back
+// to topics derived from article tags and titles.
 func (ts *TrendingService) GetTrendingTopics(ctx context.Context, limit int) ([]TrendingTopic, error) {
+	topics, err := ts.GetKeywordTrends(ctx, limit)
+	if err != nil {
+		ts.logger.Warn().Err(err).Msg("Keyword trends unavailable, falling back to tag-based topics")
+	} else if len(topics) > 0 {
+		return topics, nil
+	}
+
+	return ts.getTagTrendingTopics(ctx, limit)
+}
+
+// GetKeywordTrends aggregates extracted keywords over the configured window
+// and ranks them by a decayed frequency weighted with their velocity, i.e.
+// how the mention count changed relative to the preceding window.
+func (ts *TrendingService) GetKeywordTrends(ctx context.Context, limit int) ([]TrendingTopic, error) {
+	ts.logger.Debug().Int("limit", limit).Dur("window", ts.config.Window).Msg("Getting keyword trends")
+
+	now := time.Now()
+	windowStart := now.Add(-ts.config.Window)
+
+	mentions, err := ts.newsRepo.GetKeywordMentions(ctx, now.Add(-2*ts.config.Window))
+	if err != nil {
+		return nil, err
+	}
+
+	type keywordStats struct {
+		recent   int
+		prior    int
+		decayed  float64
+		articles map[string]bool
+	}
+
+	stats := make(map[string]*keywordStats)
+	recentArticles := make(map[string]bool)
+
+	for _, mention := range mentions {
+		keyword := ts.normalizeTag(mention.Keyword)
+		if keyword == "" {
+			continue
+		}
+
+		st, ok := stats[keyword]
+		if !ok {
+			st = &keywordStats{articles: make(map[string]bool)}
+			stats[keyword] = st
+		}
+
+		if mention.CreatedAt.Before(windowStart) {
+			st.prior++
+			continue
+		}
+
+		st.recent++
+		st.articles[mention.ArticleID] = true
+		recentArticles[mention.ArticleID] = true
+
+		age := now.Sub(mention.CreatedAt).Seconds() / ts.config.Window.Seconds()
+		st.decayed += math.Exp(-ts.config.Decay * age)
+	}
+
+	var topics []TrendingTopic
+	for keyword, st := range stats {
+		// Skip keywords mentioned by too few articles to count as trending
+		if st.recent == 0 || len(st.articles) < ts.config.MinMentions {
+			continue
+		}
+
+		momentum := velocity(st.recent, st.prior)
+		change := percentChange(st.recent, st.prior)
+		topics = append(topics, TrendingTopic{
+			Name:           keyword,
+			ArticleCount:   len(st.articles),
+			TodayChange:    st.recent - st.prior,
+			TrendDirection: trendDirection(change),
+			PercentChange:  change,
+			Momentum:       momentum,
+			Percentage:     float64(len(st.articles)) / float64(len(recentArticles)) * 100,
+			Score:          st.decayed * (1 + momentum),
+			Category:       ts.categorizeTopics(keyword),
+			LastUpdated:    now,
+		})
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].Score > topics[j].Score
+	})
+
+	if len(topics) > limit {
+		topics = topics[:limit]
+	}
+
+	ts.logger.Info().Int("topics_count", len(topics)).Int("mentions", len(mentions)).Msg("Generated keyword trends")
+	return topics, nil
+}
+
+// velocity returns the relative change between the recent and prior counts
+func velocity(recent, prior int) float64 {
+	return float64(recent-prior) / math.Max(float64(prior), 1)
+}
+
+// percentChange returns the change between the prior and recent counts as a
+// percentage. A topic with no prior mentions counts as a 100% increase.
+func percentChange(recent, prior int) float64 {
+	if prior == 0 {
+		if recent == 0 {
+			return 0
+		}
+		return 100
+	}
+	return float64(recent-prior) / float64(prior) * 100
+}
+
+// trendDirection maps a percentage change to a trend direction
+func trendDirection(change float64) string {
+	if change > steadyChangeThreshold {
+		return "rising"
+	} else if change < -steadyChangeThreshold {
+		return "falling"
+	}
+	return "steady"
+}
+
+// getTagTrendingTopics returns the top trending topics based on article tags and keywords
+func (ts *TrendingService) getTagTrendingTopics(ctx context.Context, limit int) ([]TrendingTopic, error) {
 	ts.logger.Debug().Int("limit", limit).Msg("Getting trending topics")
 
-	// Get recent articles (last 7 days for trend analysis)
-	articles, err := ts.newsRepo.GetRecentArticles(ctx, 7*24*time.Hour)
+	// Get articles from the trending window
+	articles, err := ts.newsRepo.GetRecentArticles(ctx, ts.config.Window)
 	if err != nil {
 		ts.logger.Error().Err(err).Msg("Failed to get recent articles")
 		return nil, err
 	}
 
-	// Get articles from yesterday for comparison
-	yesterdayArticles, err := ts.newsRepo.GetArticlesByDateRange(ctx, 
-		time.Now().Add(-48*time.Hour), 
-		time.Now().Add(-24*time.Hour))
+	// Get articles from the preceding window for comparison
+	now := time.Now()
+	yesterdayArticles, err := ts.newsRepo.GetArticlesByDateRange(ctx,
+		now.Add(-2*ts.config.Window),
+		now.Add(-ts.config.Window))
 	if err != nil {
-		ts.logger.Warn().Err(err).Msg("Failed to get yesterday's articles for comparison")
+		ts.logger.Warn().Err(err).Msg("Failed to get previous window articles for comparison")
 		yesterdayArticles = []models.News{} // Continue without comparison
 	}
 
@@ -61,9 +340,9 @@ func (ts *TrendingService) GetTrendingTopics(ctx context.Context, limit int) ([]
 	// Calculate trending topics
 	trendingTopics := ts.calculateTrending(topicCounts, yesterdayTopicCounts, len(articles))
 
-	// Sort by article count (descending)
+	// Sort by momentum-weighted score so accelerating topics rank first
 	sort.Slice(trendingTopics, func(i, j int) bool {
-		return trendingTopics[i].ArticleCount > trendingTopics[j].ArticleCount
+		return trendingTopics[i].Score > trendingTopics[j].Score
 	})
 
 	// Limit results
@@ -145,8 +424,18 @@ func (ts *TrendingService) normalizeTag(tag string) string {
 	tag = strings.TrimSpace(tag)
 	tag = strings.ToLower(tag)
 
-	// Skip very short or common words
-	if len(tag) < 3 {
+	// Skip very short or common words, including single non-ASCII characters
+	if len(tag) < 3 || utf8.RuneCountInString(tag) < 2 {
+		return ""
+	}
+
+	// Skip pure numbers such as years or counts
+	if isNumeric(tag) {
+		return ""
+	}
+
+	// Skip generic words from the trending blocklist
+	if ts.blocklist[tag] {
 		return ""
 	}
 
@@ -216,28 +505,36 @@ func (ts *TrendingService) normalizeTag(tag string) string {
 	return tag
 }
 
+// isNumeric reports whether the token consists only of digits and number
+// punctuation, e.g. "2024" or "1,000"
+func isNumeric(token string) bool {
+	hasDigit := false
+	for _, r := range token {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case r == '.' || r == ',' || r == '%' || r == '-':
+		default:
+			return false
+		}
+	}
+	return hasDigit
+}
+
 // calculateTrending calculates trending metrics for topics
 func (ts *TrendingService) calculateTrending(currentCounts, yesterdayCounts map[string]int, totalArticles int) []TrendingTopic {
 	var topics []TrendingTopic
 
 	for topic, count := range currentCounts {
-		// Skip topics with very few articles
-		if count < 3 {
+		// Skip topics with too few mentions to count as trending
+		if count < ts.config.MinMentions {
 			continue
 		}
 
 		yesterdayCount := yesterdayCounts[topic]
 		todayChange := count - yesterdayCount
-
-		// Determine trend direction
-		var trendDirection string
-		if todayChange > 0 {
-			trendDirection = "up"
-		} else if todayChange < 0 {
-			trendDirection = "down"
-		} else {
-			trendDirection = "stable"
-		}
+		change := percentChange(count, yesterdayCount)
+		momentum := velocity(count, yesterdayCount)
 
 		// Calculate percentage relative to total articles
 		percentage := float64(count) / float64(totalArticles) * 100
@@ -249,8 +546,11 @@ func (ts *TrendingService) calculateTrending(currentCounts, yesterdayCounts map[
 			Name:           topic,
 			ArticleCount:   count,
 			TodayChange:    todayChange,
-			TrendDirection: trendDirection,
+			TrendDirection: trendDirection(change),
+			PercentChange:  change,
+			Momentum:       momentum,
 			Percentage:     percentage,
+			Score:          float64(count) * (1 + momentum),
 			Category:       category,
 			LastUpdated:    time.Now(),
 		})
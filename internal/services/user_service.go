@@ -10,6 +10,7 @@ import (
 	"news-aggregator/internal/repository"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -33,6 +34,21 @@ func NewUserService(cfg *config.Config, logger zerolog.Logger) (*UserService, er
 	}, nil
 }
 
+// NewUserServiceWithPool creates a user service whose repository uses a
+// shared connection pool.
+func NewUserServiceWithPool(cfg *config.Config, pool *pgxpool.Pool, logger zerolog.Logger) (*UserService, error) {
+	repo, err := repository.NewUserRepositoryWithPool(pool, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user repository: %w", err)
+	}
+
+	return &UserService{
+		config:     cfg,
+		logger:     logger,
+		repository: repo,
+	}, nil
+}
+
 func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest) (*models.User, error) {
 	s.logger.Debug().Str("email", req.Email).Msg("Registering user")
 
@@ -2,20 +2,45 @@ package logger
 
 import (
     "os"
+    "strings"
 
     "github.com/rs/zerolog"
 )
 
-// New creates a zerolog.Logger with the provided level string (e.g., "debug", "info").
-func New(level string) zerolog.Logger {
+// CorrelationIDField is the log field carrying the ID that follows one
+// article through collection, processing and indexing.
+const CorrelationIDField = "correlation_id"
+
+// Output formats accepted by New.
+const (
+    FormatJSON    = "json"
+    FormatConsole = "console"
+)
+
+// New creates a zerolog.Logger with the provided level string (e.g., "debug", "info")
+// and output format ("json" or "console"; anything else falls back to JSON).
+func New(level, format string) zerolog.Logger {
     lvl, err := zerolog.ParseLevel(level)
     if err != nil {
         lvl = zerolog.InfoLevel
     }
 
     zerolog.SetGlobalLevel(lvl)
+
+    if strings.EqualFold(format, FormatConsole) {
+        return zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
+    }
+
     logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
     return logger
 }
 
-
+// WithCorrelationID returns a child logger that tags every entry with the
+// correlation ID, so logs from different services can be joined on it.
+// An empty ID returns the logger unchanged.
+func WithCorrelationID(logger zerolog.Logger, correlationID string) zerolog.Logger {
+    if correlationID == "" {
+        return logger
+    }
+    return logger.With().Str(CorrelationIDField, correlationID).Logger()
+}
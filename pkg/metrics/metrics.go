@@ -0,0 +1,144 @@
+// Package metrics defines the Prometheus metrics for article ingestion and
+// serves them for the services that have no HTTP server of their own.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+// Processing results recorded by ArticlesProcessed
+const (
+	ResultStored    = "stored"
+	ResultUpdated   = "updated"
+	ResultDuplicate = "duplicate"
+	ResultFailed    = "failed"
+)
+
+// Checks that identified an article as a duplicate, recorded by
+// DuplicatesSkipped
+const (
+	MatchContentHash = "content_hash"
+	MatchTitle       = "title"
+	MatchContent     = "content"
+)
+
+var (
+	// ArticlesFetched counts articles returned by source fetches
+	ArticlesFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "news_articles_fetched_total",
+		Help: "Articles returned by source fetches.",
+	}, []string{"source"})
+
+	// SourceFetchErrors counts source fetches that failed after all retries
+	SourceFetchErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "news_source_fetch_errors_total",
+		Help: "Source fetches that failed after all retries.",
+	}, []string{"source"})
+
+	// ArticlesProcessed counts articles handled by the processor by result.
+	// The dedup rate is the share of the duplicate result.
+	ArticlesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "news_articles_processed_total",
+		Help: "Articles handled by the processor, by result (stored, duplicate, failed).",
+	}, []string{"result"})
+
+	// DuplicatesSkipped counts articles the processor skipped before any
+	// work because they were already stored, by the check that matched.
+	// Redelivered messages show up under content_hash.
+	DuplicatesSkipped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "news_duplicates_skipped_total",
+		Help: "Articles skipped as already stored, by matching check (content_hash, url, title, content).",
+	}, []string{"match"})
+
+	// ProcessorPanics counts articles whose processing panicked and that
+	// were dead-lettered
+	ProcessorPanics = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "news_processor_panics_total",
+		Help: "Articles whose processing panicked and were dead-lettered.",
+	})
+
+	// GatewayPanics counts API requests whose handler panicked
+	GatewayPanics = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "news_gateway_panics_total",
+		Help: "API requests whose handler panicked.",
+	})
+
+	// ArticlesIndexed counts articles written to the search index
+	ArticlesIndexed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "news_articles_indexed_total",
+		Help: "Articles written to the search index.",
+	})
+
+	// IngestionLatency observes the time from an article's publication in its
+	// feed until it is stored
+	IngestionLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "news_ingestion_latency_seconds",
+		Help:    "Time from an article's feed publication until it is stored.",
+		Buckets: []float64{60, 300, 900, 1800, 3600, 7200, 21600, 86400},
+	})
+
+	// IndexLatency observes the time from an article's publication in its
+	// feed until it is searchable
+	IndexLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "news_index_latency_seconds",
+		Help:    "Time from an article's feed publication until it is searchable.",
+		Buckets: []float64{60, 300, 900, 1800, 3600, 7200, 21600, 86400},
+	})
+
+	// LastStored is when the processor last stored an article; a value that
+	// stops advancing indicates an ingestion stall
+	LastStored = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "news_last_article_stored_timestamp_seconds",
+		Help: "Unix time the processor last stored an article.",
+	})
+)
+
+// RecordStored records an article the processor has just stored
+func RecordStored(publishedAt time.Time) {
+	ArticlesProcessed.WithLabelValues(ResultStored).Inc()
+	LastStored.SetToCurrentTime()
+	if !publishedAt.IsZero() {
+		IngestionLatency.Observe(time.Since(publishedAt).Seconds())
+	}
+}
+
+// Handler returns the HTTP handler exposing all registered metrics
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve exposes the metrics on addr under path (/metrics when empty) until
+// ctx is cancelled
+func Serve(ctx context.Context, addr, path string, logger zerolog.Logger) {
+	if path == "" {
+		path = "/metrics"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, Handler())
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info().Str("addr", addr).Str("path", path).Msg("Serving metrics")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error().Err(err).Msg("Metrics server failed")
+	}
+}
@@ -27,6 +27,7 @@ CREATE TABLE IF NOT EXISTS news (
     author TEXT,
     source TEXT NOT NULL,
     category TEXT DEFAULT 'general',
+    source_category TEXT,
     tags JSONB DEFAULT '[]',
     published_at TIMESTAMP WITH TIME ZONE NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),